@@ -45,6 +45,10 @@ func NewPocketCoreApp(genState GenesisState, keybase keys.Keybase, tmClient clie
 		authSubspace,
 		moduleAccountPermissions,
 	)
+	// the balance change log is recorded by the account keeper, so it's enabled before the other keepers get a copy
+	if GlobalConfig.PocketConfig.AccountHistory {
+		app.accountKeeper.EnableHistory(app.Tkeys[auth.TStoreKey])
+	}
 	// The nodesKeeper keeper handles pocket core nodes
 	app.nodesKeeper = nodesKeeper.NewKeeper(
 		app.cdc,
@@ -3,8 +3,13 @@ package app
 import (
 	"regexp"
 	"testing"
+	"time"
 
+	"github.com/pokt-network/pocket-core/codec"
+	pocketTypes "github.com/pokt-network/pocket-core/x/pocketcore/types"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	tmTypes "github.com/tendermint/tendermint/types"
 )
 
 /*
@@ -27,3 +32,25 @@ func TestAppVersionIsSensible(t *testing.T) {
 		require.True(t, exp.MatchString(matcher))
 	}
 }
+
+func TestCommitPrefetchSessions(t *testing.T) {
+	codec.UpgradeHeight = 2
+	_ = memCodecMod(true)
+	genBz, _, _, app := fiveValidatorsOneAppGenesis()
+	_, _, cleanup := NewInMemoryTendermintNodeProto(t, genBz)
+	_, stopCli, evtChan := subscribeTo(t, tmTypes.EventNewBlock)
+	// the genesis app is staked for the chain the node hosts, and every validator is in its session
+	header := pocketTypes.SessionHeader{
+		ApplicationPubKey:  app.PublicKey.RawString(),
+		Chain:              dummyChainsHash,
+		SessionBlockHeight: 1,
+	}
+	<-evtChan // Wait for the session block
+	// the sessions are precomputed in the background after the commit
+	assert.Eventually(t, func() bool {
+		_, found := pocketTypes.GetSession(header)
+		return found
+	}, 5*time.Second, 50*time.Millisecond)
+	cleanup()
+	stopCli()
+}
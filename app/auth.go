@@ -0,0 +1,309 @@
+package app
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	sdk "github.com/pokt-network/pocket-core/types"
+	"github.com/tendermint/tendermint/libs/rand"
+)
+
+// the scopes of the private rpc routes
+const (
+	ScopeReadChains  = "read-chains"
+	ScopeWriteChains = "write-chains"
+	ScopeStop        = "stop"
+	ScopeDebug       = "debug"
+	ScopeEarnings    = "earnings" // reserved for the earnings queries
+
+	// LocalTokenName is the name of the token of auth.json, generated at every start for the local cli
+	LocalTokenName = "local"
+)
+
+var (
+	AuthScopes = []string{ScopeReadChains, ScopeWriteChains, ScopeStop, ScopeDebug, ScopeEarnings}
+
+	ErrUnauthorized = errors.New("missing or unknown auth token")
+	ErrForbidden    = errors.New("the auth token does not have the scope of the route")
+)
+
+// APIToken is a named bearer token of the private rpc routes. Only the sha256 hash of the token is stored
+type APIToken struct {
+	Name   string    `json:"name"`
+	Hash   string    `json:"hash"`
+	Scopes []string  `json:"scopes"`
+	Issued time.Time `json:"issued"`
+}
+
+// HasScope returns whether the token grants the scope
+func (t APIToken) HasScope(scope string) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthTokens are the tokens of a tokens file, reloaded whenever the file changes so tokens can be added, rotated
+// and revoked without a restart
+type AuthTokens struct {
+	mu      sync.Mutex
+	path    string
+	modTime time.Time
+	size    int64
+	tokens  []APIToken
+}
+
+func NewAuthTokens(path string) *AuthTokens {
+	return &AuthTokens{path: path}
+}
+
+var (
+	authTokens   *AuthTokens
+	authTokensMu sync.Mutex
+)
+
+// GetAuthTokens returns the tokens of the tokens file of the data directory
+func GetAuthTokens() *AuthTokens {
+	path := GlobalConfig.PocketConfig.DataDir + FS + sdk.ConfigDirName + FS + sdk.AuthTokensFileName
+	authTokensMu.Lock()
+	defer authTokensMu.Unlock()
+	if authTokens == nil || authTokens.path != path {
+		authTokens = NewAuthTokens(path)
+	}
+	return authTokens
+}
+
+// Authorize returns the name of the token of value if it grants the scope. The token of auth.json grants every scope
+func (a *AuthTokens) Authorize(value, scope string) (name string, err error) {
+	if value == "" {
+		return "", ErrUnauthorized
+	}
+	if AuthToken.Value != "" && subtle.ConstantTimeCompare([]byte(value), []byte(AuthToken.Value)) == 1 {
+		return LocalTokenName, nil
+	}
+	tokens, err := a.load()
+	if err != nil {
+		return "", err
+	}
+	hash := hashToken(value)
+	for _, t := range tokens {
+		if subtle.ConstantTimeCompare([]byte(hash), []byte(t.Hash)) != 1 {
+			continue
+		}
+		if !t.HasScope(scope) {
+			return t.Name, ErrForbidden
+		}
+		return t.Name, nil
+	}
+	return "", ErrUnauthorized
+}
+
+// List returns the tokens ordered by name
+func (a *AuthTokens) List() ([]APIToken, error) {
+	return a.load()
+}
+
+// Add creates a token with the scopes and returns its value, which is not stored
+func (a *AuthTokens) Add(name string, scopes []string) (value string, err error) {
+	if name == "" || name == LocalTokenName {
+		return "", fmt.Errorf("invalid token name %q", name)
+	}
+	for _, s := range scopes {
+		if !isAuthScope(s) {
+			return "", fmt.Errorf("unknown scope %s, expected one of %v", s, AuthScopes)
+		}
+	}
+	return a.update(func(tokens []APIToken) ([]APIToken, string, error) {
+		for _, t := range tokens {
+			if t.Name == name {
+				return nil, "", fmt.Errorf("a token named %s already exists", name)
+			}
+		}
+		value := newTokenValue()
+		return append(tokens, APIToken{Name: name, Hash: hashToken(value), Scopes: scopes, Issued: time.Now().UTC()}), value, nil
+	})
+}
+
+// Rotate replaces the value of the token, keeping its scopes, and returns the new value
+func (a *AuthTokens) Rotate(name string) (value string, err error) {
+	return a.update(func(tokens []APIToken) ([]APIToken, string, error) {
+		for i, t := range tokens {
+			if t.Name == name {
+				value := newTokenValue()
+				tokens[i].Hash, tokens[i].Issued = hashToken(value), time.Now().UTC()
+				return tokens, value, nil
+			}
+		}
+		return nil, "", fmt.Errorf("no token named %s", name)
+	})
+}
+
+// Revoke removes the token
+func (a *AuthTokens) Revoke(name string) error {
+	_, err := a.update(func(tokens []APIToken) ([]APIToken, string, error) {
+		for i, t := range tokens {
+			if t.Name == name {
+				return append(tokens[:i], tokens[i+1:]...), "", nil
+			}
+		}
+		return nil, "", fmt.Errorf("no token named %s", name)
+	})
+	return err
+}
+
+// load returns the tokens of the file, reading it again if it changed
+func (a *AuthTokens) load() ([]APIToken, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.loadLocked()
+}
+
+// CONTRACT: a.mu must be held
+func (a *AuthTokens) loadLocked() ([]APIToken, error) {
+	info, err := os.Stat(a.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			a.tokens, a.modTime, a.size = nil, time.Time{}, 0
+			return nil, nil
+		}
+		return nil, err
+	}
+	if info.ModTime().Equal(a.modTime) && info.Size() == a.size && a.tokens != nil {
+		return a.tokens, nil
+	}
+	bz, err := ioutil.ReadFile(a.path)
+	if err != nil {
+		return nil, err
+	}
+	var tokens []APIToken
+	if err = json.Unmarshal(bz, &tokens); err != nil {
+		return nil, fmt.Errorf("invalid auth tokens file %s: %s", a.path, err.Error())
+	}
+	sort.Slice(tokens, func(i, j int) bool { return tokens[i].Name < tokens[j].Name })
+	a.tokens, a.modTime, a.size = tokens, info.ModTime(), info.Size()
+	return tokens, nil
+}
+
+// update reads, modifies and writes the tokens under the lock, so concurrent updates don't drop each other's changes
+func (a *AuthTokens) update(fn func([]APIToken) ([]APIToken, string, error)) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	current, err := a.loadLocked()
+	if err != nil {
+		return "", err
+	}
+	tokens, value, err := fn(append([]APIToken(nil), current...))
+	if err != nil {
+		return "", err
+	}
+	bz, err := json.MarshalIndent(tokens, "", "    ")
+	if err != nil {
+		return "", err
+	}
+	if err = ioutil.WriteFile(a.path+".tmp", bz, 0600); err != nil {
+		return "", err
+	}
+	if err = os.Rename(a.path+".tmp", a.path); err != nil {
+		return "", err
+	}
+	// force a reload, the modification time may not change within its resolution
+	a.tokens = nil
+	return value, nil
+}
+
+func hashToken(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+func newTokenValue() string {
+	return rand.Str(40)
+}
+
+func isAuthScope(scope string) bool {
+	for _, s := range AuthScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// AuditEntry is a line of the audit log of the private rpc calls. An authorized call is logged before it runs (a
+// stop never returns) so it has no status, a rejected call is logged with the status of its rejection
+type AuditEntry struct {
+	Time   time.Time `json:"time"`
+	Token  string    `json:"token,omitempty"`
+	Scope  string    `json:"scope"`
+	Method string    `json:"method"`
+	Path   string    `json:"path"`
+	Remote string    `json:"remote"`
+	Status int       `json:"status,omitempty"`
+}
+
+var (
+	// the audit log is rotated to audit.log.1 (and the older logs to .2 ... ) when it reaches this size
+	auditLogMaxSize int64 = 10 << 20
+	auditLogBackups       = 3
+)
+
+var auditLog = struct {
+	sync.Mutex
+	path string
+	file *os.File
+	size int64
+}{}
+
+// Audit appends the entry to the audit log of the data directory
+func Audit(entry AuditEntry) {
+	bz, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	bz = append(bz, '\n')
+	auditLog.Lock()
+	defer auditLog.Unlock()
+	path := GlobalConfig.PocketConfig.DataDir + FS + sdk.ConfigDirName + FS + sdk.AuditLogFileName
+	if auditLog.file != nil && (auditLog.path != path || auditLog.size+int64(len(bz)) > auditLogMaxSize) {
+		_ = auditLog.file.Close()
+		auditLog.file = nil
+		if auditLog.path == path {
+			rotateAuditLog(path)
+		}
+	}
+	if auditLog.file == nil {
+		auditLog.file, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+		if err != nil {
+			auditLog.file = nil
+			fmt.Println("unable to open the audit log: " + err.Error())
+			return
+		}
+		auditLog.path, auditLog.size = path, 0
+		if info, err := auditLog.file.Stat(); err == nil {
+			auditLog.size = info.Size()
+		}
+	}
+	n, _ := auditLog.file.Write(bz)
+	auditLog.size += int64(n)
+}
+
+// rotateAuditLog shifts the audit log to path.1 and the older logs to the next number, dropping the oldest
+func rotateAuditLog(path string) {
+	for i := auditLogBackups - 1; i > 0; i-- {
+		_ = os.Rename(fmt.Sprintf("%s.%d", path, i), fmt.Sprintf("%s.%d", path, i+1))
+	}
+	if err := os.Rename(path, path+".1"); err != nil {
+		fmt.Println("unable to rotate the audit log: " + err.Error())
+	}
+}
@@ -0,0 +1,100 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	sdk "github.com/pokt-network/pocket-core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthTokens(t *testing.T) {
+	dir := t.TempDir()
+	tokens := NewAuthTokens(filepath.Join(dir, "auth_tokens.json"))
+	_, err := tokens.Authorize("", ScopeStop)
+	assert.Equal(t, ErrUnauthorized, err)
+	_, err = tokens.Add("ops", []string{"unknown"})
+	require.Error(t, err)
+	token, err := tokens.Add("ops", []string{ScopeReadChains, ScopeWriteChains})
+	require.NoError(t, err)
+	_, err = tokens.Add("ops", []string{ScopeStop})
+	require.Error(t, err)
+	// the scopes of the token only
+	name, err := tokens.Authorize(token, ScopeReadChains)
+	require.NoError(t, err)
+	assert.Equal(t, "ops", name)
+	_, err = tokens.Authorize(token, ScopeStop)
+	assert.Equal(t, ErrForbidden, err)
+	_, err = tokens.Authorize("wrong", ScopeReadChains)
+	assert.Equal(t, ErrUnauthorized, err)
+	// the file only stores the hash
+	bz, err := os.ReadFile(filepath.Join(dir, "auth_tokens.json"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(bz), token)
+	// rotation is seen by another instance (the running node) without a restart
+	node := NewAuthTokens(filepath.Join(dir, "auth_tokens.json"))
+	_, err = node.Authorize(token, ScopeReadChains)
+	require.NoError(t, err)
+	time.Sleep(10 * time.Millisecond)
+	rotated, err := tokens.Rotate("ops")
+	require.NoError(t, err)
+	_, err = node.Authorize(token, ScopeReadChains)
+	assert.Equal(t, ErrUnauthorized, err)
+	_, err = node.Authorize(rotated, ScopeWriteChains)
+	require.NoError(t, err)
+	// revocation
+	require.NoError(t, tokens.Revoke("ops"))
+	require.Error(t, tokens.Revoke("ops"))
+	time.Sleep(10 * time.Millisecond)
+	_, err = node.Authorize(rotated, ScopeWriteChains)
+	assert.Equal(t, ErrUnauthorized, err)
+	// the local token of auth.json grants every scope
+	AuthToken.Value = "local-token"
+	defer func() { AuthToken.Value = "" }()
+	name, err = node.Authorize("local-token", ScopeDebug)
+	require.NoError(t, err)
+	assert.Equal(t, LocalTokenName, name)
+}
+
+func TestAuthTokens_ConcurrentAdd(t *testing.T) {
+	tokens := NewAuthTokens(filepath.Join(t.TempDir(), "auth_tokens.json"))
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := tokens.Add(fmt.Sprintf("token-%d", i), []string{ScopeStop})
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+	list, err := tokens.List()
+	require.NoError(t, err)
+	assert.Len(t, list, 8)
+}
+
+func TestAudit_Rotate(t *testing.T) {
+	datadir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(datadir, sdk.ConfigDirName), 0700))
+	prevConfig, prevSize := GlobalConfig.PocketConfig, auditLogMaxSize
+	GlobalConfig.PocketConfig.DataDir = datadir
+	auditLogMaxSize = 512
+	defer func() { GlobalConfig.PocketConfig, auditLogMaxSize = prevConfig, prevSize }()
+	path := filepath.Join(datadir, sdk.ConfigDirName, sdk.AuditLogFileName)
+	for i := 0; i < 50; i++ {
+		Audit(AuditEntry{Time: time.Now(), Token: "ops", Scope: ScopeStop, Method: "POST", Path: "/v1/private/stop"})
+	}
+	// the log and its backups stay under the max size, the oldest are dropped
+	for _, p := range []string{path, path + ".1", path + ".2", path + ".3"} {
+		info, err := os.Stat(p)
+		require.NoError(t, err, p)
+		assert.True(t, info.Size() <= auditLogMaxSize, p)
+	}
+	_, err := os.Stat(path + ".4")
+	assert.True(t, os.IsNotExist(err))
+}
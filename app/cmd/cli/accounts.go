@@ -14,7 +14,6 @@ import (
 
 	"github.com/pokt-network/pocket-core/app"
 	"github.com/pokt-network/pocket-core/crypto"
-	"github.com/pokt-network/pocket-core/crypto/keys"
 	"github.com/pokt-network/pocket-core/types"
 	"github.com/spf13/cobra"
 )
@@ -40,6 +39,12 @@ func init() {
 	accountsCmd.AddCommand(signNexMS)
 	accountsCmd.AddCommand(buildMultisig)
 	accountsCmd.AddCommand(unsafeDeleteCmd)
+	accountsCmd.AddCommand(migrateKeybaseCmd)
+	accountsCmd.AddCommand(recoverCmd)
+	createCmd.Flags().BoolVar(&withMnemonic, "mnemonic", false, "derive the account from a new mnemonic")
+	recoverCmd.Flags().Uint32Var(&hdIndex, "index", 0, "the index of the account to derive")
+	recoverCmd.Flags().StringVar(&encryptPwd, "pwd-encrypt", "", "encrypt passphrase used by the cmd, non empty usage bypass interactive prompt")
+	migrateKeybaseCmd.Flags().StringVar(&keybaseTo, "to", "", "the keybase backend to migrate the keys to: leveldb or dir")
 }
 
 // accountsCmd represents the accounts namespace command
@@ -76,20 +81,38 @@ func init() {
 	updatePassphraseCmd.Flags().StringVar(&oldPwd, "pwd-old", "", "old passphrase used by the cmd, non empty usage bypass interactive prompt")
 }
 
+var withMnemonic bool
+
 // createCmd represents the create command
 var createCmd = &cobra.Command{
-	Use:   "create",
+	Use:   "create [--mnemonic]",
 	Short: "Create a new account",
 	Long: `Creates and persists a new account in the Keybase.
-Will prompt the user for a passphrase to encrypt the generated keypair.`,
+Will prompt the user for a passphrase to encrypt the generated keypair.
+With --mnemonic the account is the first (index 0) of a new BIP-39 mnemonic, which is printed once: every account
+of the mnemonic can be recovered from it with the recover command.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		app.InitConfig(datadir, tmNode, persistentPeers, seeds, remoteCLIURL)
-		kb := keys.New(app.GlobalConfig.PocketConfig.KeybaseName, app.GlobalConfig.PocketConfig.DataDir)
+		kb, err := app.NewKeybase()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
 		fmt.Print("Enter Passphrase: \n")
 		pass := app.Credentials(pwd)
 		fmt.Print("Enter passphrase again: \n")
 		confirmedpass := app.Credentials(pwd)
 		if pass == confirmedpass {
+			if withMnemonic {
+				kp, mnemonic, err := kb.CreateMnemonic(confirmedpass)
+				if err != nil {
+					fmt.Printf("Account generation Failed, %s", err)
+					return
+				}
+				fmt.Printf("Account generated successfully:\nAddress: %s\nPath: %s\n", kp.GetAddress(), kp.HDPath)
+				fmt.Printf("\nMnemonic (write it down and keep it safe, it is not stored and recovers all its accounts):\n%s\n", mnemonic)
+				return
+			}
 			kp, err := kb.Create(confirmedpass)
 			if err != nil {
 				fmt.Printf("Account generation Failed, %s", err)
@@ -198,6 +221,29 @@ var unsafeDeleteCmd = &cobra.Command{
 	},
 }
 
+var keybaseTo string
+
+var migrateKeybaseCmd = &cobra.Command{
+	Use:   "migrate-keybase --to <backend>",
+	Short: "Copy the keybase to another backend",
+	Long: `Copies every (still encrypted) key of the keybase to another backend and switches keybase_backend in config.json to it.
+The dir backend stores one armored json file per address in keystore_dir. The source keybase is left in place.`,
+	Args: cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		app.InitConfig(datadir, tmNode, persistentPeers, seeds, remoteCLIURL)
+		if keybaseTo == "" {
+			fmt.Println("a destination backend must be provided with --to")
+			return
+		}
+		copied, err := app.MigrateKeybase(keybaseTo)
+		if err != nil {
+			fmt.Println("ERROR: ", err.Error())
+			return
+		}
+		fmt.Printf("Successfully migrated %d keys to the %s keybase\n", copied, keybaseTo)
+	},
+}
+
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all accounts",
@@ -223,6 +269,32 @@ Example output:
 	},
 }
 
+var hdIndex uint32
+
+var recoverCmd = &cobra.Command{
+	Use:   "recover <mnemonic> [--index <index>]",
+	Short: "Recover an account from a mnemonic",
+	Long: `Derives the account of the index from the BIP-39 mnemonic (quoted) with the SLIP-10 path m/44'/635'/0'/0'/<index>'
+and persists it in the Keybase. Will prompt the user for a passphrase to encrypt the keypair.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		app.InitConfig(datadir, tmNode, persistentPeers, seeds, remoteCLIURL)
+		kb, err := app.NewKeybase()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println("Enter Encrypt Passphrase")
+		ePass := app.Credentials(encryptPwd)
+		kp, err := kb.Recover(args[0], ePass, hdIndex)
+		if err != nil {
+			fmt.Printf("Account recovery Failed, %s\n", err)
+			return
+		}
+		fmt.Printf("Account recovered successfully:\nAddress: %s\nPath: %s\n", kp.GetAddress(), kp.HDPath)
+	},
+}
+
 // showCmd represents the show command
 var showCmd = &cobra.Command{
 	Use:   "show <address>",
@@ -252,6 +324,9 @@ Example output:
 		fmt.Printf("Address:\t%s\nPublic Key:\t%s\n",
 			kp.GetAddress().String(),
 			hex.EncodeToString(kp.PublicKey.RawBytes()))
+		if kp.HDPath != "" {
+			fmt.Printf("Path:\t\t%s\n", kp.HDPath)
+		}
 	},
 }
 
@@ -329,7 +404,11 @@ Will prompt the user for a decryption passphrase of the armored ASCII file and f
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		app.InitConfig(datadir, tmNode, persistentPeers, seeds, remoteCLIURL)
-		kb := keys.New(app.GlobalConfig.PocketConfig.KeybaseName, app.GlobalConfig.PocketConfig.DataDir)
+		kb, err := app.NewKeybase()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
 
 		fmt.Println("Enter decrypt pass")
 		dPass := app.Credentials(decryptPwd)
@@ -476,7 +555,7 @@ Prompts the user for <fromAddr> account passphrase.`,
 			fmt.Println(err)
 			return
 		}
-		resp, err := QueryRPC(SendRawTxPath, j)
+		resp, err := broadcastTx(j)
 		if err != nil {
 			fmt.Println(err)
 			return
@@ -507,7 +586,7 @@ var sendRawTxCmd = &cobra.Command{
 			fmt.Println(err)
 			return
 		}
-		resp, err := QueryRPC(SendRawTxPath, j)
+		resp, err := broadcastTx(j)
 		if err != nil {
 			fmt.Println(err)
 			return
@@ -530,7 +609,11 @@ Will prompt the user for a passphrase to encrypt the generated keypair.`,
 			fmt.Println(err)
 			return
 		}
-		kb := keys.New(app.GlobalConfig.PocketConfig.KeybaseName, app.GlobalConfig.PocketConfig.DataDir)
+		kb, err := app.NewKeybase()
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
 		fmt.Println("Enter Encrypt Passphrase")
 		ePass := app.Credentials(encryptPwd)
 		var pk [crypto.Ed25519PrivKeySize]byte
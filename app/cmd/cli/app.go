@@ -74,7 +74,7 @@ If no changes are desired for the parameter, just enter the current param value
 			fmt.Println(err)
 			return
 		}
-		resp, err := QueryRPC(SendRawTxPath, j)
+		resp, err := broadcastTx(j)
 		if err != nil {
 			fmt.Println(err)
 			return
@@ -107,7 +107,7 @@ Prompts the user for the <fromAddr> account passphrase.`,
 			fmt.Println(err)
 			return
 		}
-		resp, err := QueryRPC(SendRawTxPath, j)
+		resp, err := broadcastTx(j)
 		if err != nil {
 			fmt.Println(err)
 			return
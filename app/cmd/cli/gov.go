@@ -68,7 +68,7 @@ Actions: [burn, transfer]`,
 			fmt.Println(err)
 			return
 		}
-		resp, err := QueryRPC(SendRawTxPath, j)
+		resp, err := broadcastTx(j)
 		if err != nil {
 			fmt.Println(err)
 			return
@@ -112,7 +112,7 @@ Actions: [burn, transfer]`,
 			fmt.Println(err)
 			return
 		}
-		resp, err := QueryRPC(SendRawTxPath, j)
+		resp, err := broadcastTx(j)
 		if err != nil {
 			fmt.Println(err)
 			return
@@ -145,7 +145,7 @@ Will prompt the user for the <fromAddr> account passphrase.`,
 			fmt.Println(err)
 			return
 		}
-		resp, err := QueryRPC(SendRawTxPath, j)
+		resp, err := broadcastTx(j)
 		if err != nil {
 			fmt.Println(err)
 			return
@@ -187,7 +187,7 @@ Will prompt the user for the <fromAddr> account passphrase.`,
 			fmt.Println(err)
 			return
 		}
-		resp, err := QueryRPC(SendRawTxPath, j)
+		resp, err := broadcastTx(j)
 		if err != nil {
 			fmt.Println(err)
 			return
@@ -241,7 +241,7 @@ Will prompt the user for the <fromAddr> account passphrase.`,
 			fmt.Println(err)
 			return
 		}
-		resp, err := QueryRPC(SendRawTxPath, j)
+		resp, err := broadcastTx(j)
 		if err != nil {
 			fmt.Println(err)
 			return
@@ -55,7 +55,7 @@ Will prompt the user for the <fromAddr> account passphrase.`,
 			fmt.Println(err)
 			return
 		}
-		resp, err := QueryRPC(SendRawTxPath, j)
+		resp, err := broadcastTx(j)
 		if err != nil {
 			fmt.Println(err)
 			return
@@ -93,7 +93,7 @@ Will prompt the user for the <fromAddr> account passphrase.`,
 			fmt.Println(err)
 			return
 		}
-		resp, err := QueryRPC(SendRawTxPath, j)
+		resp, err := broadcastTx(j)
 		if err != nil {
 			fmt.Println(err)
 			return
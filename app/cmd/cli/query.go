@@ -20,7 +20,9 @@ func init() {
 	queryCmd.AddCommand(queryBlock)
 	queryCmd.AddCommand(queryHeight)
 	queryCmd.AddCommand(queryTx)
+	queryCmd.AddCommand(queryUnconfirmedTxs)
 	queryCmd.AddCommand(queryAccountTxs)
+	queryCmd.AddCommand(queryAccountHistory)
 	queryCmd.AddCommand(queryBlockTxs)
 	queryCmd.AddCommand(queryNodes)
 	queryCmd.AddCommand(queryBalance)
@@ -113,6 +115,37 @@ var queryTx = &cobra.Command{
 	},
 }
 
+var queryUnconfirmedTxs = &cobra.Command{
+	Use:   "unconfirmed-txs [<limit>]",
+	Short: "Get the transactions of the mempool",
+	Long:  `Retrieves the transactions of the mempool of the node, not yet included in a block, up to the limit (default and maximum 100), with the count and size of all of them`,
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		app.InitConfig(datadir, tmNode, persistentPeers, seeds, remoteCLIURL)
+		var limit int
+		if len(args) == 1 {
+			var err error
+			limit, err = strconv.Atoi(args[0])
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+		}
+		params := rpc.LimitParams{Limit: limit}
+		j, err := json.Marshal(params)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		res, err := QueryRPC(GetUnconfirmedTxsPath, j)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(res)
+	},
+}
+
 var queryAccountTxs = &cobra.Command{
 	Use:   "account-txs <address> <page> <per_page> <prove (true | false)> <received (true | false)> <order (asc | desc)>",
 	Short: "Get the transactions sent by the address, paginated by page and per_page",
@@ -181,6 +214,53 @@ var queryAccountTxs = &cobra.Command{
 	},
 }
 
+var queryAccountHistory = &cobra.Command{
+	Use:   "account-history <address> <page> <per_page> <order (asc | desc)>",
+	Short: "Get the balance changes of the address, paginated by page and per_page",
+	Long: `Retrieves the balance change log of the address: the height, the signed amount and the reason
+(tx, fee, relay_reward, block_reward, slash, dao_transfer or block) of every change.
+Only available on nodes with account_history enabled in the config.`,
+	Args: cobra.RangeArgs(1, 4),
+	Run: func(cmd *cobra.Command, args []string) {
+		app.InitConfig(datadir, tmNode, persistentPeers, seeds, remoteCLIURL)
+		page := 0
+		perPage := 0
+		order := "desc"
+		if len(args) >= 2 {
+			parsedPage, err := strconv.Atoi(args[1])
+			if err == nil {
+				page = parsedPage
+			}
+		}
+		if len(args) >= 3 {
+			parsedPerPage, err := strconv.Atoi(args[2])
+			if err == nil {
+				perPage = parsedPerPage
+			}
+		}
+		if len(args) >= 4 && args[3] == "asc" {
+			order = "asc"
+		}
+		params := rpc.PaginateAddrParams{
+			Address: args[0],
+			Page:    page,
+			PerPage: perPage,
+			Sort:    order,
+		}
+		j, err := json.Marshal(params)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		res, err := QueryRPC(GetAccountHistoryPath, j)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(res)
+	},
+}
+
 var queryBlockTxs = &cobra.Command{
 	Use:   "block-txs <height> <page> <per_page> <prove (true | false)> <order (asc | desc)>",
 	Short: "Get the transactions at a certain block height, paginated by page and per_page",
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"bytes"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	sdk "github.com/pokt-network/pocket-core/types"
@@ -9,6 +10,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pokt-network/pocket-core/app"
@@ -17,6 +19,7 @@ import (
 
 var (
 	SendRawTxPath,
+	SimulateTxPath,
 	GetNodePath,
 	GetACLPath,
 	GetUpgradePath,
@@ -25,10 +28,12 @@ var (
 	GetAccountPath,
 	GetAppPath,
 	GetTxPath,
+	GetUnconfirmedTxsPath,
 	GetBlockPath,
 	GetSupportedChainsPath,
 	GetBalancePath,
 	GetAccountTxsPath,
+	GetAccountHistoryPath,
 	GetNodeParamsPath,
 	GetNodesPath,
 	GetSigningInfoPath,
@@ -51,6 +56,8 @@ func init() {
 		switch route.Name {
 		case "SendRawTx":
 			SendRawTxPath = route.Path
+		case "SimulateTx":
+			SimulateTxPath = route.Path
 		case "QueryNode":
 			GetNodePath = route.Path
 		case "QueryACL":
@@ -67,6 +74,8 @@ func init() {
 			GetAppPath = route.Path
 		case "QueryTX":
 			GetTxPath = route.Path
+		case "QueryUnconfirmedTxs":
+			GetUnconfirmedTxsPath = route.Path
 		case "QueryBlock":
 			GetBlockPath = route.Path
 		case "QuerySupportedChains":
@@ -75,6 +84,8 @@ func init() {
 			GetBalancePath = route.Path
 		case "QueryAccountTxs":
 			GetAccountTxsPath = route.Path
+		case "QueryAccountHistory":
+			GetAccountHistoryPath = route.Path
 		case "QueryNodeParams":
 			GetNodeParamsPath = route.Path
 		case "QueryNodes":
@@ -109,18 +120,63 @@ func init() {
 	}
 }
 
+// rpcClient returns the http client of the remote cli url, with the CA bundle and client certificate of the flags or
+// the config
+func rpcClient() (*http.Client, error) {
+	cfg := app.GlobalConfig.PocketConfig
+	caFile, certFile, keyFile := firstNonEmpty(remoteCACert, cfg.RemoteCLICAFile), firstNonEmpty(remoteCert, cfg.RemoteCLICertFile), firstNonEmpty(remoteKey, cfg.RemoteCLIKeyFile)
+	client := &http.Client{
+		Timeout: types.GetRPCTimeout() * time.Millisecond,
+	}
+	if caFile == "" && certFile == "" {
+		return client, nil
+	}
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile != "" {
+		pool, err := rpc.LoadCertPool(caFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	client.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+	return client, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
 func QueryRPC(path string, jsonArgs []byte) (string, error) {
 	//cliURL := app.GlobalConfig.PocketConfig.RemoteCLIURL + ":" + app.GlobalConfig.PocketConfig.RPCPort + path
 	cliURL := app.GlobalConfig.PocketConfig.RemoteCLIURL + path
-	types.SetRPCTimeout(app.GlobalConfig.PocketConfig.RPCTimeout)
 	fmt.Println(cliURL)
+	return postRPC(cliURL, jsonArgs)
+}
+
+// postRPC posts the json to the url of the rpc and returns the indented response
+func postRPC(cliURL string, jsonArgs []byte) (string, error) {
+	types.SetRPCTimeout(app.GlobalConfig.PocketConfig.RPCTimeout)
 	req, err := http.NewRequest("POST", cliURL, bytes.NewBuffer(jsonArgs))
 	if err != nil {
 		return "", err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	client := &http.Client{
-		Timeout: types.GetRPCTimeout() * time.Millisecond,
+	client, err := rpcClient()
+	if err != nil {
+		return "", err
 	}
 	resp, err := client.Do(req)
 	if err != nil {
@@ -147,8 +203,15 @@ func QueryRPC(path string, jsonArgs []byte) (string, error) {
 }
 
 func QuerySecuredRPC(path string, jsonArgs []byte, token sdk.AuthToken) (string, error) {
-	//cliURL := app.GlobalConfig.PocketConfig.RemoteCLIURL + ":" + app.GlobalConfig.PocketConfig.RPCPort + path
-	cliURL := app.GlobalConfig.PocketConfig.RemoteCLIURL + path
+	adminURL := strings.TrimRight(remoteCLIAdminURL, "/")
+	if adminURL == "" {
+		u, err := app.GlobalConfig.PocketConfig.AdminCLIURL()
+		if err != nil {
+			return "", err
+		}
+		adminURL = u
+	}
+	cliURL := adminURL + path
 	types.SetRPCTimeout(app.GlobalConfig.PocketConfig.RPCTimeout)
 	fmt.Println(cliURL)
 	req, err := http.NewRequest("POST", cliURL, bytes.NewBuffer(jsonArgs))
@@ -156,11 +219,10 @@ func QuerySecuredRPC(path string, jsonArgs []byte, token sdk.AuthToken) (string,
 		return "", err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	q := req.URL.Query()
-	q.Add("authtoken", token.Value)
-	req.URL.RawQuery = q.Encode()
-	client := &http.Client{
-		Timeout: types.GetRPCTimeout() * time.Millisecond,
+	req.Header.Set("Authorization", "Bearer "+token.Value)
+	client, err := rpcClient()
+	if err != nil {
+		return "", err
 	}
 	resp, err := client.Do(req)
 	if err != nil {
@@ -14,18 +14,22 @@ import (
 )
 
 var (
-	datadir         string
-	tmNode          string
-	remoteCLIURL    string
-	persistentPeers string
-	seeds           string
-	simulateRelay   bool
-	keybase         bool
-	mainnet         bool
-	allBlockTxs     bool
-	testnet         bool
-	profileApp      bool
-	useCache        bool
+	datadir           string
+	tmNode            string
+	remoteCLIURL      string
+	remoteCLIAdminURL string
+	remoteCACert      string
+	remoteCert        string
+	remoteKey         string
+	persistentPeers   string
+	seeds             string
+	simulateRelay     bool
+	keybase           bool
+	mainnet           bool
+	allBlockTxs       bool
+	testnet           bool
+	profileApp        bool
+	useCache          bool
 )
 
 var CLIVersion = app.AppVersion
@@ -49,6 +53,10 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&datadir, "datadir", "", "data directory (default is $HOME/.pocket/")
 	rootCmd.PersistentFlags().StringVar(&tmNode, "node", "", "takes a remote endpoint in the form <protocol>://<host>:<port>")
 	rootCmd.PersistentFlags().StringVar(&remoteCLIURL, "remoteCLIURL", "", "takes a remote endpoint in the form of <protocol>://<host> (uses RPC Port)")
+	rootCmd.PersistentFlags().StringVar(&remoteCLIAdminURL, "remoteCLIAdminURL", "", "takes the endpoint of the private routes in the form of <protocol>://<host>:<port> (default remote_cli_admin_url of the config, or the host of remoteCLIURL on the port of the private rpc group)")
+	rootCmd.PersistentFlags().StringVar(&remoteCACert, "ca-cert", "", "the CA bundle to verify an https remoteCLIURL with (default remote_cli_ca_file of the config)")
+	rootCmd.PersistentFlags().StringVar(&remoteCert, "client-cert", "", "the client certificate to present to an https remoteCLIURL (default remote_cli_cert_file of the config)")
+	rootCmd.PersistentFlags().StringVar(&remoteKey, "client-key", "", "the key of --client-cert (default remote_cli_key_file of the config)")
 	rootCmd.PersistentFlags().StringVar(&persistentPeers, "persistent_peers", "", "a comma separated list of PeerURLs: '<ID>@<IP>:<PORT>,<ID2>@<IP2>:<PORT>...<IDn>@<IPn>:<PORT>'")
 	rootCmd.PersistentFlags().StringVar(&seeds, "seeds", "", "a comma separated list of PeerURLs: '<ID>@<IP>:<PORT>,<ID2>@<IP2>:<PORT>...<IDn>@<IPn>:<PORT>'")
 	startCmd.Flags().BoolVar(&simulateRelay, "simulateRelay", false, "would you like to be able to test your relays")
@@ -94,6 +102,9 @@ func start(cmd *cobra.Command, args []string) {
 	}
 	tmNode := app.InitApp(datadir, tmNode, persistentPeers, seeds, remoteCLIURL, keybase, genesisType, useCache)
 	go rpc.StartRPC(app.GlobalConfig.PocketConfig.RPCPort, app.GlobalConfig.PocketConfig.RPCTimeout, simulateRelay, profileApp, allBlockTxs, app.GlobalConfig.PocketConfig.ChainsHotReload)
+	if app.GlobalConfig.PocketConfig.GRPCAddr != "" {
+		go rpc.StartGRPC(app.GlobalConfig.PocketConfig.GRPCAddr)
+	}
 	// trap kill signals (2,3,15,9)
 	signalChannel := make(chan os.Signal, 1)
 	signal.Notify(signalChannel,
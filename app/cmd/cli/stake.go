@@ -80,7 +80,7 @@ If no changes are desired for the parameter, just enter the current param value
 			fmt.Println(err)
 			return
 		}
-		resp, err := QueryRPC(SendRawTxPath, j)
+		resp, err := broadcastTx(j)
 		if err != nil {
 			fmt.Println(err)
 			return
@@ -143,7 +143,7 @@ The signer may be the operator or the output address.`,
 			fmt.Println(err)
 			return
 		}
-		resp, err := QueryRPC(SendRawTxPath, j)
+		resp, err := broadcastTx(j)
 		if err != nil {
 			fmt.Println(err)
 			return
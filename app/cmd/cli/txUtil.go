@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
+
 	"github.com/pokt-network/pocket-core/app"
 	"github.com/pokt-network/pocket-core/app/cmd/rpc"
 	"github.com/pokt-network/pocket-core/codec"
@@ -20,8 +22,89 @@ import (
 	"github.com/pokt-network/pocket-core/x/auth"
 	authTypes "github.com/pokt-network/pocket-core/x/auth/types"
 	govTypes "github.com/pokt-network/pocket-core/x/gov/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// dryRun simulates the transactions of the tx commands instead of broadcasting them
+	dryRun bool
+	// wait blocks the tx commands until their transaction is included in a block, or until waitTimeout
+	wait        bool
+	waitTimeout time.Duration
 )
 
+func init() {
+	for _, cmd := range txCmds() {
+		cmd.Flags().BoolVar(&dryRun, "dry-run", false, "simulate the transaction on the current state of the node and print its outcome, without broadcasting it")
+		cmd.Flags().BoolVar(&wait, "wait", false, "wait until the transaction is included in a block and print the result code and log of its delivery")
+		cmd.Flags().DurationVar(&waitTimeout, "wait-timeout", 20*time.Minute, "how long --wait waits for the transaction to be included in a block")
+	}
+}
+
+// txCmds returns the commands that broadcast a transaction
+func txCmds() []*cobra.Command {
+	return []*cobra.Command{
+		sendTxCmd, sendRawTxCmd,
+		custodialStakeCmd, nonCustodialstakeCmd, nodeUnstakeCmd, nodeUnjailCmd,
+		appStakeCmd, appUnstakeCmd,
+		govDAOTransfer, govDAOBurn, govChangeParam, govUpgrade, govFeatureEnable,
+	}
+}
+
+// broadcastTx sends the json of the SendRawTxParams to the node, which broadcasts the transaction or, with --dry-run,
+// only simulates it. With --wait, it then waits for the delivery of the transaction
+func broadcastTx(j []byte) (string, error) {
+	if dryRun {
+		return QueryRPC(SimulateTxPath, j)
+	}
+	res, err := QueryRPC(SendRawTxPath, j)
+	if err != nil || !wait {
+		return res, err
+	}
+	return waitForTx(res, waitTimeout, time.Second)
+}
+
+// waitForTx polls the node for the transaction of the broadcast response until it is included in a block, or the
+// timeout, and returns the response followed by the result of the delivery of the transaction
+func waitForTx(broadcastRes string, timeout, interval time.Duration) (string, error) {
+	var res struct {
+		TxHash string `json:"txhash"`
+		Code   uint32 `json:"code"`
+	}
+	if err := json.Unmarshal([]byte(broadcastRes), &res); err != nil {
+		return broadcastRes, err
+	}
+	// rejected by the mempool, it is never included
+	if res.Code != 0 || res.TxHash == "" {
+		return broadcastRes, nil
+	}
+	j, err := json.Marshal(rpc.HashAndProveParams{Hash: res.TxHash})
+	if err != nil {
+		return broadcastRes, err
+	}
+	fmt.Printf("waiting for the transaction %s to be included in a block...\n", res.TxHash)
+	cliURL := app.GlobalConfig.PocketConfig.RemoteCLIURL + GetTxPath
+	deadline := time.Now().Add(timeout)
+	for {
+		txRes, err := postRPC(cliURL, j)
+		if err == nil {
+			var tx struct {
+				Height   int64                    `json:"height"`
+				TxResult rpc.RPCResponseDeliverTx `json:"tx_result"`
+			}
+			if err = json.Unmarshal([]byte(txRes), &tx); err != nil {
+				return broadcastRes, err
+			}
+			return fmt.Sprintf("%s\nIncluded at height %d\nCode: %d\nCodespace: %s\nLog: %s", broadcastRes, tx.Height,
+				tx.TxResult.Code, tx.TxResult.Codespace, tx.TxResult.Log), nil
+		}
+		if time.Now().Add(interval).After(deadline) {
+			return broadcastRes, fmt.Errorf("the transaction %s was not included in a block after %s", res.TxHash, timeout)
+		}
+		time.Sleep(interval)
+	}
+}
+
 // SendTransaction - Deliver Transaction to node
 func SendTransaction(fromAddr, toAddr, passphrase, chainID string, amount sdk.BigInt, fees int64, memo string, legacyCodec bool) (*rpc.SendRawTxParams, error) {
 	fa, err := sdk.AddressFromHex(fromAddr)
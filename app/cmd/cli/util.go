@@ -1,15 +1,23 @@
 package cli
 
 import (
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"github.com/pokt-network/pocket-core/x/pocketcore/types"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/pokt-network/pocket-core/app"
+	"github.com/pokt-network/pocket-core/app/cmd/rpc"
+	"github.com/pokt-network/pocket-core/crypto/signer"
+	sdk "github.com/pokt-network/pocket-core/types"
 	"github.com/spf13/cobra"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/state"
+	dbm "github.com/tendermint/tm-db"
 )
 
 func init() {
@@ -22,6 +30,37 @@ func init() {
 	utilCmd.AddCommand(completionCmd)
 	utilCmd.AddCommand(updateConfigsCmd)
 	utilCmd.AddCommand(printDefaultConfigCmd)
+	utilCmd.AddCommand(migrateDBCmd)
+	migrateDBCmd.Flags().StringVar(&migrateFrom, "from", string(dbm.GoLevelDBBackend), "the db backend the databases are currently stored in")
+	migrateDBCmd.Flags().StringVar(&migrateTo, "to", "", "the db backend to migrate the databases to (must be compiled in)")
+	utilCmd.AddCommand(reindexTxsCmd)
+	utilCmd.AddCommand(exportSQLCmd)
+	exportSQLCmd.Flags().Int64Var(&exportFrom, "from", 0, "the first height to export (default: the height after the last one exported to --out)")
+	exportSQLCmd.Flags().Int64Var(&exportTo, "to", 0, "the last height to export (default: the latest height)")
+	exportSQLCmd.Flags().StringVar(&exportOut, "out", "pocket.sqlite", "the sqlite database file to export to")
+	exportSQLCmd.Flags().Int64Var(&exportCheckpoint, "checkpoint", 1000, "export every account balance at the heights that are a multiple of this (0 to disable)")
+	exportSQLCmd.Flags().BoolVar(&exportRemote, "remote", false, "read from the tendermint rpc of a running node instead of the local databases")
+	utilCmd.AddCommand(remoteSignerCmd)
+	remoteSignerCmd.Flags().StringVar(&signerSocket, "socket", "unix://signer.sock", "the unix socket to serve the relay, claim and proof signatures on (remote_signer of the node)")
+	remoteSignerCmd.Flags().StringSliceVar(&signerAllow, "allow", []string{signer.MsgTypeRelayResponse, signer.MsgTypeClaim, signer.MsgTypeProof}, "the message types to sign (relay_response, claim, proof, consensus)")
+	remoteSignerCmd.Flags().StringVar(&signerNodeAddr, "node-laddr", "", "the privval listener of the node (priv_validator_laddr) to sign consensus messages for")
+	remoteSignerCmd.Flags().StringVar(&signerChainID, "chain-id", "mainnet", "the chain id of the consensus messages")
+	utilCmd.AddCommand(genOpenRPCCmd)
+	genOpenRPCCmd.Flags().StringVar(&openRPCOut, "out", "", "the file to write the description to (default stdout)")
+	utilCmd.AddCommand(authTokenCmd)
+	authTokenCmd.AddCommand(authTokenAddCmd)
+	authTokenCmd.AddCommand(authTokenListCmd)
+	authTokenCmd.AddCommand(authTokenRotateCmd)
+	authTokenCmd.AddCommand(authTokenRevokeCmd)
+	authTokenAddCmd.Flags().StringSliceVar(&authTokenScopes, "scopes", nil, "the private routes the token grants (read-chains, write-chains, stop, debug, earnings)")
+	utilCmd.AddCommand(evidenceCmd)
+	evidenceCmd.AddCommand(evidenceListCmd)
+	evidenceCmd.AddCommand(evidenceShowCmd)
+	evidenceCmd.AddCommand(evidenceDeleteCmd)
+	evidenceCmd.AddCommand(evidenceVerifyCmd)
+	evidenceCmd.AddCommand(evidenceExportCmd)
+	evidenceShowCmd.Flags().Int64Var(&evidenceIndex, "index", -1, "only show the proof at this index")
+	evidenceExportCmd.Flags().StringVar(&evidenceOut, "out", "", "the file to write the evidence to (default stdout)")
 }
 
 var utilCmd = &cobra.Command{
@@ -154,6 +193,386 @@ var convertPocketEvidenceDB = &cobra.Command{
 	},
 }
 
+var (
+	migrateFrom string
+	migrateTo   string
+)
+
+var migrateDBCmd = &cobra.Command{
+	Use:   "migrate-db --from <backend> --to <backend>",
+	Short: "Migrates the pocket databases to another db backend",
+	Long: `Copies the application, transaction indexer and evidence databases from one db backend to another and verifies the copies.
+The originals are kept as <name>.db.<from>.bak and the db_backend in the config file is updated. The node must be stopped.`,
+	Args: cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		app.InitConfig(datadir, tmNode, persistentPeers, seeds, remoteCLIURL)
+		if migrateTo == "" {
+			fmt.Println("a destination backend must be provided with --to")
+			return
+		}
+		err := app.MigrateDB(dbm.BackendType(migrateFrom), dbm.BackendType(migrateTo))
+		if err != nil {
+			fmt.Println("ERROR: ", err.Error())
+			return
+		}
+		fmt.Printf("Successfully migrated the databases from %s to %s\n", migrateFrom, migrateTo)
+	},
+}
+
+var reindexTxsCmd = &cobra.Command{
+	Use:   "reindex-txs",
+	Short: "Re-indexes the transactions under every index key",
+	Long: `Re-indexes every transaction of the transaction indexer under the current index keys, so the transactions indexed before
+the tx.message_type, tx.chain and tx.code keys were added can be searched by them. The tx.chain of the historical stakes, claims
+and proofs is read from their message. The node must be stopped.`,
+	Args: cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		app.InitConfig(datadir, tmNode, persistentPeers, seeds, remoteCLIURL)
+		db, err := app.OpenTxIndexerDB(app.GlobalConfig)
+		if err != nil {
+			fmt.Println("ERROR: ", err.Error())
+			return
+		}
+		defer db.Close()
+		count, err := sdk.NewTransactionIndexer(db).Reindex(app.TxMsgChains)
+		if err != nil {
+			fmt.Println("ERROR: ", err.Error())
+			return
+		}
+		fmt.Printf("Successfully re-indexed %d transactions\n", count)
+	},
+}
+
+var (
+	exportFrom       int64
+	exportTo         int64
+	exportOut        string
+	exportCheckpoint int64
+	exportRemote     bool
+)
+
+var exportSQLCmd = &cobra.Command{
+	Use:   "export-sql [--from <height>] [--to <height>] [--out <file>] [--checkpoint <heights>] [--remote]",
+	Short: "Exports the chain data to a sqlite database",
+	Long: `Exports the blocks, txs, messages, claims, proofs, stakes, unstakes, jailings, param changes and the account balances at checkpoints
+to normalized tables of a sqlite database. Without --from the export continues after the last height exported to --out, so running it
+periodically keeps the database up to date. The local databases are read unless --remote is set, which reads from the tendermint rpc
+(tendermint_uri) of a running node instead.`,
+	Args: cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		app.InitConfig(datadir, tmNode, persistentPeers, seeds, remoteCLIURL)
+		var src app.ExportSource
+		if exportRemote {
+			src = app.NewRemoteExportSource()
+		} else {
+			var err error
+			src, err = app.NewLocalExportSource()
+			if err != nil {
+				fmt.Println("ERROR: ", err.Error())
+				return
+			}
+		}
+		defer src.Close()
+		from, to, err := app.ExportSQL(src, exportOut, exportFrom, exportTo, exportCheckpoint)
+		if err != nil {
+			fmt.Println("ERROR: ", err.Error())
+			return
+		}
+		if from > to {
+			fmt.Printf("%s is up to date at height %d\n", exportOut, to)
+			return
+		}
+		fmt.Printf("Successfully exported heights %d to %d to %s\n", from, to, exportOut)
+	},
+}
+
+var (
+	signerSocket   string
+	signerAllow    []string
+	signerNodeAddr string
+	signerChainID  string
+)
+
+var remoteSignerCmd = &cobra.Command{
+	Use:   "remote-signer [--socket <unix://path>] [--allow <types>] [--node-laddr <unix://path>] [--chain-id <id>]",
+	Short: "Runs a signer daemon with the private validator key",
+	Long: `Runs the reference signer daemon with the private validator key of the datadir, so the key does not have to be on the
+node. The relay responses and claim / proof txs are signed on --socket, which is the remote_signer of the node. Consensus
+messages are signed over the tendermint privval protocol by dialing --node-laddr, the priv_validator_laddr of the node.
+Only the message types of --allow (relay_response, claim, proof, consensus) are signed.`,
+	Args: cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		app.InitConfig(datadir, tmNode, persistentPeers, seeds, remoteCLIURL)
+		err := app.RunRemoteSigner(signerSocket, signerAllow, signerNodeAddr, signerChainID)
+		if err != nil {
+			fmt.Println("ERROR: ", err.Error())
+			return
+		}
+	},
+}
+
+var openRPCOut string
+
+var genOpenRPCCmd = &cobra.Command{
+	Use:   "gen-openrpc [--out <file>]",
+	Short: "Generates the OpenRPC description of the jsonrpc methods",
+	Long: `Generates the OpenRPC description of the methods of /v1/jsonrpc from the route table of the rpc server, as in
+doc/specs/openrpc.json. The running node also returns it for the rpc.discover method.`,
+	Args: cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		bz, err := rpc.GenerateOpenRPC(rpc.GetRoutes())
+		if err != nil {
+			fmt.Println("ERROR: ", err.Error())
+			return
+		}
+		if openRPCOut == "" {
+			fmt.Print(string(bz))
+			return
+		}
+		if err = os.WriteFile(openRPCOut, bz, 0644); err != nil {
+			fmt.Println("ERROR: ", err.Error())
+			return
+		}
+		fmt.Println("OpenRPC description written to " + openRPCOut)
+	},
+}
+
+var authTokenCmd = &cobra.Command{
+	Use:   "auth-token",
+	Short: "Manages the tokens of the private rpc routes",
+	Long: `Manages the named bearer tokens of the private rpc routes (/v1/private/* and /debug/*), stored hashed in
+<datadir>/config/auth_tokens.json. Every token only grants its scopes: read-chains, write-chains, stop, debug, earnings.
+The running node reloads the file on change, so tokens can be added, rotated and revoked without a restart.`,
+}
+
+var authTokenScopes []string
+
+var authTokenAddCmd = &cobra.Command{
+	Use:   "add <name> --scopes <scopes>",
+	Short: "Adds a token",
+	Long:  `Adds a token with the scopes and prints it. The token is only printed once: only its hash is stored.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		app.InitConfig(datadir, tmNode, persistentPeers, seeds, remoteCLIURL)
+		token, err := app.GetAuthTokens().Add(args[0], authTokenScopes)
+		if err != nil {
+			fmt.Println("ERROR: ", err.Error())
+			return
+		}
+		fmt.Printf("Token %s: %s\n", args[0], token)
+	},
+}
+
+var authTokenListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the tokens",
+	Long:  `Lists the names, scopes and issue times of the tokens.`,
+	Args:  cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		app.InitConfig(datadir, tmNode, persistentPeers, seeds, remoteCLIURL)
+		tokens, err := app.GetAuthTokens().List()
+		if err != nil {
+			fmt.Println("ERROR: ", err.Error())
+			return
+		}
+		for _, t := range tokens {
+			fmt.Printf("%s: %s (issued %s)\n", t.Name, strings.Join(t.Scopes, ","), t.Issued.Format(time.RFC3339))
+		}
+	},
+}
+
+var authTokenRotateCmd = &cobra.Command{
+	Use:   "rotate <name>",
+	Short: "Rotates a token",
+	Long:  `Replaces the token with a new one with the same scopes and prints it. The previous token stops working at once.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		app.InitConfig(datadir, tmNode, persistentPeers, seeds, remoteCLIURL)
+		token, err := app.GetAuthTokens().Rotate(args[0])
+		if err != nil {
+			fmt.Println("ERROR: ", err.Error())
+			return
+		}
+		fmt.Printf("Token %s: %s\n", args[0], token)
+	},
+}
+
+var authTokenRevokeCmd = &cobra.Command{
+	Use:   "revoke <name>",
+	Short: "Revokes a token",
+	Long:  `Removes the token.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		app.InitConfig(datadir, tmNode, persistentPeers, seeds, remoteCLIURL)
+		if err := app.GetAuthTokens().Revoke(args[0]); err != nil {
+			fmt.Println("ERROR: ", err.Error())
+			return
+		}
+		fmt.Printf("Token %s revoked\n", args[0])
+	},
+}
+
+var evidenceCmd = &cobra.Command{
+	Use:   "evidence",
+	Short: "Inspects the evidence store",
+	Long: `Inspects and maintains the local evidence store: the relay and challenge proofs of the sessions that are not claimed and proven yet.
+The commands read the evidence database of the data dir, so the node must be stopped.`,
+}
+
+var (
+	evidenceIndex int64
+	evidenceOut   string
+)
+
+// evidenceArgs parses the <appPubKey> <claimType> <relayChainID> <sessionHeight> arguments of the evidence commands
+func evidenceArgs(args []string) (appPubKey, evidenceType, chain string, sessionHeight int64, err error) {
+	sessionHeight, err = strconv.ParseInt(args[3], 10, 64)
+	return args[0], args[1], args[2], sessionHeight, err
+}
+
+var evidenceListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the evidence",
+	Long:  `Lists the sessions of the evidence store with their number of proofs and whether the evidence is sealed (claimed or at the max relays).`,
+	Args:  cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		app.InitConfig(datadir, tmNode, persistentPeers, seeds, remoteCLIURL)
+		app.InitEvidenceStore()
+		for _, e := range app.ListEvidence() {
+			fmt.Printf("%s %s %s %d: %d proofs, sealed %t\n", e.ApplicationPubKey, e.EvidenceType, e.Chain, e.SessionBlockHeight, e.NumOfProofs, e.Sealed)
+		}
+	},
+}
+
+var evidenceShowCmd = &cobra.Command{
+	Use:   "show <appPubKey> <claimType=(relay | challenge)> <relayChainID> <sessionHeight> [--index <index>]",
+	Short: "Shows the proofs of an evidence",
+	Long:  `Shows the evidence of the session and its proofs, or only the proof at --index.`,
+	Args:  cobra.ExactArgs(4),
+	Run: func(cmd *cobra.Command, args []string) {
+		app.InitConfig(datadir, tmNode, persistentPeers, seeds, remoteCLIURL)
+		appPubKey, evidenceType, chain, sessionHeight, err := evidenceArgs(args)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		app.InitEvidenceStore()
+		if evidenceIndex >= 0 {
+			p, err := app.GetEvidenceProof(appPubKey, chain, evidenceType, sessionHeight, evidenceIndex)
+			if err != nil {
+				fmt.Println("ERROR: ", err.Error())
+				return
+			}
+			bz, _ := json.MarshalIndent(p, "", "  ")
+			fmt.Println(string(bz))
+			return
+		}
+		e, err := app.ExportEvidence(appPubKey, chain, evidenceType, sessionHeight)
+		if err != nil {
+			fmt.Println("ERROR: ", err.Error())
+			return
+		}
+		fmt.Printf("%d proofs, sealed %t, merkle root %s\n", e.NumOfProofs, e.Sealed, hex.EncodeToString(e.MerkleRoot.Hash))
+		for i, p := range e.Proofs {
+			bz, _ := json.Marshal(p)
+			fmt.Printf("%d: %s\n", i, bz)
+		}
+	},
+}
+
+var evidenceDeleteCmd = &cobra.Command{
+	Use:   "delete <appPubKey> <claimType=(relay | challenge)> <relayChainID> <sessionHeight>",
+	Short: "Deletes an evidence",
+	Long:  `Deletes the evidence of the session and its proofs. The relays of the session can't be claimed afterwards.`,
+	Args:  cobra.ExactArgs(4),
+	Run: func(cmd *cobra.Command, args []string) {
+		app.InitConfig(datadir, tmNode, persistentPeers, seeds, remoteCLIURL)
+		appPubKey, evidenceType, chain, sessionHeight, err := evidenceArgs(args)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Printf("Are you sure you would like to delete the %s evidence of %s for %s at session height %d \n", evidenceType, appPubKey, chain, sessionHeight)
+		if !app.Confirmation("") {
+			return
+		}
+		app.InitEvidenceStore()
+		if err := app.DeleteEvidence(appPubKey, chain, evidenceType, sessionHeight); err != nil {
+			fmt.Println("ERROR: ", err.Error())
+			return
+		}
+		fmt.Println("Evidence deleted successfully")
+	},
+}
+
+var evidenceVerifyCmd = &cobra.Command{
+	Use:   "verify <appPubKey> <claimType=(relay | challenge)> <relayChainID> <sessionHeight>",
+	Short: "Verifies an evidence against its claim",
+	Long: `Recomputes the merkle root of the evidence of the session and compares it and the number of proofs to the claim of this node
+(MsgClaim.MerkleRoot and TotalProofs) in the local application state.`,
+	Args: cobra.ExactArgs(4),
+	Run: func(cmd *cobra.Command, args []string) {
+		app.InitConfig(datadir, tmNode, persistentPeers, seeds, remoteCLIURL)
+		appPubKey, evidenceType, chain, sessionHeight, err := evidenceArgs(args)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		app.InitEvidenceStore()
+		v, err := app.VerifyEvidence(appPubKey, chain, evidenceType, sessionHeight)
+		if err != nil {
+			fmt.Println("ERROR: ", err.Error())
+			return
+		}
+		fmt.Printf("Evidence: %d proofs, merkle root %s\n", v.NumOfProofs, hex.EncodeToString(v.MerkleRoot.Hash))
+		if !v.ClaimFound {
+			fmt.Println("No claim found for the session: not submitted yet, or already proven or expired")
+			return
+		}
+		fmt.Printf("Claim: %d proofs, merkle root %s\n", v.ClaimTotalProofs, hex.EncodeToString(v.ClaimMerkleRoot.Hash))
+		if v.Match {
+			fmt.Println("The evidence matches the claim")
+		} else {
+			fmt.Println("The evidence does NOT match the claim")
+		}
+	},
+}
+
+var evidenceExportCmd = &cobra.Command{
+	Use:   "export <appPubKey> <claimType=(relay | challenge)> <relayChainID> <sessionHeight> [--out <file>]",
+	Short: "Exports an evidence to json",
+	Long:  `Writes the evidence of the session, its merkle root and all of its proofs as json, e.g. to attach to a bug report.`,
+	Args:  cobra.ExactArgs(4),
+	Run: func(cmd *cobra.Command, args []string) {
+		app.InitConfig(datadir, tmNode, persistentPeers, seeds, remoteCLIURL)
+		appPubKey, evidenceType, chain, sessionHeight, err := evidenceArgs(args)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		app.InitEvidenceStore()
+		e, err := app.ExportEvidence(appPubKey, chain, evidenceType, sessionHeight)
+		if err != nil {
+			fmt.Println("ERROR: ", err.Error())
+			return
+		}
+		bz, err := json.MarshalIndent(e, "", "  ")
+		if err != nil {
+			fmt.Println("ERROR: ", err.Error())
+			return
+		}
+		if evidenceOut == "" {
+			fmt.Println(string(bz))
+			return
+		}
+		if err = os.WriteFile(evidenceOut, bz, 0644); err != nil {
+			fmt.Println("ERROR: ", err.Error())
+			return
+		}
+		fmt.Println("Evidence written to " + evidenceOut)
+	},
+}
+
 var (
 	blocks bool
 )
@@ -0,0 +1,61 @@
+package rpc
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/pokt-network/pocket-core/app"
+)
+
+// ErrClientCert is returned to the calls without a verified client certificate when the rpc server verifies them
+var ErrClientCert = errors.New("a client certificate signed by the rpc_tls_client_ca_file is required")
+
+// Authorized only lets the calls with a token granting the scope through to the handler, and records every call in
+// the audit log. The token is read from the `Authorization: Bearer <token>` header, or the deprecated `authtoken`
+// query parameter. When the rpc server verifies client certificates (mTLS), a verified certificate is required too
+func Authorized(scope string, handler httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		name, err := app.GetAuthTokens().Authorize(bearerToken(r), scope)
+		if app.GlobalConfig.PocketConfig.RPCTLSClientCAFile != "" && !hasClientCert(r) {
+			err = ErrClientCert
+		}
+		entry := app.AuditEntry{
+			Time:   time.Now().UTC(),
+			Token:  name,
+			Scope:  scope,
+			Method: r.Method,
+			Path:   r.URL.Path,
+			Remote: r.RemoteAddr,
+		}
+		switch err {
+		case nil:
+			// audited before the handler runs, a stop does not return
+			app.Audit(entry)
+			handler(w, r, ps)
+			return
+		case app.ErrForbidden:
+			entry.Status = http.StatusForbidden
+			WriteErrorResponse(w, entry.Status, err.Error())
+		case app.ErrUnauthorized, ErrClientCert:
+			entry.Status = http.StatusUnauthorized
+			WriteErrorResponse(w, entry.Status, err.Error())
+		default:
+			entry.Status = http.StatusInternalServerError
+			WriteErrorResponse(w, entry.Status, err.Error())
+		}
+		app.Audit(entry)
+	}
+}
+
+func bearerToken(r *http.Request) string {
+	if h := r.Header.Get("Authorization"); h != "" {
+		if len(h) > 7 && strings.EqualFold(h[:7], "Bearer ") {
+			return strings.TrimSpace(h[7:])
+		}
+		return ""
+	}
+	return r.URL.Query().Get("authtoken")
+}
@@ -14,6 +14,8 @@ import (
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/pokt-network/pocket-core/app"
+	sdk "github.com/pokt-network/pocket-core/types"
+	"github.com/pokt-network/pocket-core/x/auth/util"
 	"github.com/pokt-network/pocket-core/x/pocketcore/types"
 )
 
@@ -40,6 +42,37 @@ func Dispatch(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	WriteJSONResponse(w, string(j), r.URL.Path, r.Host)
 }
 
+// BatchDispatchParams is the request of the sessions of multiple chains of an app, of the latest session when the session
+// height is zero
+type BatchDispatchParams struct {
+	ApplicationPubKey string   `json:"app_public_key"`
+	Chains            []string `json:"chains"`
+	SessionHeight     int64    `json:"session_height"`
+}
+
+// BatchDispatch supports CORS functionality
+func BatchDispatch(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	if cors(&w, r) {
+		return
+	}
+	d := BatchDispatchParams{}
+	if err := PopModel(w, r, ps, &d); err != nil {
+		WriteErrorResponse(w, 400, err.Error())
+		return
+	}
+	res, err := app.PCA.HandleBatchDispatch(d.ApplicationPubKey, d.Chains, d.SessionHeight)
+	if err != nil {
+		WriteErrorResponse(w, 400, err.Error())
+		return
+	}
+	j, er := json.Marshal(res)
+	if er != nil {
+		WriteErrorResponse(w, 400, er.Error())
+		return
+	}
+	WriteJSONResponse(w, string(j), r.URL.Path, r.Host)
+}
+
 type RPCRelayResponse struct {
 	Signature string `json:"signature"`
 	Response  string `json:"response"`
@@ -49,6 +82,7 @@ type RPCRelayResponse struct {
 type RPCRelayErrorResponse struct {
 	Error    error                   `json:"error"`
 	Dispatch *types.DispatchResponse `json:"dispatch"`
+	Response string                  `json:"response,omitempty"` // the unsigned backend response that failed its checks
 }
 
 // Relay supports CORS functionality
@@ -57,12 +91,12 @@ func Relay(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	if cors(&w, r) {
 		return
 	}
-	if err := PopModel(w, r, ps, &relay); err != nil {
+	if err := readRelay(r, app.PCA.HostedChains(), &relay); err != nil {
 		response := RPCRelayErrorResponse{
-			Error: err,
+			Error: err.err,
 		}
 		j, _ := json.Marshal(response)
-		WriteJSONResponseWithCode(w, string(j), r.URL.Path, r.Host, 400)
+		WriteJSONResponseWithCode(w, string(j), r.URL.Path, r.Host, err.code)
 		return
 	}
 	res, dispatch, err := app.PCA.HandleRelay(relay)
@@ -71,72 +105,56 @@ func Relay(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 			Error:    err,
 			Dispatch: dispatch,
 		}
+		if res != nil {
+			response.Response = res.Response
+		}
 		j, _ := json.Marshal(response)
 		WriteJSONResponseWithCode(w, string(j), r.URL.Path, r.Host, 400)
 		return
 	}
-	response := RPCRelayResponse{
-		Signature: res.Signature,
-		Response:  res.Response,
-	}
-	j, er := json.Marshal(response)
-	if er != nil {
-		WriteErrorResponse(w, 400, er.Error())
-		return
-	}
-	WriteJSONResponse(w, string(j), r.URL.Path, r.Host)
+	writeRelayResponse(w, r, res)
 }
 
 // UpdateChains
 func UpdateChains(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
-	value := r.URL.Query().Get("authtoken")
-	if value == app.AuthToken.Value {
-		var hostedChainsSlice []types.HostedBlockchain
-		if err := PopModel(w, r, ps, &hostedChainsSlice); err != nil {
-			WriteErrorResponse(w, 400, err.Error())
+	var hostedChainsSlice []types.HostedBlockchain
+	if err := PopModel(w, r, ps, &hostedChainsSlice); err != nil {
+		WriteErrorResponse(w, 400, err.Error())
+		return
+	}
+	m := make(map[string]types.HostedBlockchain)
+	for _, chain := range hostedChainsSlice {
+		if err := nodesTypes.ValidateNetworkIdentifier(chain.ID); err != nil {
+			WriteErrorResponse(w, 400, fmt.Sprintf("invalid ID: %s in network identifier in json", chain.ID))
 			return
 		}
-		m := make(map[string]types.HostedBlockchain)
-		for _, chain := range hostedChainsSlice {
-			if err := nodesTypes.ValidateNetworkIdentifier(chain.ID); err != nil {
-				WriteErrorResponse(w, 400, fmt.Sprintf("invalid ID: %s in network identifier in json", chain.ID))
-				return
-			}
-			m[chain.ID] = chain
-		}
-		result, err := app.PCA.SetHostedChains(m)
-		if err != nil {
-			WriteErrorResponse(w, 400, err.Error())
-		} else {
-			j, er := json.Marshal(result)
-			if er != nil {
-				WriteErrorResponse(w, 400, er.Error())
-				return
-			}
-			WriteJSONResponse(w, string(j), r.URL.Path, r.Host)
-		}
+		m[chain.ID] = chain
+	}
+	result, err := app.PCA.SetHostedChains(m)
+	if err != nil {
+		WriteErrorResponse(w, 400, err.Error())
 	} else {
-		WriteErrorResponse(w, 401, "wrong authtoken "+value)
+		j, er := json.Marshal(result)
+		if er != nil {
+			WriteErrorResponse(w, 400, er.Error())
+			return
+		}
+		WriteJSONResponse(w, string(j), r.URL.Path, r.Host)
 	}
 }
 
 // Stop
 func Stop(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
-	value := r.URL.Query().Get("authtoken")
-	if value == app.AuthToken.Value {
-		app.ShutdownPocketCore()
-		err := app.PCA.TMNode().Stop()
-		if err != nil {
-			fmt.Println(err)
-			WriteErrorResponse(w, 400, err.Error())
-			fmt.Println("Force Stop , PID:" + fmt.Sprint(os.Getpid()))
-			os.Exit(1)
-		}
-		fmt.Println("Stop Successful, PID:" + fmt.Sprint(os.Getpid()))
-		os.Exit(0)
-	} else {
-		WriteErrorResponse(w, 401, "wrong authtoken "+value)
+	app.ShutdownPocketCore()
+	err := app.PCA.TMNode().Stop()
+	if err != nil {
+		fmt.Println(err)
+		WriteErrorResponse(w, 400, err.Error())
+		fmt.Println("Force Stop , PID:" + fmt.Sprint(os.Getpid()))
+		os.Exit(1)
 	}
+	fmt.Println("Stop Successful, PID:" + fmt.Sprint(os.Getpid()))
+	os.Exit(0)
 }
 
 // Challenge supports CORS functionality
@@ -163,8 +181,9 @@ func Challenge(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 }
 
 type SendRawTxParams struct {
-	Addr        string `json:"address"`
-	RawHexBytes string `json:"raw_hex_bytes"`
+	Addr          string `json:"address"`
+	RawHexBytes   string `json:"raw_hex_bytes"`
+	BroadcastMode string `json:"broadcast_mode,omitempty"` // async, sync (default) or commit, see util.ParseBroadcastMode
 }
 
 func SendRawTx(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
@@ -178,7 +197,12 @@ func SendRawTx(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 		WriteErrorResponse(w, 400, err.Error())
 		return
 	}
-	res, err := app.PCA.SendRawTx(params.Addr, bz)
+	mode, err := util.ParseBroadcastMode(params.BroadcastMode)
+	if err != nil {
+		WriteErrorResponse(w, 400, err.Error())
+		return
+	}
+	res, err := app.PCA.BroadcastRawTx(params.Addr, bz, mode)
 	if err != nil {
 		WriteErrorResponse(w, 400, err.Error())
 		return
@@ -191,6 +215,59 @@ func SendRawTx(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	WriteJSONResponse(w, string(j), r.URL.Path, r.Host)
 }
 
+// SimulateTxResponse is the outcome of a tx run on the current state of the node, without broadcasting it
+type SimulateTxResponse struct {
+	Tx             RPCStdTx            `json:"tx"`
+	Success        bool                `json:"success"`
+	Code           sdk.CodeType        `json:"code"`
+	Codespace      sdk.CodespaceType   `json:"codespace,omitempty"`
+	Log            string              `json:"log,omitempty"`
+	Events         sdk.StringEvents    `json:"events"`
+	Fee            sdk.Coins           `json:"fee"`
+	MinFee         sdk.BigInt          `json:"min_fee"`
+	BalanceChanges []app.BalanceChange `json:"balance_changes"`
+}
+
+// SimulateTx runs a signed tx on the current state: its validation (but the signature), fee deduction and message
+// handler. Nothing is broadcast, and no fee is charged
+func SimulateTx(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var params = SendRawTxParams{}
+	if err := PopModel(w, r, ps, &params); err != nil {
+		WriteErrorResponse(w, 400, err.Error())
+		return
+	}
+	bz, err := hex.DecodeString(params.RawHexBytes)
+	if err != nil {
+		WriteErrorResponse(w, 400, err.Error())
+		return
+	}
+	res, err := app.PCA.SimulateRawTx(bz)
+	if err != nil {
+		WriteErrorResponse(w, 400, err.Error())
+		return
+	}
+	resp := SimulateTxResponse{
+		Tx:             RPCStdTx(res.Tx),
+		Success:        res.Result.IsOK(),
+		Code:           res.Result.Code,
+		Codespace:      res.Result.Codespace,
+		Log:            res.Result.Log,
+		Events:         sdk.StringifyEvents(res.Result.Events.ToABCIEvents()),
+		Fee:            res.Fee,
+		MinFee:         res.MinFee,
+		BalanceChanges: res.BalanceChanges,
+	}
+	if resp.BalanceChanges == nil {
+		resp.BalanceChanges = []app.BalanceChange{}
+	}
+	j, er := json.Marshal(resp)
+	if er != nil {
+		WriteErrorResponse(w, 400, er.Error())
+		return
+	}
+	WriteJSONResponse(w, string(j), r.URL.Path, r.Host)
+}
+
 type simRelayParams struct {
 	RelayNetworkID string        `json:"relay_network_id"` // RelayNetworkID
 	Payload        types.Payload `json:"payload"`          // the data payload of the request
@@ -209,6 +286,16 @@ func SimRequest(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 		WriteErrorResponse(w, 400, err.Error())
 		return
 	}
+	// the non http chains are simulated on their backend
+	if chain.BackendType() != types.BackendHTTP {
+		res, er := chain.Execute(params.Payload)
+		if er != nil {
+			WriteErrorResponse(w, 400, er.Error())
+			return
+		}
+		WriteResponse(w, res, r.URL.Path, r.Host)
+		return
+	}
 	url := strings.Trim(chain.URL, `/`)
 	if len(params.Payload.Path) > 0 {
 		url = url + "/" + strings.Trim(params.Payload.Path, `/`)
@@ -0,0 +1,206 @@
+package rpc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	pocketTypes "github.com/pokt-network/pocket-core/x/pocketcore/types"
+)
+
+const (
+	// the content type of the protobuf encoded relays and relay responses
+	protobufContentType = "application/x-protobuf"
+	// the smallest relay response body that is compressed
+	minCompressedBodySize = 1024
+)
+
+// contentCoding is a content coding of the relay bodies (Content-Encoding / Accept-Encoding)
+type contentCoding struct {
+	newReader func(r io.Reader) (io.ReadCloser, error)
+	newWriter func(w io.Writer) io.WriteCloser
+}
+
+var (
+	// the content codings of the relay bodies
+	contentCodings = map[string]contentCoding{
+		"gzip": {
+			newReader: func(r io.Reader) (io.ReadCloser, error) { return gzip.NewReader(r) },
+			newWriter: func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) },
+		},
+	}
+	// the content codings of the relay responses, by preference of the node
+	contentCodingPreference = []string{"gzip"}
+)
+
+// relayBodyError is an error reading a relay request body, with its http status
+type relayBodyError struct {
+	code int
+	err  error
+}
+
+func (e relayBodyError) Error() string {
+	return e.err.Error()
+}
+
+// readRelay reads the relay of the request: the body is decoded with its Content-Encoding, then as protobuf when its
+// Content-Type is application/x-protobuf or as json otherwise. The decoded body is limited to the max body size of the
+// relay chain
+func readRelay(r *http.Request, hostedChains *pocketTypes.HostedBlockchains, relay *pocketTypes.Relay) *relayBodyError {
+	var body io.Reader = r.Body
+	if coding := strings.ToLower(strings.TrimSpace(r.Header.Get("Content-Encoding"))); coding != "" && coding != "identity" {
+		c, ok := contentCodings[coding]
+		if !ok {
+			return &relayBodyError{http.StatusUnsupportedMediaType, fmt.Errorf("unsupported content encoding: %s", coding)}
+		}
+		rc, err := c.newReader(r.Body)
+		if err != nil {
+			return &relayBodyError{http.StatusBadRequest, err}
+		}
+		defer rc.Close()
+		body = rc
+	}
+	// the chain is not known before the relay is decoded
+	limit := hostedChains.LargestMaxBodySize()
+	bz, err := ioutil.ReadAll(io.LimitReader(body, limit+1))
+	if err != nil {
+		return &relayBodyError{http.StatusBadRequest, err}
+	}
+	if int64(len(bz)) > limit {
+		return &relayBodyError{http.StatusRequestEntityTooLarge, fmt.Errorf("the relay body exceeds the max body size of %d bytes", limit)}
+	}
+	if isProtobuf(r.Header.Get("Content-Type")) {
+		var pr pocketTypes.ProtoRelay
+		if err := pr.Unmarshal(bz); err != nil {
+			return &relayBodyError{http.StatusBadRequest, err}
+		}
+		*relay = pr.FromProto()
+	} else if len(bz) != 0 {
+		if err := json.Unmarshal(bz, relay); err != nil {
+			return &relayBodyError{http.StatusBadRequest, err}
+		}
+	}
+	if limit := hostedChains.MaxBodySize(relay.Proof.Blockchain); int64(len(bz)) > limit {
+		return &relayBodyError{http.StatusRequestEntityTooLarge, fmt.Errorf("the relay body exceeds the max body size of %d bytes of chain %s", limit, relay.Proof.Blockchain)}
+	}
+	return nil
+}
+
+// writeRelayResponse writes the relay response as protobuf when the client accepts it (or sent the relay as protobuf
+// without an Accept header) and as json otherwise, in the content coding negotiated with Accept-Encoding
+func writeRelayResponse(w http.ResponseWriter, r *http.Request, res *pocketTypes.RelayResponse) {
+	var (
+		body        []byte
+		contentType string
+		err         error
+	)
+	if acceptsProtobuf(r) {
+		body, err = res.Marshal()
+		contentType = protobufContentType
+	} else {
+		body, err = json.Marshal(RPCRelayResponse{
+			Signature: res.Signature,
+			Response:  res.Response,
+		})
+		contentType = "application/json; charset=utf-8"
+	}
+	if err != nil {
+		WriteErrorResponse(w, 400, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Add("Vary", "Accept-Encoding")
+	coding := negotiateContentCoding(r.Header.Get("Accept-Encoding"))
+	if coding == "" || len(body) < minCompressedBodySize {
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(body); err != nil {
+			fmt.Println(fmt.Errorf("error in RPC Handler writeRelayResponse: %v", err))
+		}
+		return
+	}
+	var buf bytes.Buffer
+	cw := contentCodings[coding].newWriter(&buf)
+	if _, err := cw.Write(body); err != nil {
+		WriteErrorResponse(w, 500, err.Error())
+		return
+	}
+	if err := cw.Close(); err != nil {
+		WriteErrorResponse(w, 500, err.Error())
+		return
+	}
+	w.Header().Set("Content-Encoding", coding)
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		fmt.Println(fmt.Errorf("error in RPC Handler writeRelayResponse: %v", err))
+	}
+}
+
+// negotiateContentCoding returns the content coding of the node with the highest quality in the Accept-Encoding header,
+// or "" for identity
+func negotiateContentCoding(acceptEncoding string) string {
+	qualities := make(map[string]float64)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, q := parseQuality(part)
+		if coding != "" {
+			qualities[coding] = q
+		}
+	}
+	best, bestQ := "", 0.0
+	for _, coding := range contentCodingPreference {
+		q, ok := qualities[coding]
+		if !ok {
+			q = qualities["*"]
+		}
+		if q > bestQ {
+			best, bestQ = coding, q
+		}
+	}
+	return best
+}
+
+// parseQuality parses an element of an Accept or Accept-Encoding header: the lower case value and its quality
+func parseQuality(s string) (string, float64) {
+	parts := strings.Split(s, ";")
+	value, q := strings.ToLower(strings.TrimSpace(parts[0])), 1.0
+	for _, param := range parts[1:] {
+		param = strings.TrimSpace(param)
+		if strings.HasPrefix(param, "q=") {
+			v, err := strconv.ParseFloat(param[2:], 64)
+			if err != nil {
+				return value, 0
+			}
+			q = v
+		}
+	}
+	return value, q
+}
+
+// acceptsProtobuf returns whether the relay response is encoded as protobuf: the media type of Accept, or the one of
+// the relay when Accept has neither json nor protobuf
+func acceptsProtobuf(r *http.Request) bool {
+	protobufQ, jsonQ := 0.0, 0.0
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		switch mediaType, q := parseQuality(part); mediaType {
+		case protobufContentType:
+			protobufQ = q
+		case "application/json":
+			jsonQ = q
+		}
+	}
+	if protobufQ <= 0 && jsonQ <= 0 {
+		return isProtobuf(r.Header.Get("Content-Type"))
+	}
+	return protobufQ > jsonQ
+}
+
+func isProtobuf(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == protobufContentType
+}
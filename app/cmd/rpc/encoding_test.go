@@ -0,0 +1,63 @@
+package rpc
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiateContentCoding(t *testing.T) {
+	tests := []struct {
+		acceptEncoding string
+		expected       string
+	}{
+		{"", ""},
+		{"identity", ""},
+		{"br", ""},
+		{"gzip", "gzip"},
+		{"deflate, GZIP", "gzip"},
+		{"gzip;q=0", ""},
+		{"*", contentCodingPreference[0]},
+		{"gzip;q=0.5, zstd;q=0.1", "gzip"},
+		{"gzip;q=0.5, *;q=1", contentCodingPreference[0]},
+		{"gzip;q=abc", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, negotiateContentCoding(tt.acceptEncoding), tt.acceptEncoding)
+	}
+	if _, ok := contentCodings["zstd"]; ok {
+		assert.Equal(t, "zstd", negotiateContentCoding("gzip, zstd"))
+		assert.Equal(t, "gzip", negotiateContentCoding("gzip, zstd;q=0.9"))
+		assert.Equal(t, "zstd", negotiateContentCoding("*, gzip;q=0"))
+	} else {
+		// without cgo
+		assert.Equal(t, "", negotiateContentCoding("zstd"))
+		assert.Equal(t, "", negotiateContentCoding("*, gzip;q=0"))
+	}
+}
+
+func TestAcceptsProtobuf(t *testing.T) {
+	tests := []struct {
+		contentType string
+		accept      string
+		expected    bool
+	}{
+		{"", "", false},
+		{"application/json", "", false},
+		{protobufContentType, "", true},
+		{protobufContentType, "*/*", true},
+		{protobufContentType, "application/json", false},
+		{"application/json", protobufContentType, true},
+		{"", "application/json;q=0.5, " + protobufContentType, true},
+		{protobufContentType, protobufContentType + ";q=0.2, application/json;q=0.8", false},
+		{"application/x-protobuf; charset=binary", "", true},
+	}
+	for _, tt := range tests {
+		r, err := http.NewRequest("POST", "localhost:8081/v1/client/relay", nil)
+		assert.Nil(t, err)
+		r.Header.Set("Content-Type", tt.contentType)
+		r.Header.Set("Accept", tt.accept)
+		assert.Equal(t, tt.expected, acceptsProtobuf(r), "content type %q, accept %q", tt.contentType, tt.accept)
+	}
+}
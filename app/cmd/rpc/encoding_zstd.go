@@ -0,0 +1,19 @@
+//go:build cgo
+// +build cgo
+
+package rpc
+
+import (
+	"io"
+
+	"github.com/DataDog/zstd"
+)
+
+// zstd is a cgo library, the relay bodies are not zstd encoded in the builds without cgo
+func init() {
+	contentCodings["zstd"] = contentCoding{
+		newReader: func(r io.Reader) (io.ReadCloser, error) { return zstd.NewReader(r), nil },
+		newWriter: func(w io.Writer) io.WriteCloser { return zstd.NewWriter(w) },
+	}
+	contentCodingPreference = append([]string{"zstd"}, contentCodingPreference...)
+}
@@ -0,0 +1,149 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pokt-network/pocket-core/app"
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+)
+
+const (
+	EventsPath = "/v1/events"
+
+	eventsWriteWait  = 10 * time.Second
+	eventsPongWait   = 60 * time.Second
+	eventsPingPeriod = eventsPongWait * 9 / 10
+	// control frames are limited to 125 bytes, 2 of which are the close code
+	maxCloseReasonLen = 123
+)
+
+var (
+	eventsUpgrader = websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     checkEventsOrigin,
+	}
+	eventSubscribers   int64
+	eventSubscriberIDs uint64
+)
+
+// checkEventsOrigin allows the clients that are not browsers (no Origin header), the same origin and the event_origins of
+// the config (* for every origin)
+func checkEventsOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	for _, o := range app.GlobalConfig.PocketConfig.EventOrigins {
+		if o == "*" || o == origin {
+			return true
+		}
+	}
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, r.Host)
+}
+
+// parse the stream filter and resume height out of the url query of an events request
+// e.g. /v1/events?types=tx,event&query=tx.message_type='claim'&event_types=claim&from_height=100
+func eventsParams(r *http.Request) (filter app.StreamFilter, fromHeight int64, err error) {
+	values := r.URL.Query()
+	if types := values.Get("types"); types != "" {
+		for _, t := range strings.Split(types, ",") {
+			switch t {
+			case app.StreamTypeBlock, app.StreamTypeTx, app.StreamTypeEvent:
+				filter.Types = append(filter.Types, t)
+			default:
+				return filter, 0, fmt.Errorf("unsupported stream type: %s, must be one of %s, %s or %s", t, app.StreamTypeBlock, app.StreamTypeTx, app.StreamTypeEvent)
+			}
+		}
+	}
+	if q := values.Get("query"); q != "" {
+		if filter.TxQuery, err = query.New(q); err != nil {
+			return filter, 0, fmt.Errorf("unable to parse the tx query: %s", err.Error())
+		}
+	}
+	if eventTypes := values.Get("event_types"); eventTypes != "" {
+		filter.EventTypes = strings.Split(eventTypes, ",")
+	}
+	if h := values.Get("from_height"); h != "" {
+		if fromHeight, err = strconv.ParseInt(h, 10, 64); err != nil || fromHeight < 0 {
+			return filter, 0, fmt.Errorf("invalid from_height: %s", h)
+		}
+	}
+	return filter, fromHeight, nil
+}
+
+// Events upgrades the request to a websocket and streams new blocks, txs and module events as json messages.
+// With from_height the stream first replays every committed height since then, so a client can resume after
+// a disconnect from the height of the last block message it received + 1.
+func Events(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	filter, fromHeight, err := eventsParams(r)
+	if err != nil {
+		WriteErrorResponse(w, 400, err.Error())
+		return
+	}
+	if err = app.PCA.ValidateStreamHeight(fromHeight); err != nil {
+		WriteErrorResponse(w, 400, err.Error())
+		return
+	}
+	if atomic.AddInt64(&eventSubscribers, 1) > int64(app.GlobalConfig.PocketConfig.MaxEventSubscribers) {
+		atomic.AddInt64(&eventSubscribers, -1)
+		WriteErrorResponse(w, http.StatusServiceUnavailable, "max event subscribers reached")
+		return
+	}
+	defer atomic.AddInt64(&eventSubscribers, -1)
+	conn, err := eventsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return // the upgrader already replied with an http error
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// the client doesn't send anything but control frames, reading is only done to process them and detect a close
+	_ = conn.SetReadDeadline(time.Now().Add(eventsPongWait))
+	conn.SetPongHandler(func(string) error { return conn.SetReadDeadline(time.Now().Add(eventsPongWait)) })
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+	go func() {
+		ticker := time.NewTicker(eventsPingPeriod)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(eventsWriteWait)); err != nil {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+	subscriber := fmt.Sprintf("events-%d-%s", atomic.AddUint64(&eventSubscriberIDs, 1), r.RemoteAddr)
+	err = app.PCA.StreamEvents(ctx, subscriber, fromHeight, filter, func(msg app.StreamMessage) error {
+		_ = conn.SetWriteDeadline(time.Now().Add(eventsWriteWait))
+		return conn.WriteJSON(msg)
+	})
+	if err != nil && ctx.Err() == nil {
+		reason := err.Error()
+		if len(reason) > maxCloseReasonLen {
+			reason = reason[:maxCloseReasonLen]
+		}
+		_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseInternalServerErr, reason), time.Now().Add(eventsWriteWait))
+	}
+}
@@ -0,0 +1,176 @@
+package rpc
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pokt-network/pocket-core/app"
+	"github.com/pokt-network/pocket-core/types"
+)
+
+// RouteGroup returns the group of the route path: relay (/v1/client/*), private (/v1/private/*), debug (/debug/*) or
+// query (every other route)
+func RouteGroup(path string) string {
+	switch {
+	case strings.HasPrefix(path, "/v1/client/"):
+		return types.RPCGroupRelay
+	case strings.HasPrefix(path, "/v1/private/"):
+		return types.RPCGroupPrivate
+	case strings.HasPrefix(path, "/debug/"):
+		return types.RPCGroupDebug
+	default:
+		return types.RPCGroupQuery
+	}
+}
+
+// groupConfig returns the config of the route group, the default one if it is not in rpc_groups
+func groupConfig(group string) types.RPCGroupConfig {
+	return app.GlobalConfig.PocketConfig.RPCGroup(group)
+}
+
+// listener is a listen address and the route groups it serves
+type listener struct {
+	addr   string
+	groups []string
+}
+
+func (l listener) serves(group string) bool {
+	for _, g := range l.groups {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
+
+// groupListeners returns the listeners of the route groups: the groups of a listen address share its listener, and
+// the groups without one are served on the rpc port
+func groupListeners(port string) []listener {
+	var res []listener
+	for _, group := range types.RPCGroups {
+		addr := groupConfig(group).ListenAddr
+		if addr == "" {
+			addr = ":" + port
+		}
+		found := false
+		for i := range res {
+			if res[i].addr == addr {
+				res[i].groups, found = append(res[i].groups, group), true
+			}
+		}
+		if !found {
+			res = append(res, listener{addr: addr, groups: []string{group}})
+		}
+	}
+	return res
+}
+
+// groupLimits are the timeout and the concurrency limit of a route group, shared by the listener of the group and the
+// JSON-RPC calls of its routes
+type groupLimits struct {
+	timeout time.Duration
+	slots   chan struct{}
+}
+
+// newGroupLimits returns the limits of the config of the group, with the default timeout (ms) if it has none
+func newGroupLimits(group string, defaultTimeout int64) *groupLimits {
+	c := groupConfig(group)
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	l := &groupLimits{timeout: time.Duration(timeout) * time.Millisecond}
+	if c.MaxConcurrent > 0 {
+		l.slots = make(chan struct{}, c.MaxConcurrent)
+	}
+	return l
+}
+
+// acquire takes a concurrency slot of the group, false if every slot is taken
+func (l *groupLimits) acquire() bool {
+	if l.slots == nil {
+		return true
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// release frees the slot taken by acquire
+func (l *groupLimits) release() {
+	if l.slots != nil {
+		<-l.slots
+	}
+}
+
+// newLimits returns the limits of every route group
+func newLimits(defaultTimeout int64) map[string]*groupLimits {
+	limits := make(map[string]*groupLimits)
+	for _, group := range types.RPCGroups {
+		limits[group] = newGroupLimits(group, defaultTimeout)
+	}
+	return limits
+}
+
+// listenerHandler serves the routes of the groups of a listener, each group with its own handler and limits
+func listenerHandler(groups []string, routes map[string]Routes, limits map[string]*groupLimits) http.Handler {
+	handlers := make(map[string]http.Handler)
+	for _, group := range groups {
+		handlers[group] = groupHandler(group, routes[group], limits[group])
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h, ok := handlers[RouteGroup(r.URL.Path)]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// groupHandler serves the routes of a group with its limits and cors policy
+func groupHandler(group string, routes Routes, limits *groupLimits) http.Handler {
+	c := groupConfig(group)
+	var handler http.Handler = http.TimeoutHandler(Router(routes), limits.timeout, timeoutMessage)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		setCORSHeaders(w, r, c.CORSOrigins)
+		if !limits.acquire() {
+			WriteErrorResponse(w, http.StatusServiceUnavailable, concurrencyMessage)
+			return
+		}
+		defer limits.release()
+		handler.ServeHTTP(w, r)
+	})
+}
+
+const (
+	timeoutMessage     = "Server Timeout Handling Request"
+	concurrencyMessage = "too many concurrent requests, try again later"
+)
+
+// setCORSHeaders allows the origin of the request if it is in origins ("*" allows every origin)
+func setCORSHeaders(w http.ResponseWriter, r *http.Request, origins []string) {
+	allowed := ""
+	for _, o := range origins {
+		if o == "*" {
+			allowed = "*"
+			break
+		}
+		if o == r.Header.Get("Origin") {
+			allowed = o
+		}
+	}
+	if allowed == "" {
+		return
+	}
+	if allowed != "*" {
+		w.Header().Add("Vary", "Origin")
+	}
+	w.Header().Set("Access-Control-Allow-Origin", allowed)
+	w.Header().Set("Access-Control-Allow-Methods", "POST")
+	w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+}
@@ -0,0 +1,312 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pokt-network/pocket-core/app"
+	codecTypes "github.com/pokt-network/pocket-core/codec/types"
+	"github.com/pokt-network/pocket-core/types"
+	appsTypes "github.com/pokt-network/pocket-core/x/apps/types"
+	authTypes "github.com/pokt-network/pocket-core/x/auth/types"
+	govTypes "github.com/pokt-network/pocket-core/x/gov/types"
+	nodesTypes "github.com/pokt-network/pocket-core/x/nodes/types"
+	pocketTypes "github.com/pokt-network/pocket-core/x/pocketcore/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+// StartGRPC serves the query services of the modules and the tx service over gRPC on the address, with the tls
+// certificate of the rpc server if it has one
+func StartGRPC(addr string) {
+	srv, err := NewGRPCServer()
+	if err != nil {
+		log.Fatal(fmt.Errorf("unable to start the grpc server: %s", err.Error()))
+	}
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Fatal(fmt.Errorf("unable to start the grpc server: %s", err.Error()))
+	}
+	log.Fatal(srv.Serve(lis))
+}
+
+// NewGRPCServer returns a gRPC server with the query services of the modules and the tx service registered
+func NewGRPCServer() (*grpc.Server, error) {
+	opts := []grpc.ServerOption{grpc.ForceServerCodec(GogoCodec{})}
+	cfg := app.GlobalConfig.PocketConfig
+	if cfg.RPCTLSCertFile != "" {
+		tlsConfig, err := NewTLSConfig(cfg.RPCTLSCertFile, cfg.RPCTLSKeyFile, cfg.RPCTLSClientCAFile, rpcLogger())
+		if err != nil {
+			return nil, err
+		}
+		// gRPC runs over http/2 only
+		tlsConfig.NextProtos = []string{"h2"}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	srv := grpc.NewServer(opts...)
+	nodesTypes.RegisterQueryServer(srv, nodesQueryServer{})
+	appsTypes.RegisterQueryServer(srv, appsQueryServer{})
+	pocketTypes.RegisterQueryServer(srv, pocketQueryServer{})
+	govTypes.RegisterQueryServer(srv, govQueryServer{})
+	authTypes.RegisterQueryServer(srv, authQueryServer{})
+	types.RegisterServiceServer(srv, txServer{})
+	return srv, nil
+}
+
+// GogoCodec encodes the gRPC messages with their generated gogoproto marshalers
+type GogoCodec struct{}
+
+func (GogoCodec) Marshal(v interface{}) ([]byte, error) {
+	m, ok := v.(proto.Marshaler)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a gogoproto message", v)
+	}
+	return m.Marshal()
+}
+
+func (GogoCodec) Unmarshal(data []byte, v interface{}) error {
+	m, ok := v.(proto.Unmarshaler)
+	if !ok {
+		return fmt.Errorf("%T is not a gogoproto message", v)
+	}
+	return m.Unmarshal(data)
+}
+
+func (GogoCodec) Name() string {
+	return "proto"
+}
+
+// grpcError returns the status of a failed query, the equivalent of the 400 of the rpc routes
+func grpcError(err error) error {
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+func toParams(params []app.SingleParamReturn) []types.Param {
+	res := make([]types.Param, 0, len(params))
+	for _, p := range params {
+		res = append(res, types.Param{Key: p.Key, Value: p.Value})
+	}
+	return res
+}
+
+func toPageResponse(p app.Page) types.PageResponse {
+	return types.PageResponse{Page: int32(p.Page), TotalPages: int32(p.Total)}
+}
+
+type nodesQueryServer struct{}
+
+func (nodesQueryServer) Node(_ context.Context, req *nodesTypes.QueryNodeRequest) (*nodesTypes.QueryNodeResponse, error) {
+	res, err := app.PCA.QueryNode(req.Address, req.Height)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &nodesTypes.QueryNodeResponse{Node: res.ToProto()}, nil
+}
+
+func (nodesQueryServer) Nodes(_ context.Context, req *nodesTypes.QueryNodesRequest) (*nodesTypes.QueryNodesResponse, error) {
+	page, err := app.PCA.QueryNodes(req.Height, nodesTypes.QueryValidatorsParams{
+		StakingStatus: types.StakeStatus(req.StakingStatus),
+		JailedStatus:  int(req.JailedStatus),
+		Blockchain:    req.Blockchain,
+		Page:          int(req.Pagination.Page),
+		Limit:         int(req.Pagination.PerPage),
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	res := &nodesTypes.QueryNodesResponse{Pagination: toPageResponse(page)}
+	nodes, _ := page.Result.([]nodesTypes.Validator)
+	for _, n := range nodes {
+		res.Nodes = append(res.Nodes, n.ToProto())
+	}
+	return res, nil
+}
+
+func (nodesQueryServer) SigningInfo(_ context.Context, req *nodesTypes.QuerySigningInfoRequest) (*nodesTypes.QuerySigningInfoResponse, error) {
+	res, err := app.PCA.QuerySigningInfo(req.Height, req.Address)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &nodesTypes.QuerySigningInfoResponse{SigningInfo: res}, nil
+}
+
+func (nodesQueryServer) Params(_ context.Context, req *nodesTypes.QueryParamsRequest) (*nodesTypes.QueryParamsResponse, error) {
+	res, err := app.PCA.QueryAllParams(req.Height)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &nodesTypes.QueryParamsResponse{Params: toParams(res.NodeParams)}, nil
+}
+
+type appsQueryServer struct{}
+
+func (appsQueryServer) App(_ context.Context, req *appsTypes.QueryAppRequest) (*appsTypes.QueryAppResponse, error) {
+	res, err := app.PCA.QueryApp(req.Address, req.Height)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &appsTypes.QueryAppResponse{App: res.ToProto()}, nil
+}
+
+func (appsQueryServer) Apps(_ context.Context, req *appsTypes.QueryAppsRequest) (*appsTypes.QueryAppsResponse, error) {
+	page, err := app.PCA.QueryApps(req.Height, appsTypes.QueryApplicationsWithOpts{
+		StakingStatus: types.StakeStatus(req.StakingStatus),
+		Blockchain:    req.Blockchain,
+		Page:          int(req.Pagination.Page),
+		Limit:         int(req.Pagination.PerPage),
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	res := &appsTypes.QueryAppsResponse{Pagination: toPageResponse(page)}
+	apps, _ := page.Result.(appsTypes.Applications)
+	for _, a := range apps {
+		res.Apps = append(res.Apps, a.ToProto())
+	}
+	return res, nil
+}
+
+func (appsQueryServer) Params(_ context.Context, req *appsTypes.QueryParamsRequest) (*appsTypes.QueryParamsResponse, error) {
+	res, err := app.PCA.QueryAllParams(req.Height)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &appsTypes.QueryParamsResponse{Params: toParams(res.AppParams)}, nil
+}
+
+type pocketQueryServer struct{}
+
+func (pocketQueryServer) Claim(_ context.Context, req *pocketTypes.QueryClaimRequest) (*pocketTypes.QueryClaimResponse, error) {
+	res, err := app.PCA.QueryClaim(req.Address, req.AppPubkey, req.Chain, req.EvidenceType, req.SessionBlockHeight, req.Height)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pocketTypes.QueryClaimResponse{Claim: *res}, nil
+}
+
+func (pocketQueryServer) Claims(_ context.Context, req *pocketTypes.QueryClaimsRequest) (*pocketTypes.QueryClaimsResponse, error) {
+	page, err := app.PCA.QueryClaims(req.Address, req.Height, int(req.Pagination.Page), int(req.Pagination.PerPage))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	claims, _ := page.Result.([]pocketTypes.MsgClaim)
+	return &pocketTypes.QueryClaimsResponse{Claims: claims, Pagination: toPageResponse(page)}, nil
+}
+
+func (pocketQueryServer) SupportedChains(_ context.Context, req *pocketTypes.QuerySupportedChainsRequest) (*pocketTypes.QuerySupportedChainsResponse, error) {
+	res, err := app.PCA.QueryPocketSupportedBlockchains(req.Height)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pocketTypes.QuerySupportedChainsResponse{Chains: res}, nil
+}
+
+func (pocketQueryServer) Params(_ context.Context, req *pocketTypes.QueryParamsRequest) (*pocketTypes.QueryParamsResponse, error) {
+	res, err := app.PCA.QueryAllParams(req.Height)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pocketTypes.QueryParamsResponse{Params: toParams(res.PocketParams)}, nil
+}
+
+type govQueryServer struct{}
+
+func (govQueryServer) Param(_ context.Context, req *govTypes.QueryParamRequest) (*govTypes.QueryParamResponse, error) {
+	res, err := app.PCA.QueryParam(req.Height, req.Key)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	if res.Key == "" {
+		return nil, status.Error(codes.NotFound, "no param with the key "+req.Key)
+	}
+	return &govTypes.QueryParamResponse{Param: types.Param{Key: res.Key, Value: res.Value}}, nil
+}
+
+func (govQueryServer) Params(_ context.Context, req *govTypes.QueryParamsRequest) (*govTypes.QueryParamsResponse, error) {
+	res, err := app.PCA.QueryAllParams(req.Height)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &govTypes.QueryParamsResponse{Params: toParams(res.GovParams)}, nil
+}
+
+func (govQueryServer) Upgrade(_ context.Context, req *govTypes.QueryUpgradeRequest) (*govTypes.QueryUpgradeResponse, error) {
+	res, err := app.PCA.QueryUpgrade(req.Height)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &govTypes.QueryUpgradeResponse{Upgrade: res}, nil
+}
+
+func (govQueryServer) DAO(_ context.Context, req *govTypes.QueryDAORequest) (*govTypes.QueryDAOResponse, error) {
+	owner, err := app.PCA.QueryDaoOwner(req.Height)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	balance, err := app.PCA.QueryDaoBalance(req.Height)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &govTypes.QueryDAOResponse{Owner: owner.String(), Balance: balance.String()}, nil
+}
+
+type authQueryServer struct{}
+
+func (authQueryServer) Account(_ context.Context, req *authTypes.QueryAccountRequest) (*authTypes.QueryAccountResponse, error) {
+	res, err := app.PCA.QueryAccount(req.Address, req.Height)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	var msg proto.Message
+	switch acc := (*res).(type) {
+	case nil:
+		return nil, status.Error(codes.NotFound, "no account for the address "+req.Address)
+	case *authTypes.BaseAccount:
+		p := acc.ToProto()
+		msg = &p
+	case *authTypes.ModuleAccount:
+		p := acc.ToProto()
+		msg = &p
+	case *authTypes.MultiSigAccount:
+		p := acc.ToProto()
+		msg = &p
+	default:
+		return nil, status.Error(codes.Internal, fmt.Sprintf("unknown account type %T", acc))
+	}
+	any, err := codecTypes.NewAnyWithValue(msg)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &authTypes.QueryAccountResponse{Account: any}, nil
+}
+
+func (authQueryServer) Balance(_ context.Context, req *authTypes.QueryBalanceRequest) (*authTypes.QueryBalanceResponse, error) {
+	res, err := app.PCA.QueryBalance(req.Address, req.Height)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &authTypes.QueryBalanceResponse{Balance: res.String()}, nil
+}
+
+func (authQueryServer) Params(_ context.Context, req *authTypes.QueryParamsRequest) (*authTypes.QueryParamsResponse, error) {
+	res, err := app.PCA.QueryAllParams(req.Height)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &authTypes.QueryParamsResponse{Params: toParams(res.AuthParams)}, nil
+}
+
+type txServer struct{}
+
+func (txServer) BroadcastTx(_ context.Context, req *types.BroadcastTxRequest) (*types.BroadcastTxResponse, error) {
+	res, err := app.PCA.SendRawTx(req.Address, req.TxBytes)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &types.BroadcastTxResponse{TxResponse: res}, nil
+}
@@ -0,0 +1,137 @@
+package rpc
+
+import (
+	"context"
+	"crypto/tls"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pokt-network/pocket-core/app"
+	"github.com/pokt-network/pocket-core/codec"
+	"github.com/pokt-network/pocket-core/types"
+	authTypes "github.com/pokt-network/pocket-core/x/auth/types"
+	govTypes "github.com/pokt-network/pocket-core/x/gov/types"
+	nodesTypes "github.com/pokt-network/pocket-core/x/nodes/types"
+	pocketTypes "github.com/pokt-network/pocket-core/x/pocketcore/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmTypes "github.com/tendermint/tendermint/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+func TestRPC_GRPC(t *testing.T) {
+	codec.UpgradeHeight = 7000
+	_, _, cleanup := NewInMemoryTendermintNode(t, oneValTwoNodeGenesisState())
+	_, stopCli, evtChan := subscribeTo(t, tmTypes.EventNewBlock)
+	defer func() {
+		cleanup()
+		stopCli()
+	}()
+	<-evtChan // Wait for block
+	kb := getInMemoryKeybase()
+	cb, err := kb.GetCoinbase()
+	require.NoError(t, err)
+
+	srv, err := NewGRPCServer()
+	require.NoError(t, err)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure(), grpc.WithDefaultCallOptions(grpc.ForceCodec(GogoCodec{})))
+	require.NoError(t, err)
+	defer conn.Close()
+	ctx := context.Background()
+
+	// nodes
+	nodes := nodesTypes.NewQueryClient(conn)
+	node, err := nodes.Node(ctx, &nodesTypes.QueryNodeRequest{Address: cb.GetAddress().String()})
+	require.NoError(t, err)
+	assert.Equal(t, cb.GetAddress(), node.Node.Address)
+	page, err := nodes.Nodes(ctx, &nodesTypes.QueryNodesRequest{Pagination: types.PageRequest{Page: 1, PerPage: 10}})
+	require.NoError(t, err)
+	assert.NotEmpty(t, page.Nodes)
+	assert.Equal(t, int32(1), page.Pagination.Page)
+	params, err := nodes.Params(ctx, &nodesTypes.QueryParamsRequest{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, params.Params)
+	_, err = nodes.Node(ctx, &nodesTypes.QueryNodeRequest{Address: "invalid"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// pocketcore
+	chains, err := pocketTypes.NewQueryClient(conn).SupportedChains(ctx, &pocketTypes.QuerySupportedChainsRequest{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, chains.Chains)
+
+	// gov
+	gov := govTypes.NewQueryClient(conn)
+	param, err := gov.Param(ctx, &govTypes.QueryParamRequest{Key: "pos/StakeDenom"})
+	require.NoError(t, err)
+	assert.Equal(t, "upokt", param.Param.Value)
+	_, err = gov.Param(ctx, &govTypes.QueryParamRequest{Key: "pos/Unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// auth
+	auth := authTypes.NewQueryClient(conn)
+	acc, err := auth.Account(ctx, &authTypes.QueryAccountRequest{Address: cb.GetAddress().String()})
+	require.NoError(t, err)
+	assert.Equal(t, "/x.auth.ProtoBaseAccount", acc.Account.TypeUrl)
+	var base authTypes.ProtoBaseAccount
+	require.NoError(t, base.Unmarshal(acc.Account.Value))
+	assert.Equal(t, cb.GetAddress(), base.Address)
+	balance, err := auth.Balance(ctx, &authTypes.QueryBalanceRequest{Address: cb.GetAddress().String()})
+	require.NoError(t, err)
+	assert.NotEqual(t, "0", balance.Balance)
+
+	// tx, the check tx result of an invalid tx is returned like the rpc route does
+	tx := types.NewServiceClient(conn)
+	res, err := tx.BroadcastTx(ctx, &types.BroadcastTxRequest{Address: cb.GetAddress().String(), TxBytes: []byte("invalid")})
+	require.NoError(t, err)
+	assert.NotZero(t, res.TxResponse.Code)
+	_, err = tx.BroadcastTx(ctx, &types.BroadcastTxRequest{Address: "invalid", TxBytes: []byte("invalid")})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestRPC_GRPCTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca, caKey, caPem, _ := newTestCert(t, "ca", nil, nil)
+	_, _, serverPem, serverKey := newTestCert(t, "server", ca, caKey)
+	caFile, certFile, keyFile := filepath.Join(dir, "ca.pem"), filepath.Join(dir, "server.pem"), filepath.Join(dir, "server.key")
+	require.NoError(t, ioutil.WriteFile(caFile, caPem, 0600))
+	require.NoError(t, ioutil.WriteFile(certFile, serverPem, 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, serverKey, 0600))
+	prev := app.GlobalConfig.PocketConfig
+	app.GlobalConfig.PocketConfig.RPCTLSCertFile, app.GlobalConfig.PocketConfig.RPCTLSKeyFile = certFile, keyFile
+	defer func() { app.GlobalConfig.PocketConfig = prev }()
+
+	srv, err := NewGRPCServer()
+	require.NoError(t, err)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+	pool, err := LoadCertPool(caFile)
+	require.NoError(t, err)
+
+	// the server negotiates h2 over alpn
+	conn, err := tls.Dial("tcp", lis.Addr().String(), &tls.Config{RootCAs: pool, ServerName: "localhost", NextProtos: []string{"h2"}})
+	require.NoError(t, err)
+	assert.Equal(t, "h2", conn.ConnectionState().NegotiatedProtocol)
+	assert.Equal(t, "server", conn.ConnectionState().PeerCertificates[0].Subject.CommonName)
+	_ = conn.Close()
+	// and a grpc client connects over tls
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	grpcConn, err := grpc.DialContext(ctx, lis.Addr().String(), grpc.WithBlock(),
+		grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{RootCAs: pool, ServerName: "localhost"})))
+	require.NoError(t, err)
+	assert.Equal(t, connectivity.Ready, grpcConn.GetState())
+	_ = grpcConn.Close()
+}
@@ -0,0 +1,262 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/pokt-network/pocket-core/types"
+)
+
+const (
+	JSONRPCPath    = "/v1/jsonrpc"
+	JSONRPCVersion = "2.0"
+	// MaxJSONRPCBatch is the maximum number of calls of a batch request
+	MaxJSONRPCBatch = 100
+	// DiscoverMethod returns the OpenRPC description of the methods
+	DiscoverMethod = "rpc.discover"
+)
+
+// the standard JSON-RPC 2.0 error codes; the errors of the methods are server errors (-32000) with the http status
+// and, for an sdk.Error, its codespace and code in the data
+const (
+	JSONRPCParseError     = -32700
+	JSONRPCInvalidRequest = -32600
+	JSONRPCMethodNotFound = -32601
+	JSONRPCInvalidParams  = -32602
+	JSONRPCInternalError  = -32603
+	JSONRPCServerError    = -32000
+)
+
+type JSONRPCRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	ID      json.RawMessage `json:"id,omitempty"`
+}
+
+type JSONRPCResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *JSONRPCError   `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+type JSONRPCError struct {
+	Code    int               `json:"code"`
+	Message string            `json:"message"`
+	Data    *JSONRPCErrorData `json:"data,omitempty"`
+}
+
+type JSONRPCErrorData struct {
+	HTTPStatus int                 `json:"http_status,omitempty"`
+	Codespace  types.CodespaceType `json:"codespace,omitempty"`
+	Code       types.CodeType      `json:"code,omitempty"`
+}
+
+// MethodName returns the JSON-RPC method name of the route: its path after /v1, with underscores (query_height for
+// /v1/query/height), or version for /v1
+func MethodName(route Route) string {
+	name := strings.Trim(strings.TrimPrefix(route.Path, "/v1"), "/")
+	if name == "" {
+		return "version"
+	}
+	return strings.ReplaceAll(name, "/", "_")
+}
+
+// JSONRPCMethods returns the routes exposed as JSON-RPC methods by name: the query and the client (relay group) routes.
+// The private and debug routes are only served on their own listeners
+func JSONRPCMethods(routes Routes) map[string]Route {
+	methods := make(map[string]Route)
+	for _, route := range routes {
+		if route.Method == "OPTIONS" || route.Path == JSONRPCPath {
+			continue
+		}
+		if group := RouteGroup(route.Path); group != types.RPCGroupQuery && group != types.RPCGroupRelay {
+			continue
+		}
+		methods[MethodName(route)] = route
+	}
+	return methods
+}
+
+// JSONRPC serves the methods of the routes over JSON-RPC 2.0, single or batch requests. Every call is handled by the
+// handler of its route, with the params as the request body. A batch has the body limit of a single request. The
+// JSON-RPC route is served with the limits of the query group, and the calls of the client routes take the limits of
+// the relay group (nil = no limits)
+func JSONRPC(routes Routes, limits map[string]*groupLimits) httprouter.Handle {
+	methods := JSONRPCMethods(routes)
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxRequestBody))
+		if err != nil {
+			WriteErrorResponse(w, 400, err.Error())
+			return
+		}
+		body = bytes.TrimSpace(body)
+		// batch request
+		if len(body) > 0 && body[0] == '[' {
+			var calls []json.RawMessage
+			if err := json.Unmarshal(body, &calls); err != nil {
+				writeJSONRPC(w, jsonRPCErrorResponse(nil, JSONRPCParseError, err.Error()))
+				return
+			}
+			if len(calls) == 0 || len(calls) > MaxJSONRPCBatch {
+				writeJSONRPC(w, jsonRPCErrorResponse(nil, JSONRPCInvalidRequest, "a batch must have 1 to "+strconv.Itoa(MaxJSONRPCBatch)+" calls"))
+				return
+			}
+			res := make([]JSONRPCResponse, 0, len(calls))
+			for _, c := range calls {
+				if resp, ok := jsonRPCCall(methods, limits, r, c); ok {
+					res = append(res, resp)
+				}
+			}
+			if len(res) == 0 {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			writeJSONRPC(w, res)
+			return
+		}
+		resp, ok := jsonRPCCall(methods, limits, r, body)
+		if !ok {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeJSONRPC(w, resp)
+	}
+}
+
+// jsonRPCCall handles a call and returns its response, unless the call is a notification (no id)
+func jsonRPCCall(methods map[string]Route, limits map[string]*groupLimits, r *http.Request, raw json.RawMessage) (JSONRPCResponse, bool) {
+	var req JSONRPCRequest
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return jsonRPCErrorResponse(nil, JSONRPCParseError, err.Error()), true
+	}
+	if req.JSONRPC != JSONRPCVersion || req.Method == "" {
+		return jsonRPCErrorResponse(req.ID, JSONRPCInvalidRequest, `expected "jsonrpc": "2.0" and a method`), true
+	}
+	notification := len(req.ID) == 0
+	var resp JSONRPCResponse
+	if req.Method == DiscoverMethod {
+		doc, err := json.Marshal(OpenRPC(methods))
+		if err != nil {
+			resp = jsonRPCErrorResponse(req.ID, JSONRPCInternalError, err.Error())
+		} else {
+			resp = JSONRPCResponse{JSONRPC: JSONRPCVersion, Result: doc, ID: req.ID}
+		}
+		return resp, !notification
+	}
+	route, ok := methods[req.Method]
+	if !ok {
+		return jsonRPCErrorResponse(req.ID, JSONRPCMethodNotFound, "method not found: "+req.Method), !notification
+	}
+	params := bytes.TrimSpace(req.Params)
+	if len(params) > 0 && params[0] != '{' && !bytes.Equal(params, []byte("null")) {
+		return jsonRPCErrorResponse(req.ID, JSONRPCInvalidParams, "the params must be an object"), !notification
+	}
+	sub, err := http.NewRequest(route.Method, route.Path, bytes.NewReader(params))
+	if err != nil {
+		return jsonRPCErrorResponse(req.ID, JSONRPCInternalError, err.Error()), !notification
+	}
+	sub = sub.WithContext(r.Context())
+	sub.Header.Set("Content-Type", "application/json")
+	sub.RemoteAddr, sub.Host = r.RemoteAddr, r.Host
+	rec := newBufferedResponse()
+	if group := RouteGroup(route.Path); group != types.RPCGroupQuery && limits[group] != nil {
+		if msg := serveLimited(route, rec, sub, limits[group]); msg != "" {
+			err := &JSONRPCError{Code: JSONRPCServerError, Message: msg, Data: &JSONRPCErrorData{HTTPStatus: http.StatusServiceUnavailable}}
+			return JSONRPCResponse{JSONRPC: JSONRPCVersion, Error: err, ID: req.ID}, !notification
+		}
+	} else {
+		route.HandlerFunc(rec, sub, httprouter.Params{})
+	}
+	if rec.status == http.StatusOK {
+		result := bytes.TrimSpace(rec.body.Bytes())
+		if !json.Valid(result) {
+			result, _ = json.Marshal(string(result))
+		}
+		return JSONRPCResponse{JSONRPC: JSONRPCVersion, Result: result, ID: req.ID}, !notification
+	}
+	return JSONRPCResponse{JSONRPC: JSONRPCVersion, Error: jsonRPCServerError(rec), ID: req.ID}, !notification
+}
+
+// serveLimited handles the call with the concurrency limit and the timeout of the group of its route, as its listener
+// does. Returns the error message if the call is rejected or times out
+func serveLimited(route Route, rec *bufferedResponse, sub *http.Request, limits *groupLimits) string {
+	if !limits.acquire() {
+		return concurrencyMessage
+	}
+	defer limits.release()
+	ctx, cancel := context.WithTimeout(sub.Context(), limits.timeout)
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		route.HandlerFunc(rec, sub.WithContext(ctx), httprouter.Params{})
+	}()
+	select {
+	case <-done:
+		return ""
+	case <-ctx.Done():
+		// the response of the handler is dropped, as the timeout handler of the listener does
+		return timeoutMessage
+	}
+}
+
+// jsonRPCServerError returns the error of a failed call from the error response of its handler, with the codespace,
+// code and message of the sdk error the handler wrote
+func jsonRPCServerError(rec *bufferedResponse) *JSONRPCError {
+	res := &JSONRPCError{Code: JSONRPCServerError, Message: strings.TrimSpace(rec.body.String()), Data: &JSONRPCErrorData{HTTPStatus: rec.status}}
+	var e rpcError
+	if err := json.Unmarshal(rec.body.Bytes(), &e); err == nil && e.Message != "" {
+		res.Message = e.Message
+	}
+	if rec.err != nil {
+		res.Message = fmt.Sprintf("%v", rec.err)
+		res.Data.Codespace, res.Data.Code = rec.err.Codespace(), rec.err.Code()
+	}
+	return res
+}
+
+func jsonRPCErrorResponse(id json.RawMessage, code int, msg string) JSONRPCResponse {
+	return JSONRPCResponse{JSONRPC: JSONRPCVersion, Error: &JSONRPCError{Code: code, Message: msg}, ID: id}
+}
+
+func writeJSONRPC(w http.ResponseWriter, res interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// bufferedResponse keeps the response of a route handler called by a JSON-RPC method, and the sdk error of an error
+// response written with writeError
+type bufferedResponse struct {
+	header http.Header
+	body   *bytes.Buffer
+	status int
+	err    types.Error
+}
+
+func newBufferedResponse() *bufferedResponse {
+	return &bufferedResponse{header: make(http.Header), body: new(bytes.Buffer), status: http.StatusOK}
+}
+
+func (b *bufferedResponse) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponse) Write(bz []byte) (int, error) {
+	return b.body.Write(bz)
+}
+
+func (b *bufferedResponse) WriteHeader(status int) {
+	b.status = status
+}
@@ -0,0 +1,157 @@
+package rpc
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const OpenRPCVersion = "1.2.6"
+
+// OpenRPCDoc is the OpenRPC description of the JSON-RPC methods, generated from the route table
+type OpenRPCDoc struct {
+	OpenRPC string          `json:"openrpc"`
+	Info    OpenRPCInfo     `json:"info"`
+	Methods []OpenRPCMethod `json:"methods"`
+}
+
+type OpenRPCInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type OpenRPCMethod struct {
+	Name           string               `json:"name"`
+	Summary        string               `json:"summary"`
+	ParamStructure string               `json:"paramStructure"`
+	Params         []OpenRPCContent     `json:"params"`
+	Result         OpenRPCContent       `json:"result"`
+	HTTP           OpenRPCHTTPExtension `json:"x-http"`
+}
+
+type OpenRPCContent struct {
+	Name   string                 `json:"name"`
+	Schema map[string]interface{} `json:"schema"`
+}
+
+// OpenRPCHTTPExtension is the REST route of a method
+type OpenRPCHTTPExtension struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+}
+
+// OpenRPC returns the OpenRPC description of the methods, ordered by name. The params of a method are the fields of
+// the model of the body of its route
+func OpenRPC(methods map[string]Route) OpenRPCDoc {
+	doc := OpenRPCDoc{
+		OpenRPC: OpenRPCVersion,
+		Info:    OpenRPCInfo{Title: "Pocket Core JSON-RPC", Version: "v1"},
+		Methods: make([]OpenRPCMethod, 0, len(methods)),
+	}
+	for name, route := range methods {
+		m := OpenRPCMethod{
+			Name:           name,
+			Summary:        route.Name,
+			ParamStructure: "by-name",
+			Params:         []OpenRPCContent{},
+			Result:         OpenRPCContent{Name: "result", Schema: map[string]interface{}{}},
+			HTTP:           OpenRPCHTTPExtension{Method: route.Method, Path: route.Path},
+		}
+		if route.Params != nil {
+			for _, f := range jsonFields(reflect.TypeOf(route.Params)) {
+				m.Params = append(m.Params, OpenRPCContent{Name: f.name, Schema: jsonSchema(f.typ, map[reflect.Type]bool{})})
+			}
+		}
+		doc.Methods = append(doc.Methods, m)
+	}
+	sort.Slice(doc.Methods, func(i, j int) bool { return doc.Methods[i].Name < doc.Methods[j].Name })
+	return doc
+}
+
+// GenerateOpenRPC returns the indented OpenRPC description of the JSON-RPC methods of the routes
+func GenerateOpenRPC(routes Routes) ([]byte, error) {
+	bz, err := json.MarshalIndent(OpenRPC(JSONRPCMethods(routes)), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(bz, '\n'), nil
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// jsonSchema returns the JSON schema of the json encoding of the type. The types with their own json encoding are
+// described by their go type only
+func jsonSchema(t reflect.Type, seen map[reflect.Type]bool) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType) {
+		return map[string]interface{}{"description": t.String()}
+	}
+	if t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType) {
+		return map[string]interface{}{"type": "string", "description": t.String()}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "contentEncoding": "base64"}
+		}
+		return map[string]interface{}{"type": "array", "items": jsonSchema(t.Elem(), seen)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": jsonSchema(t.Elem(), seen)}
+	case reflect.Struct:
+		if seen[t] {
+			return map[string]interface{}{"description": t.String()}
+		}
+		seen[t] = true
+		defer delete(seen, t)
+		props := make(map[string]interface{})
+		for _, f := range jsonFields(t) {
+			props[f.name] = jsonSchema(f.typ, seen)
+		}
+		return map[string]interface{}{"type": "object", "properties": props}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+type jsonField struct {
+	name string
+	typ  reflect.Type
+}
+
+// jsonFields returns the fields of the json encoding of the struct, with the fields of its untagged embedded structs
+func jsonFields(t reflect.Type) []jsonField {
+	var res []jsonField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			res = append(res, jsonFields(f.Type)...)
+			continue
+		}
+		if f.PkgPath != "" || tag == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		res = append(res, jsonField{name: name, typ: f.Type})
+	}
+	return res
+}
@@ -69,6 +69,18 @@ type PaginatedHeightParams struct {
 	Sort    string `json:"order,omitempty"`
 }
 
+type LimitParams struct {
+	Limit int `json:"limit,omitempty"`
+}
+
+type PaginatedQueryParams struct {
+	Query   string `json:"query"`
+	Page    int    `json:"page,omitempty"`
+	PerPage int    `json:"per_page,omitempty"`
+	Prove   bool   `json:"prove,omitempty"`
+	Sort    string `json:"order,omitempty"`
+}
+
 type PaginatedHeightAndAddrParams struct {
 	Height  int64  `json:"height"`
 	Addr    string `json:"address"`
@@ -79,7 +91,7 @@ type PaginatedHeightAndAddrParams struct {
 func Block(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	var params = HeightParams{Height: 0}
 	if err := PopModel(w, r, ps, &params); err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	if params.Height == 0 {
@@ -87,7 +99,7 @@ func Block(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	}
 	res, err := app.PCA.QueryBlock(&params.Height)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	WriteJSONResponse(w, string(res), r.URL.Path, r.Host)
@@ -96,18 +108,18 @@ func Block(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 func Tx(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	var params = HashAndProveParams{}
 	if err := PopModel(w, r, ps, &params); err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	res, err := app.PCA.QueryTx(params.Hash, params.Prove)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	rpcResponse := ResultTxToRPC(res)
 	s, er := json.MarshalIndent(rpcResponse, "", "  ")
 	if er != nil {
-		WriteErrorResponse(w, 400, er.Error())
+		writeError(w, 400, er)
 		return
 	}
 	WriteJSONResponse(w, string(s), r.URL.Path, r.Host)
@@ -231,7 +243,7 @@ func ResultTxToRPC(res *core_types.ResultTx) *RPCResultTx {
 func AccountTxs(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	var params = PaginateAddrParams{}
 	if err := PopModel(w, r, ps, &params); err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	var res *core_types.ResultTxSearch
@@ -242,13 +254,13 @@ func AccountTxs(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 		res, err = app.PCA.QueryRecipientTxs(params.Address, params.Page, params.PerPage, params.Prove, params.Sort)
 	}
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	rpcResponse := ResultTxSearchToRPC(res)
 	s, er := json.MarshalIndent(rpcResponse, "", "  ")
 	if er != nil {
-		WriteErrorResponse(w, 400, er.Error())
+		writeError(w, 400, er)
 		return
 	}
 	WriteJSONResponse(w, string(s), r.URL.Path, r.Host)
@@ -257,7 +269,7 @@ func AccountTxs(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 func BlockTxs(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	var params = PaginatedHeightParams{}
 	if err := PopModel(w, r, ps, &params); err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	if params.Height == 0 {
@@ -265,12 +277,99 @@ func BlockTxs(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	}
 	res, err := app.PCA.QueryBlockTxs(params.Height, params.Page, params.PerPage, params.Prove, params.Sort)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 	}
 	rpcResponse := ResultTxSearchToRPC(res)
 	s, er := json.MarshalIndent(rpcResponse, "", "  ")
 	if er != nil {
-		WriteErrorResponse(w, 400, er.Error())
+		writeError(w, 400, er)
+		return
+	}
+	WriteJSONResponse(w, string(s), r.URL.Path, r.Host)
+}
+
+func TxSearch(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var params = PaginatedQueryParams{}
+	if err := PopModel(w, r, ps, &params); err != nil {
+		writeError(w, 400, err)
+		return
+	}
+	res, err := app.PCA.QueryTxSearch(params.Query, params.Page, params.PerPage, params.Prove, params.Sort)
+	if err != nil {
+		writeError(w, 400, err)
+		return
+	}
+	rpcResponse := ResultTxSearchToRPC(res)
+	s, er := json.MarshalIndent(rpcResponse, "", "  ")
+	if er != nil {
+		writeError(w, 400, er)
+		return
+	}
+	WriteJSONResponse(w, string(s), r.URL.Path, r.Host)
+}
+
+// Result of querying for the txs of the mempool
+type RPCResultUnconfirmedTxs struct {
+	Count      int                `json:"count"`       // the number of returned txs
+	Total      int                `json:"total"`       // the number of txs of the mempool
+	TotalBytes int64              `json:"total_bytes"` // the size of the txs of the mempool
+	Txs        []RPCUnconfirmedTx `json:"txs"`
+}
+
+type RPCUnconfirmedTx struct {
+	Hash  bytes.HexBytes `json:"hash"`
+	Tx    types.Tx       `json:"tx"`
+	StdTx RPCStdTx       `json:"stdTx,omitempty"`
+}
+
+// UnconfirmedTxs returns the txs of the mempool of the node, not yet included in a block
+func UnconfirmedTxs(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var params = LimitParams{}
+	if err := PopModel(w, r, ps, &params); err != nil {
+		writeError(w, 400, err)
+		return
+	}
+	res, err := app.PCA.QueryUnconfirmedTxs(params.Limit)
+	if err != nil {
+		writeError(w, 400, err)
+		return
+	}
+	rpcResponse := RPCResultUnconfirmedTxs{
+		Count:      res.Count,
+		Total:      res.Total,
+		TotalBytes: res.TotalBytes,
+		Txs:        make([]RPCUnconfirmedTx, 0, len(res.Txs)),
+	}
+	height := app.PCA.LastBlockHeight()
+	for _, tx := range res.Txs {
+		utx := RPCUnconfirmedTx{Hash: tx.Hash(), Tx: tx}
+		if stdTx, err := app.UnmarshalTx(tx, height); err == nil {
+			utx.StdTx = RPCStdTx(stdTx)
+		}
+		rpcResponse.Txs = append(rpcResponse.Txs, utx)
+	}
+	s, er := json.MarshalIndent(rpcResponse, "", "  ")
+	if er != nil {
+		writeError(w, 400, er)
+		return
+	}
+	WriteJSONResponse(w, string(s), r.URL.Path, r.Host)
+}
+
+func AccountHistory(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var params = PaginateAddrParams{}
+	if err := PopModel(w, r, ps, &params); err != nil {
+		writeError(w, 400, err)
+		return
+	}
+	res, err := app.PCA.QueryAccountHistory(params.Address, params.Page, params.PerPage, params.Sort)
+	if err != nil {
+		writeError(w, 400, err)
+		return
+	}
+	s, er := json.MarshalIndent(res, "", "  ")
+	if er != nil {
+		writeError(w, 400, er)
 		return
 	}
 	WriteJSONResponse(w, string(s), r.URL.Path, r.Host)
@@ -279,7 +378,7 @@ func BlockTxs(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 func AllBlockTxs(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	var params = PaginatedHeightParams{}
 	if err := PopModel(w, r, ps, &params); err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	if params.Height == 0 {
@@ -287,12 +386,12 @@ func AllBlockTxs(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	}
 	res, err := app.PCA.QueryAllBlockTxs(params.Height, params.Page, params.PerPage)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 	}
 	rpcResponse := ResultTxSearchToRPC(res)
 	s, er := json.MarshalIndent(rpcResponse, "", "  ")
 	if er != nil {
-		WriteErrorResponse(w, 400, er.Error())
+		writeError(w, 400, er)
 		return
 	}
 	WriteJSONResponse(w, string(s), r.URL.Path, r.Host)
@@ -305,12 +404,12 @@ type queryHeightResponse struct {
 func Height(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	res, err := app.PCA.QueryHeight()
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	height, err := json.Marshal(&queryHeightResponse{Height: res})
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	WriteJSONResponse(w, string(height), r.URL.Path, r.Host)
@@ -323,7 +422,7 @@ type queryBalanceResponse struct {
 func Balance(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	var params = HeightAndAddrParams{Height: 0}
 	if err := PopModel(w, r, ps, &params); err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	if params.Height == 0 {
@@ -331,12 +430,12 @@ func Balance(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	}
 	balance, err := app.PCA.QueryBalance(params.Address, params.Height)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	s, err := json.MarshalIndent(&queryBalanceResponse{Balance: balance.BigInt()}, "", "")
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	WriteJSONResponse(w, string(s), r.URL.Path, r.Host)
@@ -345,7 +444,7 @@ func Balance(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 func Account(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	var params = HeightAndAddrParams{Height: 0}
 	if err := PopModel(w, r, ps, &params); err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	if params.Height == 0 {
@@ -353,12 +452,12 @@ func Account(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	}
 	res, err := app.PCA.QueryAccount(params.Address, params.Height)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	s, err := json.Marshal(res)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	WriteJSONResponse(w, string(s), r.URL.Path, r.Host)
@@ -367,7 +466,7 @@ func Account(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 func Nodes(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	var params = HeightAndValidatorOptsParams{}
 	if err := PopModel(w, r, ps, &params); err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	if params.Height == 0 {
@@ -381,25 +480,25 @@ func Nodes(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	}
 	res, err := app.PCA.QueryNodes(params.Height, params.Opts)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	j, err := res.JSON()
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	_, err = w.Write(j)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 	}
 }
 
 func Node(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	var params = HeightAndAddrParams{Height: 0}
 	if err := PopModel(w, r, ps, &params); err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	if params.Height == 0 {
@@ -407,12 +506,12 @@ func Node(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	}
 	res, err := app.PCA.QueryNode(params.Address, params.Height)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	j, err := res.MarshalJSON()
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	WriteJSONResponse(w, string(j), r.URL.Path, r.Host)
@@ -421,7 +520,7 @@ func Node(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 func SigningInfo(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	var params = PaginatedHeightAndAddrParams{Height: 0}
 	if err := PopModel(w, r, ps, &params); err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	if params.Height == 0 {
@@ -429,12 +528,12 @@ func SigningInfo(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	}
 	res, err := app.PCA.QuerySigningInfos(params.Addr, params.Height, params.Page, params.PerPage)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	j, err := res.JSON()
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	WriteJSONResponse(w, string(j), r.URL.Path, r.Host)
@@ -443,7 +542,7 @@ func SigningInfo(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 func SecondUpgrade(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	var params = HeightParams{Height: 0}
 	if err := PopModel(w, r, ps, &params); err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	if params.Height == 0 {
@@ -458,38 +557,33 @@ func SecondUpgrade(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 }
 
 func Chains(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
-	value := r.URL.Query().Get("authtoken")
-	if value == app.AuthToken.Value {
-		res, err := app.PCA.QueryHostedChains()
-		if err != nil {
-			WriteErrorResponse(w, 400, err.Error())
-			return
-		}
-		j, err := app.Codec().MarshalJSON(res)
-		if err != nil {
-			WriteErrorResponse(w, 400, err.Error())
-			return
-		}
-		WriteJSONResponse(w, string(j), r.URL.Path, r.Host)
-	} else {
-		WriteErrorResponse(w, 401, "wrong authtoken "+value)
+	res, err := app.PCA.QueryHostedChains()
+	if err != nil {
+		writeError(w, 400, err)
+		return
+	}
+	j, err := app.Codec().MarshalJSON(res)
+	if err != nil {
+		writeError(w, 400, err)
+		return
 	}
+	WriteJSONResponse(w, string(j), r.URL.Path, r.Host)
 }
 
 func NodeParams(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	var params = HeightParams{Height: 0}
 	if err := PopModel(w, r, ps, &params); err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	res, err := app.PCA.QueryNodeParams(params.Height)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	j, err := app.Codec().MarshalJSON(res)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	WriteJSONResponse(w, string(j), r.URL.Path, r.Host)
@@ -503,12 +597,12 @@ func QueryValidatorsByChain(w http.ResponseWriter, r *http.Request, ps httproute
 		},
 	}
 	if err := PopModel(w, r, ps, &params); err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	res, err := app.PCA.QueryValidatorByChain(params.Height, params.Opts.Blockchain)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 
@@ -532,7 +626,7 @@ type QueryNodeReceiptParam struct {
 func NodeClaim(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	var params = QueryNodeReceiptParam{}
 	if err := PopModel(w, r, ps, &params); err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	if params.Height == 0 {
@@ -540,12 +634,12 @@ func NodeClaim(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	}
 	res, err := app.PCA.QueryClaim(params.Address, params.AppPubKey, params.Blockchain, params.ReceiptType, params.SBlockHeight, params.Height)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	j, err := app.Codec().MarshalJSON(res)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	WriteJSONResponse(w, string(j), r.URL.Path, r.Host)
@@ -554,7 +648,7 @@ func NodeClaim(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 func NodeClaims(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	var params = PaginatedHeightAndAddrParams{}
 	if err := PopModel(w, r, ps, &params); err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	if params.Height == 0 {
@@ -562,12 +656,12 @@ func NodeClaims(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	}
 	res, err := app.PCA.QueryClaims(params.Addr, params.Height, params.Page, params.PerPage)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	j, err := res.JSON()
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	WriteJSONResponse(w, string(j), r.URL.Path, r.Host)
@@ -576,7 +670,7 @@ func NodeClaims(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 func Apps(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	var params = HeightAndApplicaitonOptsParams{}
 	if err := PopModel(w, r, ps, &params); err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	if params.Height == 0 {
@@ -590,25 +684,25 @@ func Apps(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	}
 	res, err := app.PCA.QueryApps(params.Height, params.Opts)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	j, err := res.JSON()
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	_, err = w.Write(j)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 	}
 }
 
 func App(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	var params = HeightAndAddrParams{Height: 0}
 	if err := PopModel(w, r, ps, &params); err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	if params.Height == 0 {
@@ -616,12 +710,12 @@ func App(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	}
 	res, err := app.PCA.QueryApp(params.Address, params.Height)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	j, err := res.MarshalJSON()
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	WriteJSONResponse(w, string(j), r.URL.Path, r.Host)
@@ -630,7 +724,7 @@ func App(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 func AppParams(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	var params = HeightParams{Height: 0}
 	if err := PopModel(w, r, ps, &params); err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	if params.Height == 0 {
@@ -638,12 +732,12 @@ func AppParams(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	}
 	res, err := app.PCA.QueryAppParams(params.Height)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	j, err := app.Codec().MarshalJSON(res)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	WriteJSONResponse(w, string(j), r.URL.Path, r.Host)
@@ -652,7 +746,7 @@ func AppParams(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 func PocketParams(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	var params = HeightParams{Height: 0}
 	if err := PopModel(w, r, ps, &params); err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	if params.Height == 0 {
@@ -660,12 +754,12 @@ func PocketParams(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 	}
 	res, err := app.PCA.QueryPocketParams(params.Height)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	j, err := app.Codec().MarshalJSON(res)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	WriteJSONResponse(w, string(j), r.URL.Path, r.Host)
@@ -674,7 +768,7 @@ func PocketParams(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 func SupportedChains(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	var params = HeightParams{Height: 0}
 	if err := PopModel(w, r, ps, &params); err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	if params.Height == 0 {
@@ -682,12 +776,12 @@ func SupportedChains(w http.ResponseWriter, r *http.Request, ps httprouter.Param
 	}
 	res, err := app.PCA.QueryPocketSupportedBlockchains(params.Height)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	j, err := app.Codec().MarshalJSON(res)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	WriteResponse(w, string(j), r.URL.Path, r.Host)
@@ -705,7 +799,7 @@ type querySupplyResponse struct {
 func Supply(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	var params = HeightParams{Height: 0}
 	if err := PopModel(w, r, ps, &params); err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	if params.Height == 0 {
@@ -713,17 +807,17 @@ func Supply(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	}
 	nodesStake, total, err := app.PCA.QueryTotalNodeCoins(params.Height)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	appsStaked, err := app.PCA.QueryTotalAppCoins(params.Height)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	dao, err := app.PCA.QueryDaoBalance(params.Height)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	totalStaked := nodesStake.Add(appsStaked).Add(dao)
@@ -737,7 +831,7 @@ func Supply(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 		Total:         total.BigInt().String(),
 	}, "", "  ")
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	WriteJSONResponse(w, string(res), r.URL.Path, r.Host)
@@ -746,7 +840,7 @@ func Supply(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 func DAOOwner(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	var params = HeightParams{Height: 0}
 	if err := PopModel(w, r, ps, &params); err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	if params.Height == 0 {
@@ -754,12 +848,12 @@ func DAOOwner(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	}
 	res, err := app.PCA.QueryDaoOwner(0)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	s, err := json.Marshal(res)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	WriteResponse(w, string(s), r.URL.Path, r.Host)
@@ -768,7 +862,7 @@ func DAOOwner(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 func Upgrade(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	var params = HeightParams{Height: 0}
 	if err := PopModel(w, r, ps, &params); err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	if params.Height == 0 {
@@ -776,12 +870,12 @@ func Upgrade(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	}
 	res, err := app.PCA.QueryUpgrade(0)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	s, err := json.Marshal(res)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	WriteResponse(w, string(s), r.URL.Path, r.Host)
@@ -790,7 +884,7 @@ func Upgrade(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 func ACL(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	var params = HeightParams{Height: 0}
 	if err := PopModel(w, r, ps, &params); err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	if params.Height == 0 {
@@ -798,12 +892,12 @@ func ACL(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	}
 	res, err := app.PCA.QueryACL(params.Height)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	j, err := app.Codec().MarshalJSON(res)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	WriteResponse(w, string(j), r.URL.Path, r.Host)
@@ -812,7 +906,7 @@ func ACL(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 func AllParams(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	var params = HeightParams{Height: 0}
 	if err := PopModel(w, r, ps, &params); err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	if params.Height == 0 {
@@ -820,12 +914,12 @@ func AllParams(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	}
 	res, err := app.PCA.QueryAllParams(params.Height)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	j, err := app.Codec().MarshalJSON(res)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	WriteJSONResponse(w, string(j), r.URL.Path, r.Host)
@@ -833,7 +927,7 @@ func AllParams(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 func Param(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	var params = HeightAndKeyParams{Height: 0}
 	if err := PopModel(w, r, ps, &params); err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	if params.Height == 0 {
@@ -841,12 +935,12 @@ func Param(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	}
 	res, err := app.PCA.QueryParam(params.Height, params.Key)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	j, err := app.Codec().MarshalJSON(res)
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	WriteJSONResponse(w, string(j), r.URL.Path, r.Host)
@@ -855,7 +949,7 @@ func Param(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 func State(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	var params = HeightParams{Height: 0}
 	if err := PopModel(w, r, ps, &params); err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	if params.Height == 0 {
@@ -863,7 +957,7 @@ func State(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	}
 	res, err := app.PCA.ExportState(params.Height, "")
 	if err != nil {
-		WriteErrorResponse(w, 400, err.Error())
+		writeError(w, 400, err)
 		return
 	}
 	WriteRaw(w, res, r.URL.Path, r.Host)
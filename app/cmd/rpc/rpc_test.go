@@ -10,10 +10,14 @@ import (
 	"math/rand"
 	"net/http"
 	"net/http/httptest"
+	neturl "net/url"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/pokt-network/pocket-core/codec"
 
@@ -23,6 +27,7 @@ import (
 
 	types3 "github.com/pokt-network/pocket-core/x/apps/types"
 
+	"github.com/gorilla/websocket"
 	"github.com/julienschmidt/httprouter"
 	"github.com/pokt-network/pocket-core/types"
 	"github.com/pokt-network/pocket-core/x/auth"
@@ -33,6 +38,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	core_types "github.com/tendermint/tendermint/rpc/core/types"
 	tmTypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
 	"gopkg.in/h2non/gock.v1"
 )
 
@@ -175,6 +181,47 @@ func TestRPC_QueryAccountTXs(t *testing.T) {
 	stopCli()
 }
 
+func TestRPC_QueryAccountHistory(t *testing.T) {
+	codec.UpgradeHeight = 7000
+	app.GlobalConfig.PocketConfig.AccountHistory = true
+	defer func() { app.GlobalConfig.PocketConfig.AccountHistory = false }()
+	_, _, cleanup := NewInMemoryTendermintNode(t, oneValTwoNodeGenesisState())
+	app.PCA.SetAccountHistory(types.NewAccountHistory(dbm.NewMemDB()))
+	memCLI, _, evtChan := subscribeTo(t, tmTypes.EventNewBlock)
+	<-evtChan // Wait for block
+	_, stopCli, evtChan := subscribeTo(t, tmTypes.EventTx)
+	kb := getInMemoryKeybase()
+	cb, err := kb.GetCoinbase()
+	assert.Nil(t, err)
+	tx, err := nodes.Send(memCodec(), memCLI, kb, cb.GetAddress(), cb.GetAddress(), "test", types.NewInt(100), true)
+	assert.Nil(t, err)
+	assert.NotNil(t, tx)
+	<-evtChan // Wait for tx
+
+	var params = PaginateAddrParams{
+		Address: cb.GetAddress().String(),
+		Sort:    "asc",
+	}
+	q := newQueryRequest("accounthistory", newBody(params))
+	rec := httptest.NewRecorder()
+	AccountHistory(rec, q, httprouter.Params{})
+	resp := getJSONResponse(rec)
+	var res app.AccountHistoryResult
+	assert.Nil(t, json.Unmarshal([]byte(resp), &res))
+	// the fee and both sides of the send to self
+	assert.Equal(t, 3, res.TotalCount)
+	assert.Len(t, res.Changes, 3)
+	reasons := make(map[string]int)
+	for _, change := range res.Changes {
+		assert.Equal(t, tx.TxHash, change.TxHash)
+		reasons[change.Reason]++
+	}
+	assert.Equal(t, map[string]int{types.BalanceChangeFee: 1, types.BalanceChangeTx: 2}, reasons)
+
+	cleanup()
+	stopCli()
+}
+
 func TestRPC_QueryBlockTXs(t *testing.T) {
 	codec.UpgradeHeight = 7000
 	var tx *types.TxResponse
@@ -237,6 +284,67 @@ func TestRPC_QueryBlockTXs(t *testing.T) {
 	stopCli()
 }
 
+func TestRPC_Events(t *testing.T) {
+	codec.UpgradeHeight = 7000
+	_, _, cleanup := NewInMemoryTendermintNode(t, oneValTwoNodeGenesisState())
+	memCLI, _, evtChan := subscribeTo(t, tmTypes.EventNewBlock)
+	<-evtChan // Wait for block
+	_, stopCli, evtChan := subscribeTo(t, tmTypes.EventTx)
+	kb := getInMemoryKeybase()
+	cb, err := kb.GetCoinbase()
+	assert.Nil(t, err)
+	tx, err := nodes.Send(memCodec(), memCLI, kb, cb.GetAddress(), cb.GetAddress(), "test", types.NewInt(100), true)
+	assert.Nil(t, err)
+	<-evtChan // Wait for tx
+
+	app.GlobalConfig.PocketConfig.MaxEventSubscribers = 1
+	srv := httptest.NewServer(Router(Routes{Route{Name: "Events", Method: "GET", Path: EventsPath, HandlerFunc: Events}}))
+	defer srv.Close()
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + EventsPath
+	// invalid parameters are rejected before the upgrade
+	_, res, err := websocket.DefaultDialer.Dial(url+"?types=blocks", nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, 400, res.StatusCode)
+	// a height older than the max lookback
+	app.GlobalConfig.PocketConfig.MaxEventLookback = 1
+	_, res, err = websocket.DefaultDialer.Dial(url+"?from_height=1", nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, 400, res.StatusCode)
+	app.GlobalConfig.PocketConfig.MaxEventLookback = types.DefaultMaxEventLookback
+	// a browser origin that is not configured
+	_, res, err = websocket.DefaultDialer.Dial(url, http.Header{"Origin": []string{"https://example.com"}})
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	// resume from the first height, only the send tx and the block messages
+	conn, _, err := websocket.DefaultDialer.Dial(url+"?types=tx,block&from_height=1&query="+neturl.QueryEscape("tx.message_type='send'"), nil)
+	assert.Nil(t, err)
+	defer conn.Close()
+	// the max subscribers is reached
+	_, res, err = websocket.DefaultDialer.Dial(url, nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+	var txMsg *app.StreamMessage
+	lastBlock := int64(0)
+	for txMsg == nil || lastBlock < txMsg.Height {
+		var msg app.StreamMessage
+		assert.Nil(t, conn.ReadJSON(&msg))
+		switch msg.Type {
+		case app.StreamTypeTx:
+			txMsg = &msg
+		case app.StreamTypeBlock:
+			assert.Equal(t, lastBlock+1, msg.Height) // every height is sent in order
+			lastBlock = msg.Height
+		default:
+			t.Fatalf("unexpected stream message type %s", msg.Type)
+		}
+	}
+	assert.Equal(t, tx.TxHash, txMsg.Tx.Hash)
+	assert.Equal(t, cb.GetAddress().String(), txMsg.Tx.Signer)
+
+	cleanup()
+	stopCli()
+}
+
 func TestRPC_QueryBalance(t *testing.T) {
 	codec.UpgradeHeight = 7000
 	_, _, cleanup := NewInMemoryTendermintNode(t, oneValTwoNodeGenesisState())
@@ -974,6 +1082,102 @@ func TestRPC_Relay(t *testing.T) {
 	stopCli()
 }
 
+func TestRPC_RelayConcurrent(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+	codec.UpgradeHeight = 7000
+
+	kb := getInMemoryKeybase()
+	genBZ, _, validators, application := fiveValidatorsOneAppGenesis()
+	_, _, cleanup := NewInMemoryTendermintNode(t, genBZ)
+	// setup relay endpoint
+	expectedRequest := `"jsonrpc":"2.0","method":"web3_sha3","params":["0x68656c6c6f20776f726c64"],"id":64`
+	expectedResponse := "0x47173285a8d7341e5e972fc677286384f802f8ef42a5ec5f03bbfa254cb01fad"
+	gock.New(dummyChainsURL).
+		Post("").
+		Persist().
+		BodyString(expectedRequest).
+		Reply(200).
+		BodyString(expectedResponse)
+	defer gock.Off()
+	appPrivateKey, err := kb.ExportPrivateKeyObject(application.Address, "test")
+	assert.Nil(t, err)
+	// setup AAT
+	aat := pocketTypes.AAT{
+		Version:              "0.0.1",
+		ApplicationPublicKey: appPrivateKey.PublicKey().RawString(),
+		ClientPublicKey:      appPrivateKey.PublicKey().RawString(),
+		ApplicationSignature: "",
+	}
+	sig, err := appPrivateKey.Sign(aat.Hash())
+	assert.Nil(t, err)
+	aat.ApplicationSignature = hex.EncodeToString(sig)
+	// setup the relays: every relay is sent several times concurrently
+	const distinct, copies = 20, 5
+	relays := make([]pocketTypes.Relay, distinct)
+	for i := range relays {
+		relay := pocketTypes.Relay{
+			Payload: pocketTypes.Payload{Data: expectedRequest, Method: "POST"},
+			Meta:    pocketTypes.RelayMeta{BlockHeight: 5},
+			Proof: pocketTypes.RelayProof{
+				Entropy:            int64(7000000 + i),
+				SessionBlockHeight: 1,
+				ServicerPubKey:     validators[0].PublicKey.RawString(),
+				Blockchain:         dummyChainsHash,
+				Token:              aat,
+			},
+		}
+		relay.Proof.RequestHash = relay.RequestHashString()
+		sig, err := appPrivateKey.Sign(relay.Proof.Hash())
+		assert.Nil(t, err)
+		relay.Proof.Signature = hex.EncodeToString(sig)
+		relays[i] = relay
+	}
+	header := relays[0].Proof.SessionHeader()
+	_, stopCli, evtChan := subscribeTo(t, tmTypes.EventNewBlock)
+	<-evtChan // Wait for block
+	// the relays the app may get from a servicer in the session
+	stakedApp, err := app.PCA.QueryApp(application.Address.String(), 1)
+	assert.Nil(t, err)
+	params, err := app.PCA.QueryPocketParams(1)
+	assert.Nil(t, err)
+	maxRelays := pocketTypes.MaxPossibleRelays(stakedApp, params.SessionNodeCount).Int64()
+	before := int64(0)
+	if evidence, err := pocketTypes.GetEvidence(header, pocketTypes.RelayEvidence, types.ZeroInt()); err == nil {
+		before = evidence.NumOfProofs
+	}
+	var served int64
+	var wg sync.WaitGroup
+	for c := 0; c < copies; c++ {
+		for _, relay := range relays {
+			wg.Add(1)
+			go func(relay pocketTypes.Relay) {
+				defer wg.Done()
+				q := newClientRequest("relay", newBody(relay))
+				rec := httptest.NewRecorder()
+				Relay(rec, q, httprouter.Params{})
+				if rec.Code == 200 {
+					atomic.AddInt64(&served, 1)
+				}
+			}(relay)
+		}
+	}
+	wg.Wait()
+	// every relay is served at most once, never over the max, and every served proof is stored exactly once
+	expected := int64(distinct)
+	if maxRelays-before < expected {
+		expected = maxRelays - before
+	}
+	assert.Equal(t, expected, served)
+	evidence, err := pocketTypes.GetEvidence(header, pocketTypes.RelayEvidence, types.ZeroInt())
+	assert.Nil(t, err)
+	assert.Equal(t, before+served, evidence.NumOfProofs)
+
+	cleanup()
+	stopCli()
+}
+
 func TestRPC_Dispatch(t *testing.T) {
 	codec.UpgradeHeight = 7000
 	kb := getInMemoryKeybase()
@@ -1019,6 +1223,46 @@ func TestRPC_Dispatch(t *testing.T) {
 
 }
 
+func TestRPC_BatchDispatch(t *testing.T) {
+	codec.UpgradeHeight = 7000
+	kb := getInMemoryKeybase()
+	genBZ, _, validators, app := fiveValidatorsOneAppGenesis()
+	_, _, cleanup := NewInMemoryTendermintNode(t, genBZ)
+	appPrivateKey, err := kb.ExportPrivateKeyObject(app.Address, "test")
+	assert.Nil(t, err)
+	params := BatchDispatchParams{
+		ApplicationPubKey: appPrivateKey.PublicKey().RawString(),
+		Chains:            []string{dummyChainsHash},
+	}
+	_, stopCli, evtChan := subscribeTo(t, tmTypes.EventNewBlock)
+	<-evtChan // Wait for block
+	q := newClientRequest("batchdispatch", newBody(params))
+	rec := httptest.NewRecorder()
+	BatchDispatch(rec, q, httprouter.Params{})
+	assert.Equal(t, 200, rec.Code)
+	resp := getJSONResponse(rec)
+	var res struct {
+		Sessions []struct {
+			Header pocketTypes.SessionHeader `json:"header"`
+		} `json:"sessions"`
+	}
+	assert.Nil(t, json.Unmarshal(resp, &res))
+	assert.Len(t, res.Sessions, 1)
+	assert.Equal(t, dummyChainsHash, res.Sessions[0].Header.Chain)
+	rawResp := string(resp)
+	for _, validator := range validators {
+		assert.Regexp(t, validator.Address.String(), rawResp)
+	}
+	// a session height in the future
+	params.SessionHeight = 1000
+	q = newClientRequest("batchdispatch", newBody(params))
+	rec = httptest.NewRecorder()
+	BatchDispatch(rec, q, httprouter.Params{})
+	assert.Equal(t, 400, rec.Code)
+	cleanup()
+	stopCli()
+}
+
 func TestRPC_RawTX(t *testing.T) {
 	codec.UpgradeHeight = 7000
 	_, kb, cleanup := NewInMemoryTendermintNode(t, oneValTwoNodeGenesisState())
@@ -1088,6 +1332,158 @@ func TestRPC_RawTX(t *testing.T) {
 	stopCli()
 }
 
+func TestRPC_SimulateTx(t *testing.T) {
+	codec.UpgradeHeight = 7000
+	_, kb, cleanup := NewInMemoryTendermintNode(t, oneValTwoNodeGenesisState())
+	cb, err := kb.GetCoinbase()
+	assert.Nil(t, err)
+	kp, err := kb.Create("test")
+	assert.Nil(t, err)
+	pk, err := kb.ExportPrivateKeyObject(cb.GetAddress(), "test")
+	assert.Nil(t, err)
+	_, stopCli, evtChan := subscribeTo(t, tmTypes.EventNewBlock)
+	newTx := func(amount int64) string {
+		txBz, err := auth.DefaultTxEncoder(memCodec())(authTypes.NewTestTx(types.Context{}.WithChainID("pocket-test"),
+			&types2.MsgSend{
+				FromAddress: cb.GetAddress(),
+				ToAddress:   kp.GetAddress(),
+				Amount:      types.NewInt(amount),
+			},
+			pk,
+			rand2.Int64(),
+			types.NewCoins(types.NewCoin(types.DefaultStakeDenom, types.NewInt(100000)))), 0)
+		assert.Nil(t, err)
+		return hex.EncodeToString(txBz)
+	}
+	<-evtChan // Wait for block
+	params := SendRawTxParams{Addr: cb.GetAddress().String(), RawHexBytes: newTx(10)}
+	q := newClientRequest("simulate", newBody(params))
+	rec := httptest.NewRecorder()
+	SimulateTx(rec, q, httprouter.Params{})
+	assert.Equal(t, 200, rec.Code)
+	// the std tx is only marshalled by the rpc
+	type simulateTxResponse struct {
+		SimulateTxResponse
+		Tx json.RawMessage `json:"tx"`
+	}
+	var res simulateTxResponse
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.NotEmpty(t, res.Tx)
+	assert.True(t, res.Success)
+	assert.Equal(t, "100000upokt", res.Fee.String())
+	assert.True(t, res.MinFee.IsPositive())
+	assert.NotEmpty(t, res.Events)
+	// the sender pays the amount and the fee, the recipient gets the amount and the fee collector the fee
+	deltas := make(map[string]int64)
+	for _, c := range res.BalanceChanges {
+		deltas[c.Address.String()] = c.Delta.Int64()
+		assert.True(t, c.After.Sub(c.Before).Equal(c.Delta))
+	}
+	assert.Equal(t, int64(-100010), deltas[cb.GetAddress().String()])
+	assert.Equal(t, int64(10), deltas[kp.GetAddress().String()])
+	assert.Equal(t, int64(100000), deltas[authTypes.NewModuleAddress(authTypes.FeeCollectorName).String()])
+	assert.Len(t, res.BalanceChanges, 3)
+	// nothing was broadcast or written
+	balance, err := app.PCA.QueryBalance(kp.GetAddress().String(), 0)
+	assert.Nil(t, err)
+	assert.True(t, balance.IsZero())
+
+	// a send of more than the balance fails with the error of the handler
+	params = SendRawTxParams{Addr: cb.GetAddress().String(), RawHexBytes: newTx(1000000000000000)}
+	q = newClientRequest("simulate", newBody(params))
+	rec = httptest.NewRecorder()
+	SimulateTx(rec, q, httprouter.Params{})
+	assert.Equal(t, 200, rec.Code)
+	res = simulateTxResponse{}
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.False(t, res.Success)
+	assert.NotZero(t, res.Code)
+	assert.NotEmpty(t, res.Log)
+
+	cleanup()
+	stopCli()
+}
+
+func TestRPC_BroadcastModes(t *testing.T) {
+	codec.UpgradeHeight = 7000
+	_, kb, cleanup := NewInMemoryTendermintNode(t, oneValTwoNodeGenesisState())
+	cb, err := kb.GetCoinbase()
+	assert.Nil(t, err)
+	kp, err := kb.Create("test")
+	assert.Nil(t, err)
+	pk, err := kb.ExportPrivateKeyObject(cb.GetAddress(), "test")
+	assert.Nil(t, err)
+	_, stopCli, evtChan := subscribeTo(t, tmTypes.EventNewBlock)
+	newTx := func() string {
+		txBz, err := auth.DefaultTxEncoder(memCodec())(authTypes.NewTestTx(types.Context{}.WithChainID("pocket-test"),
+			&types2.MsgSend{
+				FromAddress: cb.GetAddress(),
+				ToAddress:   kp.GetAddress(),
+				Amount:      types.NewInt(1),
+			},
+			pk,
+			rand2.Int64(),
+			types.NewCoins(types.NewCoin(types.DefaultStakeDenom, types.NewInt(100000)))), 0)
+		assert.Nil(t, err)
+		return hex.EncodeToString(txBz)
+	}
+	<-evtChan // Wait for block
+	// commit returns once the tx is in a block, with the result of its delivery
+	params := SendRawTxParams{Addr: cb.GetAddress().String(), RawHexBytes: newTx(), BroadcastMode: "commit"}
+	q := newClientRequest("rawtx", newBody(params))
+	rec := httptest.NewRecorder()
+	SendRawTx(rec, q, httprouter.Params{})
+	assert.Equal(t, 200, rec.Code)
+	var response types.TxResponse
+	assert.Nil(t, memCodec().UnmarshalJSON(getJSONResponse(rec), &response))
+	assert.Zero(t, response.Code)
+	assert.NotZero(t, response.Height)
+	assert.NotEmpty(t, response.TxHash)
+
+	// async returns the hash right away
+	params = SendRawTxParams{Addr: cb.GetAddress().String(), RawHexBytes: newTx(), BroadcastMode: "async"}
+	q = newClientRequest("rawtx", newBody(params))
+	rec = httptest.NewRecorder()
+	SendRawTx(rec, q, httprouter.Params{})
+	assert.Equal(t, 200, rec.Code)
+	response = types.TxResponse{}
+	assert.Nil(t, memCodec().UnmarshalJSON(getJSONResponse(rec), &response))
+	assert.NotEmpty(t, response.TxHash)
+	assert.Zero(t, response.Height)
+
+	params = SendRawTxParams{Addr: cb.GetAddress().String(), RawHexBytes: newTx(), BroadcastMode: "unknown"}
+	q = newClientRequest("rawtx", newBody(params))
+	rec = httptest.NewRecorder()
+	SendRawTx(rec, q, httprouter.Params{})
+	assert.Equal(t, 400, rec.Code)
+
+	cleanup()
+	stopCli()
+}
+
+func TestRPC_UnconfirmedTxs(t *testing.T) {
+	codec.UpgradeHeight = 7000
+	_, _, cleanup := NewInMemoryTendermintNode(t, oneValTwoNodeGenesisState())
+	_, stopCli, evtChan := subscribeTo(t, tmTypes.EventNewBlock)
+	<-evtChan // Wait for block
+	q := newQueryRequest("unconfirmedtxs", newBody(LimitParams{Limit: 10}))
+	rec := httptest.NewRecorder()
+	UnconfirmedTxs(rec, q, httprouter.Params{})
+	assert.Equal(t, 200, rec.Code)
+	var res struct {
+		Count      int               `json:"count"`
+		Total      int               `json:"total"`
+		TotalBytes int64             `json:"total_bytes"`
+		Txs        []json.RawMessage `json:"txs"`
+	}
+	assert.Nil(t, json.Unmarshal(getJSONResponse(rec), &res))
+	assert.Equal(t, len(res.Txs), res.Count)
+	assert.True(t, res.Count <= res.Total)
+
+	cleanup()
+	stopCli()
+}
+
 func TestRPC_QueryNodeClaims(t *testing.T) {
 	codec.UpgradeHeight = 7000
 	_, _, cleanup := NewInMemoryTendermintNode(t, oneValTwoNodeGenesisState())
@@ -1246,6 +1642,204 @@ func newCORSRequest(query string, body io.Reader) *http.Request {
 	return req
 }
 
+func TestRPC_Authorized(t *testing.T) {
+	datadir := t.TempDir()
+	assert.Nil(t, os.MkdirAll(datadir+FS+types.ConfigDirName, 0700))
+	prev := app.GlobalConfig.PocketConfig
+	app.GlobalConfig.PocketConfig.DataDir = datadir
+	defer func() { app.GlobalConfig.PocketConfig = prev }()
+	token, err := app.GetAuthTokens().Add("reader", []string{app.ScopeReadChains})
+	assert.Nil(t, err)
+	handler := func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		WriteResponse(w, "ok", r.URL.Path, r.Host)
+	}
+	call := func(scope string, set func(r *http.Request)) int {
+		req := httptest.NewRequest("POST", "/v1/private/chains", nil)
+		set(req)
+		rec := httptest.NewRecorder()
+		Authorized(scope, handler)(rec, req, httprouter.Params{})
+		return rec.Code
+	}
+	bearer := func(value string) func(r *http.Request) {
+		return func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+value) }
+	}
+	assert.Equal(t, 200, call(app.ScopeReadChains, bearer(token)))
+	assert.Equal(t, 403, call(app.ScopeStop, bearer(token)))
+	assert.Equal(t, 401, call(app.ScopeReadChains, bearer("wrong")))
+	assert.Equal(t, 401, call(app.ScopeReadChains, func(r *http.Request) {}))
+	// the deprecated query parameter
+	assert.Equal(t, 200, call(app.ScopeReadChains, func(r *http.Request) { r.URL.RawQuery = "authtoken=" + token }))
+	// every call is audited
+	bz, err := ioutil.ReadFile(datadir + FS + types.ConfigDirName + FS + types.AuditLogFileName)
+	assert.Nil(t, err)
+	lines := strings.Split(strings.TrimSpace(string(bz)), "\n")
+	assert.Len(t, lines, 5)
+	var entry app.AuditEntry
+	assert.Nil(t, json.Unmarshal([]byte(lines[len(lines)-1]), &entry))
+	assert.Equal(t, "reader", entry.Token)
+	// authorized calls are audited before they run, without a status
+	assert.Zero(t, entry.Status)
+	assert.Nil(t, json.Unmarshal([]byte(lines[1]), &entry))
+	assert.Equal(t, 403, entry.Status)
+}
+
+func TestRPC_Groups(t *testing.T) {
+	assert.Equal(t, types.RPCGroupRelay, RouteGroup("/v1/client/relay"))
+	assert.Equal(t, types.RPCGroupQuery, RouteGroup("/v1/query/height"))
+	assert.Equal(t, types.RPCGroupPrivate, RouteGroup("/v1/private/stop"))
+	assert.Equal(t, types.RPCGroupDebug, RouteGroup("/debug/memstats"))
+	prev := app.GlobalConfig.PocketConfig
+	defer func() { app.GlobalConfig.PocketConfig = prev }()
+	app.GlobalConfig.PocketConfig.RPCGroups = types.DefaultRPCGroups()
+	// by default the admin routes are on their own localhost listener
+	listeners := groupListeners("8081")
+	assert.Equal(t, []listener{
+		{addr: ":8081", groups: []string{types.RPCGroupRelay, types.RPCGroupQuery}},
+		{addr: types.DefaultRPCAdminListenAddr, groups: []string{types.RPCGroupPrivate, types.RPCGroupDebug}},
+	}, listeners)
+	// a listener only serves the routes of its groups
+	started, release := make(chan struct{}), make(chan struct{})
+	slow := func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		close(started)
+		<-release
+		WriteResponse(w, "ok", r.URL.Path, r.Host)
+	}
+	ok := func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		WriteResponse(w, "ok", r.URL.Path, r.Host)
+	}
+	app.GlobalConfig.PocketConfig.RPCGroups[types.RPCGroupQuery] = types.RPCGroupConfig{MaxConcurrent: 1, CORSOrigins: []string{"https://explorer"}}
+	routes := map[string]Routes{
+		types.RPCGroupQuery:   {Route{Name: "QueryHeight", Method: "POST", Path: "/v1/query/height", HandlerFunc: slow}, Route{Name: "QueryApp", Method: "POST", Path: "/v1/query/app", HandlerFunc: ok}},
+		types.RPCGroupPrivate: {Route{Name: "Stop", Method: "POST", Path: "/v1/private/stop", HandlerFunc: ok}},
+	}
+	h := listenerHandler(listeners[0].groups, routes, newLimits(10000))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("POST", "/v1/private/stop", nil))
+	assert.Equal(t, 404, rec.Code)
+	// the concurrency limit of the group
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("POST", "/v1/query/height", nil))
+		done <- rec
+	}()
+	<-started
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("POST", "/v1/query/height", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	close(release)
+	assert.Equal(t, 200, (<-done).Code)
+	// the cors policy of the group
+	req := httptest.NewRequest("POST", "/v1/query/app", nil)
+	req.Header.Set("Origin", "https://explorer")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, "https://explorer", rec.Header().Get(acaoHeaderKey))
+	req.Header.Set("Origin", "https://other")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Empty(t, rec.Header().Get(acaoHeaderKey))
+}
+
+func TestRPC_JSONRPC(t *testing.T) {
+	routes := Routes{
+		Route{Name: "QueryHeight", Method: "POST", Path: "/v1/query/height", HandlerFunc: func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+			WriteJSONResponse(w, `{"height":10}`, r.URL.Path, r.Host)
+		}},
+		Route{Name: "QueryBlock", Method: "POST", Path: "/v1/query/block", Params: HeightParams{}, HandlerFunc: func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+			var params HeightParams
+			if err := PopModel(w, r, ps, &params); err != nil {
+				WriteErrorResponse(w, 400, err.Error())
+				return
+			}
+			writeError(w, 400, types.ErrInternal("no block at height "+strconv.Itoa(int(params.Height))))
+		}},
+		Route{Name: "Dispatch", Method: "POST", Path: "/v1/client/dispatch", HandlerFunc: func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+			WriteJSONResponse(w, `{"session":1}`, r.URL.Path, r.Host)
+		}},
+		Route{Name: "Slow", Method: "POST", Path: "/v1/client/slow", HandlerFunc: func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+			<-r.Context().Done()
+		}},
+		Route{Name: "Stop", Method: "POST", Path: "/v1/private/stop", HandlerFunc: Authorized(app.ScopeStop, Stop)},
+		Route{Name: "MemStats", Method: "GET", Path: "/debug/memstats", HandlerFunc: MemStats},
+	}
+	// the client methods have the limits of the relay group
+	relayLimits := &groupLimits{timeout: 100 * time.Millisecond, slots: make(chan struct{}, 1)}
+	limits := map[string]*groupLimits{types.RPCGroupRelay: relayLimits}
+	call := func(body string) (int, string) {
+		rec := httptest.NewRecorder()
+		JSONRPC(routes, limits)(rec, httptest.NewRequest("POST", JSONRPCPath, strings.NewReader(body)), httprouter.Params{})
+		return rec.Code, rec.Body.String()
+	}
+	code, body := call(`{"jsonrpc":"2.0","method":"query_height","id":1}`)
+	assert.Equal(t, 200, code)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","result":{"height":10},"id":1}`, body)
+	// the sdk errors keep their codespace and code
+	_, body = call(`{"jsonrpc":"2.0","method":"query_block","params":{"height":5},"id":"a"}`)
+	var resp JSONRPCResponse
+	assert.Nil(t, json.Unmarshal([]byte(body), &resp))
+	assert.Equal(t, JSONRPCServerError, resp.Error.Code)
+	assert.Equal(t, "no block at height 5", resp.Error.Message)
+	assert.Equal(t, types.CodespaceRoot, resp.Error.Data.Codespace)
+	assert.Equal(t, types.CodeInternal, resp.Error.Data.Code)
+	assert.Equal(t, 400, resp.Error.Data.HTTPStatus)
+	// the client methods
+	_, body = call(`{"jsonrpc":"2.0","method":"client_dispatch","params":{},"id":1}`)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","result":{"session":1},"id":1}`, body)
+	assert.True(t, relayLimits.acquire())
+	_, body = call(`{"jsonrpc":"2.0","method":"client_dispatch","params":{},"id":1}`)
+	resp = JSONRPCResponse{}
+	assert.Nil(t, json.Unmarshal([]byte(body), &resp))
+	assert.Equal(t, concurrencyMessage, resp.Error.Message)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.Error.Data.HTTPStatus)
+	relayLimits.release()
+	_, body = call(`{"jsonrpc":"2.0","method":"client_slow","params":{},"id":1}`)
+	resp = JSONRPCResponse{}
+	assert.Nil(t, json.Unmarshal([]byte(body), &resp))
+	assert.Equal(t, timeoutMessage, resp.Error.Message)
+	// batch, with the standard errors; the private and debug routes are not methods and notifications have no response
+	_, body = call(`[{"jsonrpc":"2.0","method":"query_height","id":1},{"jsonrpc":"2.0","method":"private_stop","id":2},` +
+		`{"jsonrpc":"2.0","method":"query_block","params":[1],"id":3},{"jsonrpc":"1.0","method":"query_height","id":4},` +
+		`{"jsonrpc":"2.0","method":"debug_memstats","id":5},{"jsonrpc":"2.0","method":"query_height"}]`)
+	var batch []JSONRPCResponse
+	assert.Nil(t, json.Unmarshal([]byte(body), &batch))
+	assert.Len(t, batch, 5)
+	assert.Nil(t, batch[0].Error)
+	assert.Equal(t, JSONRPCMethodNotFound, batch[1].Error.Code)
+	assert.Equal(t, JSONRPCInvalidParams, batch[2].Error.Code)
+	assert.Equal(t, JSONRPCInvalidRequest, batch[3].Error.Code)
+	assert.Equal(t, JSONRPCMethodNotFound, batch[4].Error.Code)
+	// a batch has the body limit of a single request
+	_, body = call(`[{"jsonrpc":"2.0","method":"query_height","params":{"pad":"` + strings.Repeat("a", maxRequestBody) + `"},"id":1}]`)
+	assert.Contains(t, body, strconv.Itoa(JSONRPCParseError))
+	_, body = call(`[]`)
+	assert.Contains(t, body, strconv.Itoa(JSONRPCInvalidRequest))
+	_, body = call(`{"jsonrpc":`)
+	assert.Contains(t, body, strconv.Itoa(JSONRPCParseError))
+	code, _ = call(`{"jsonrpc":"2.0","method":"query_height"}`)
+	assert.Equal(t, http.StatusNoContent, code)
+	// discovery
+	_, body = call(`{"jsonrpc":"2.0","method":"rpc.discover","id":1}`)
+	assert.Nil(t, json.Unmarshal([]byte(body), &resp))
+	var doc OpenRPCDoc
+	assert.Nil(t, json.Unmarshal(resp.Result, &doc))
+	assert.Len(t, doc.Methods, 4)
+	for _, m := range doc.Methods {
+		if m.Name == "query_block" {
+			assert.Equal(t, "height", m.Params[0].Name)
+		}
+	}
+}
+
+func TestRPC_OpenRPCDoc(t *testing.T) {
+	// doc/specs/openrpc.json is generated from the route table with `pocket util gen-openrpc`
+	expected, err := GenerateOpenRPC(GetRoutes())
+	assert.Nil(t, err)
+	actual, err := ioutil.ReadFile("../../../doc/specs/openrpc.json")
+	assert.Nil(t, err)
+	assert.Equal(t, string(expected), string(actual), "doc/specs/openrpc.json is out of date, run `pocket util gen-openrpc --out doc/specs/openrpc.json`")
+}
+
 func newClientRequest(query string, body io.Reader) *http.Request {
 	req, err := http.NewRequest("POST", "localhost:8081/v1/client/"+query, body)
 	if err != nil {
@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -14,6 +15,9 @@ import (
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/pokt-network/pocket-core/app"
+	"github.com/pokt-network/pocket-core/types"
+	pocketTypes "github.com/pokt-network/pocket-core/x/pocketcore/types"
+	tmlog "github.com/tendermint/tendermint/libs/log"
 )
 
 var APIVersion = app.AppVersion
@@ -21,43 +25,91 @@ var APIVersion = app.AppVersion
 func StartRPC(port string, timeout int64, simulation, debug, allBlockTxs, hotReloadChains bool) {
 	routes := GetRoutes()
 	if simulation {
-		simRoute := Route{Name: "SimulateRequest", Method: "POST", Path: "/v1/client/sim", HandlerFunc: SimRequest}
+		simRoute := Route{Name: "SimulateRequest", Method: "POST", Path: "/v1/client/sim", HandlerFunc: SimRequest, Params: simRelayParams{}}
 		routes = append(routes, simRoute)
 	}
 
 	if debug {
-		routes = append(routes, Route{Name: "DebugBlock", Method: "GET", Path: "/debug/pprof/block", HandlerFunc: wrapperHandler(pprof.Handler(("block")))})
-		routes = append(routes, Route{Name: "DebugCmd", Method: "GET", Path: "/debug/pprof/cmdline", HandlerFunc: wrapperHandlerFunc(pprof.Cmdline)})
-		routes = append(routes, Route{Name: "DebugGoroutine", Method: "GET", Path: "/debug/pprof/goroutine", HandlerFunc: wrapperHandler(pprof.Handler(("goroutine")))})
-		routes = append(routes, Route{Name: "DebugHeap", Method: "GET", Path: "/debug/pprof/heap", HandlerFunc: wrapperHandler(pprof.Handler(("heap")))})
-		routes = append(routes, Route{Name: "DebugIndex", Method: "GET", Path: "/debug/pprof", HandlerFunc: wrapperHandlerFunc(pprof.Index)})
-		routes = append(routes, Route{Name: "DebugProfile", Method: "GET", Path: "/debug/pprof/profile", HandlerFunc: wrapperHandlerFunc(pprof.Profile)})
-		routes = append(routes, Route{Name: "DebugSymbol", Method: "GET", Path: "/debug/pprof/symbol", HandlerFunc: wrapperHandlerFunc(pprof.Symbol)})
-		routes = append(routes, Route{Name: "DebugThreadCreate", Method: "GET", Path: "/debug/pprof/threadcreate", HandlerFunc: wrapperHandler(pprof.Handler(("threadcreate")))})
-		routes = append(routes, Route{Name: "DebugTrace", Method: "GET", Path: "/debug/pprof/trace", HandlerFunc: wrapperHandlerFunc(pprof.Trace)})
-		routes = append(routes, Route{Name: "FreeOsMemory", Method: "GET", Path: "/debug/freememory", HandlerFunc: FreeMemory})
-		routes = append(routes, Route{Name: "MemStats", Method: "GET", Path: "/debug/memstats", HandlerFunc: MemStats})
-		routes = append(routes, Route{Name: "QuerySecondUpgrade", Method: "POST", Path: "/debug/second", HandlerFunc: SecondUpgrade})
-		routes = append(routes, Route{Name: "QueryValidatorByChain", Method: "POST", Path: "/debug/vbc", HandlerFunc: QueryValidatorsByChain})
+		var debugRoutes Routes
+		debugRoutes = append(debugRoutes, Route{Name: "DebugBlock", Method: "GET", Path: "/debug/pprof/block", HandlerFunc: wrapperHandler(pprof.Handler(("block")))})
+		debugRoutes = append(debugRoutes, Route{Name: "DebugCmd", Method: "GET", Path: "/debug/pprof/cmdline", HandlerFunc: wrapperHandlerFunc(pprof.Cmdline)})
+		debugRoutes = append(debugRoutes, Route{Name: "DebugGoroutine", Method: "GET", Path: "/debug/pprof/goroutine", HandlerFunc: wrapperHandler(pprof.Handler(("goroutine")))})
+		debugRoutes = append(debugRoutes, Route{Name: "DebugHeap", Method: "GET", Path: "/debug/pprof/heap", HandlerFunc: wrapperHandler(pprof.Handler(("heap")))})
+		debugRoutes = append(debugRoutes, Route{Name: "DebugIndex", Method: "GET", Path: "/debug/pprof", HandlerFunc: wrapperHandlerFunc(pprof.Index)})
+		debugRoutes = append(debugRoutes, Route{Name: "DebugProfile", Method: "GET", Path: "/debug/pprof/profile", HandlerFunc: wrapperHandlerFunc(pprof.Profile)})
+		debugRoutes = append(debugRoutes, Route{Name: "DebugSymbol", Method: "GET", Path: "/debug/pprof/symbol", HandlerFunc: wrapperHandlerFunc(pprof.Symbol)})
+		debugRoutes = append(debugRoutes, Route{Name: "DebugThreadCreate", Method: "GET", Path: "/debug/pprof/threadcreate", HandlerFunc: wrapperHandler(pprof.Handler(("threadcreate")))})
+		debugRoutes = append(debugRoutes, Route{Name: "DebugTrace", Method: "GET", Path: "/debug/pprof/trace", HandlerFunc: wrapperHandlerFunc(pprof.Trace)})
+		debugRoutes = append(debugRoutes, Route{Name: "FreeOsMemory", Method: "GET", Path: "/debug/freememory", HandlerFunc: FreeMemory})
+		debugRoutes = append(debugRoutes, Route{Name: "MemStats", Method: "GET", Path: "/debug/memstats", HandlerFunc: MemStats})
+		debugRoutes = append(debugRoutes, Route{Name: "QuerySecondUpgrade", Method: "POST", Path: "/debug/second", HandlerFunc: SecondUpgrade, Params: HeightParams{}})
+		debugRoutes = append(debugRoutes, Route{Name: "QueryValidatorByChain", Method: "POST", Path: "/debug/vbc", HandlerFunc: QueryValidatorsByChain, Params: HeightAndValidatorOptsParams{}})
+		// the debug routes expose the internals of the node, they require the debug scope
+		for _, route := range debugRoutes {
+			route.HandlerFunc = Authorized(app.ScopeDebug, route.HandlerFunc)
+			routes = append(routes, route)
+		}
 	}
 
 	if allBlockTxs {
-		routes = append(routes, Route{Name: "QueryAllBlockTxs", Method: "POST", Path: "/v1/query/allblocktxs", HandlerFunc: AllBlockTxs})
+		routes = append(routes, Route{Name: "QueryAllBlockTxs", Method: "POST", Path: "/v1/query/allblocktxs", HandlerFunc: AllBlockTxs, Params: PaginatedHeightParams{}})
 	}
 
 	//if hot reload is not enabled, enable manual reload.
 	if !hotReloadChains {
-		routes = append(routes, Route{Name: "UpdateChains", Method: "POST", Path: "/v1/private/updatechains", HandlerFunc: UpdateChains})
+		routes = append(routes, Route{Name: "UpdateChains", Method: "POST", Path: "/v1/private/updatechains", HandlerFunc: Authorized(app.ScopeWriteChains, UpdateChains)})
 	}
 
-	srv := &http.Server{
-		ReadTimeout:       30 * time.Second,
-		ReadHeaderTimeout: 20 * time.Second,
-		WriteTimeout:      60 * time.Second,
-		Addr:              ":" + port,
-		Handler:           http.TimeoutHandler(Router(routes), time.Duration(timeout)*time.Millisecond, "Server Timeout Handling Request"),
+	limits := newLimits(timeout)
+	routes = append(routes, Route{Name: "JSONRPC", Method: "POST", Path: JSONRPCPath, HandlerFunc: JSONRPC(routes, limits)})
+
+	cfg := app.GlobalConfig.PocketConfig
+	var tlsConfig *tls.Config
+	if cfg.RPCTLSCertFile != "" {
+		var err error
+		tlsConfig, err = NewTLSConfig(cfg.RPCTLSCertFile, cfg.RPCTLSKeyFile, cfg.RPCTLSClientCAFile, rpcLogger())
+		if err != nil {
+			log.Fatal(fmt.Errorf("unable to load the rpc tls certificate: %s", err.Error()))
+		}
+	}
+	groups := make(map[string]Routes)
+	for _, route := range routes {
+		group := RouteGroup(route.Path)
+		groups[group] = append(groups[group], route)
+	}
+	errs := make(chan error)
+	for _, l := range groupListeners(port) {
+		mux := http.NewServeMux()
+		mux.Handle("/", listenerHandler(l.groups, groups, limits))
+		// the event stream is a long lived websocket, it can't be hijacked through the timeout handler and manages its own deadlines
+		if l.serves(types.RPCGroupQuery) && cfg.MaxEventSubscribers > 0 {
+			mux.Handle(EventsPath, Router(Routes{Route{Name: "Events", Method: "GET", Path: EventsPath, HandlerFunc: Events}}))
+		}
+		srv := &http.Server{
+			ReadTimeout:       30 * time.Second,
+			ReadHeaderTimeout: 20 * time.Second,
+			WriteTimeout:      60 * time.Second,
+			Addr:              l.addr,
+			Handler:           mux,
+			TLSConfig:         tlsConfig,
+		}
+		go func() {
+			if tlsConfig != nil {
+				errs <- srv.ListenAndServeTLS("", "")
+				return
+			}
+			errs <- srv.ListenAndServe()
+		}()
 	}
-	log.Fatal(srv.ListenAndServe())
+	log.Fatal(<-errs)
+}
+
+// rpcLogger returns the logger of the running app, or a no-op logger without one
+func rpcLogger() tmlog.Logger {
+	if app.PCA == nil {
+		return tmlog.NewNopLogger()
+	}
+	return app.PCA.Logger().With("module", "rpc")
 }
 
 func Router(routes Routes) *httprouter.Router {
@@ -68,10 +120,9 @@ func Router(routes Routes) *httprouter.Router {
 	return router
 }
 
+// cors sets the headers of the cors policy of the relay group, the group of the routes calling it
 func cors(w *http.ResponseWriter, r *http.Request) (isOptions bool) {
-	(*w).Header().Set("Access-Control-Allow-Origin", "*")
-	(*w).Header().Set("Access-Control-Allow-Methods", "POST")
-	(*w).Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+	setCORSHeaders(*w, r, groupConfig(types.RPCGroupRelay).CORSOrigins)
 	return ((*r).Method == "OPTIONS")
 }
 
@@ -80,6 +131,8 @@ type Route struct {
 	Method      string
 	Path        string
 	HandlerFunc httprouter.Handle
+	// Params is the model of the request body, for the jsonrpc methods and their OpenRPC description
+	Params interface{}
 }
 
 type Routes []Route
@@ -87,40 +140,46 @@ type Routes []Route
 func GetRoutes() Routes {
 	routes := Routes{
 		Route{Name: "AppVersion", Method: "GET", Path: "/v1", HandlerFunc: Version},
-		Route{Name: "Challenge", Method: "POST", Path: "/v1/client/challenge", HandlerFunc: Challenge},
+		Route{Name: "Challenge", Method: "POST", Path: "/v1/client/challenge", HandlerFunc: Challenge, Params: pocketTypes.ChallengeProofInvalidData{}},
 		Route{Name: "ChallengeCORS", Method: "OPTIONS", Path: "/v1/client/challenge", HandlerFunc: Challenge},
-		Route{Name: "HandleDispatch", Method: "POST", Path: "/v1/client/dispatch", HandlerFunc: Dispatch},
+		Route{Name: "HandleDispatch", Method: "POST", Path: "/v1/client/dispatch", HandlerFunc: Dispatch, Params: pocketTypes.SessionHeader{}},
 		Route{Name: "HandleDispatchCORS", Method: "OPTIONS", Path: "/v1/client/dispatch", HandlerFunc: Dispatch},
-		Route{Name: "SendRawTx", Method: "POST", Path: "/v1/client/rawtx", HandlerFunc: SendRawTx},
-		Route{Name: "Service", Method: "POST", Path: "/v1/client/relay", HandlerFunc: Relay},
-		Route{Name: "Stop", Method: "POST", Path: "/v1/private/stop", HandlerFunc: Stop},
+		Route{Name: "HandleBatchDispatch", Method: "POST", Path: "/v1/client/batchdispatch", HandlerFunc: BatchDispatch, Params: BatchDispatchParams{}},
+		Route{Name: "HandleBatchDispatchCORS", Method: "OPTIONS", Path: "/v1/client/batchdispatch", HandlerFunc: BatchDispatch},
+		Route{Name: "SendRawTx", Method: "POST", Path: "/v1/client/rawtx", HandlerFunc: SendRawTx, Params: SendRawTxParams{}},
+		Route{Name: "SimulateTx", Method: "POST", Path: "/v1/client/simulate", HandlerFunc: SimulateTx, Params: SendRawTxParams{}},
+		Route{Name: "Service", Method: "POST", Path: "/v1/client/relay", HandlerFunc: Relay, Params: pocketTypes.Relay{}},
+		Route{Name: "Stop", Method: "POST", Path: "/v1/private/stop", HandlerFunc: Authorized(app.ScopeStop, Stop)},
 		Route{Name: "ServiceCORS", Method: "OPTIONS", Path: "/v1/client/relay", HandlerFunc: Relay},
-		Route{Name: "QueryAccount", Method: "POST", Path: "/v1/query/account", HandlerFunc: Account},
-		Route{Name: "QueryAccountTxs", Method: "POST", Path: "/v1/query/accounttxs", HandlerFunc: AccountTxs},
-		Route{Name: "QueryACL", Method: "POST", Path: "/v1/query/acl", HandlerFunc: ACL},
-		Route{Name: "QueryAllParams", Method: "POST", Path: "/v1/query/allparams", HandlerFunc: AllParams},
-		Route{Name: "QueryApp", Method: "POST", Path: "/v1/query/app", HandlerFunc: App},
-		Route{Name: "QueryAppParams", Method: "POST", Path: "/v1/query/appparams", HandlerFunc: AppParams},
-		Route{Name: "QueryApps", Method: "POST", Path: "/v1/query/apps", HandlerFunc: Apps},
-		Route{Name: "QueryBalance", Method: "POST", Path: "/v1/query/balance", HandlerFunc: Balance},
-		Route{Name: "QueryBlock", Method: "POST", Path: "/v1/query/block", HandlerFunc: Block},
-		Route{Name: "QueryBlockTxs", Method: "POST", Path: "/v1/query/blocktxs", HandlerFunc: BlockTxs},
-		Route{Name: "QueryDAOOwner", Method: "POST", Path: "/v1/query/daoowner", HandlerFunc: DAOOwner},
+		Route{Name: "QueryAccount", Method: "POST", Path: "/v1/query/account", HandlerFunc: Account, Params: HeightAndAddrParams{}},
+		Route{Name: "QueryAccountTxs", Method: "POST", Path: "/v1/query/accounttxs", HandlerFunc: AccountTxs, Params: PaginateAddrParams{}},
+		Route{Name: "QueryAccountHistory", Method: "POST", Path: "/v1/query/accounthistory", HandlerFunc: AccountHistory, Params: PaginateAddrParams{}},
+		Route{Name: "QueryACL", Method: "POST", Path: "/v1/query/acl", HandlerFunc: ACL, Params: HeightParams{}},
+		Route{Name: "QueryAllParams", Method: "POST", Path: "/v1/query/allparams", HandlerFunc: AllParams, Params: HeightParams{}},
+		Route{Name: "QueryApp", Method: "POST", Path: "/v1/query/app", HandlerFunc: App, Params: HeightAndAddrParams{}},
+		Route{Name: "QueryAppParams", Method: "POST", Path: "/v1/query/appparams", HandlerFunc: AppParams, Params: HeightParams{}},
+		Route{Name: "QueryApps", Method: "POST", Path: "/v1/query/apps", HandlerFunc: Apps, Params: HeightAndApplicaitonOptsParams{}},
+		Route{Name: "QueryBalance", Method: "POST", Path: "/v1/query/balance", HandlerFunc: Balance, Params: HeightAndAddrParams{}},
+		Route{Name: "QueryBlock", Method: "POST", Path: "/v1/query/block", HandlerFunc: Block, Params: HeightParams{}},
+		Route{Name: "QueryBlockTxs", Method: "POST", Path: "/v1/query/blocktxs", HandlerFunc: BlockTxs, Params: PaginatedHeightParams{}},
+		Route{Name: "QueryDAOOwner", Method: "POST", Path: "/v1/query/daoowner", HandlerFunc: DAOOwner, Params: HeightParams{}},
 		Route{Name: "QueryHeight", Method: "POST", Path: "/v1/query/height", HandlerFunc: Height},
-		Route{Name: "QueryNode", Method: "POST", Path: "/v1/query/node", HandlerFunc: Node},
-		Route{Name: "QueryNodeClaim", Method: "POST", Path: "/v1/query/nodeclaim", HandlerFunc: NodeClaim},
-		Route{Name: "QueryNodeClaims", Method: "POST", Path: "/v1/query/nodeclaims", HandlerFunc: NodeClaims},
-		Route{Name: "QueryNodeParams", Method: "POST", Path: "/v1/query/nodeparams", HandlerFunc: NodeParams},
-		Route{Name: "QueryNodes", Method: "POST", Path: "/v1/query/nodes", HandlerFunc: Nodes},
-		Route{Name: "QueryParam", Method: "POST", Path: "/v1/query/param", HandlerFunc: Param},
-		Route{Name: "QueryPocketParams", Method: "POST", Path: "/v1/query/pocketparams", HandlerFunc: PocketParams},
-		Route{Name: "QueryState", Method: "POST", Path: "/v1/query/state", HandlerFunc: State},
-		Route{Name: "QuerySupply", Method: "POST", Path: "/v1/query/supply", HandlerFunc: Supply},
-		Route{Name: "QuerySupportedChains", Method: "POST", Path: "/v1/query/supportedchains", HandlerFunc: SupportedChains},
-		Route{Name: "QueryTX", Method: "POST", Path: "/v1/query/tx", HandlerFunc: Tx},
-		Route{Name: "QueryUpgrade", Method: "POST", Path: "/v1/query/upgrade", HandlerFunc: Upgrade},
-		Route{Name: "QuerySigningInfo", Method: "POST", Path: "/v1/query/signinginfo", HandlerFunc: SigningInfo},
-		Route{Name: "QueryChains", Method: "POST", Path: "/v1/private/chains", HandlerFunc: Chains},
+		Route{Name: "QueryNode", Method: "POST", Path: "/v1/query/node", HandlerFunc: Node, Params: HeightAndAddrParams{}},
+		Route{Name: "QueryNodeClaim", Method: "POST", Path: "/v1/query/nodeclaim", HandlerFunc: NodeClaim, Params: QueryNodeReceiptParam{}},
+		Route{Name: "QueryNodeClaims", Method: "POST", Path: "/v1/query/nodeclaims", HandlerFunc: NodeClaims, Params: PaginatedHeightAndAddrParams{}},
+		Route{Name: "QueryNodeParams", Method: "POST", Path: "/v1/query/nodeparams", HandlerFunc: NodeParams, Params: HeightParams{}},
+		Route{Name: "QueryNodes", Method: "POST", Path: "/v1/query/nodes", HandlerFunc: Nodes, Params: HeightAndValidatorOptsParams{}},
+		Route{Name: "QueryParam", Method: "POST", Path: "/v1/query/param", HandlerFunc: Param, Params: HeightAndKeyParams{}},
+		Route{Name: "QueryPocketParams", Method: "POST", Path: "/v1/query/pocketparams", HandlerFunc: PocketParams, Params: HeightParams{}},
+		Route{Name: "QueryState", Method: "POST", Path: "/v1/query/state", HandlerFunc: State, Params: HeightParams{}},
+		Route{Name: "QuerySupply", Method: "POST", Path: "/v1/query/supply", HandlerFunc: Supply, Params: HeightParams{}},
+		Route{Name: "QuerySupportedChains", Method: "POST", Path: "/v1/query/supportedchains", HandlerFunc: SupportedChains, Params: HeightParams{}},
+		Route{Name: "QueryTX", Method: "POST", Path: "/v1/query/tx", HandlerFunc: Tx, Params: HashAndProveParams{}},
+		Route{Name: "QueryTxSearch", Method: "POST", Path: "/v1/query/txsearch", HandlerFunc: TxSearch, Params: PaginatedQueryParams{}},
+		Route{Name: "QueryUnconfirmedTxs", Method: "POST", Path: "/v1/query/unconfirmedtxs", HandlerFunc: UnconfirmedTxs, Params: LimitParams{}},
+		Route{Name: "QueryUpgrade", Method: "POST", Path: "/v1/query/upgrade", HandlerFunc: Upgrade, Params: HeightParams{}},
+		Route{Name: "QuerySigningInfo", Method: "POST", Path: "/v1/query/signinginfo", HandlerFunc: SigningInfo, Params: PaginatedHeightAndAddrParams{}},
+		Route{Name: "QueryChains", Method: "POST", Path: "/v1/private/chains", HandlerFunc: Authorized(app.ScopeReadChains, Chains)},
 	}
 	return routes
 }
@@ -206,13 +265,26 @@ func WriteErrorResponse(w http.ResponseWriter, errorCode int, errorMsg string) {
 	}
 }
 
+// writeError writes the error response of err; the JSON-RPC methods keep the codespace and code of an sdk error
+func writeError(w http.ResponseWriter, errorCode int, err error) {
+	if b, ok := w.(*bufferedResponse); ok {
+		if e, ok := err.(types.Error); ok {
+			b.err = e
+		}
+	}
+	WriteErrorResponse(w, errorCode, err.Error())
+}
+
 type rpcError struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 }
 
+// maxRequestBody is the max size of a request body
+const maxRequestBody = 1048576
+
 func PopModel(_ http.ResponseWriter, r *http.Request, _ httprouter.Params, model interface{}) error {
-	body, err := ioutil.ReadAll(io.LimitReader(r.Body, 1048576))
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxRequestBody))
 	if err != nil {
 		return err
 	}
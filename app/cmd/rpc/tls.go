@@ -0,0 +1,116 @@
+package rpc
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// tlsReloadInterval is the minimum time between two checks of the certificate files
+var tlsReloadInterval = 10 * time.Second
+
+// tlsReloader serves the certificate of its files, loading it again when the content of the files changes so rotated
+// certificates are served without a restart. The files are checked at most once per tlsReloadInterval, in the
+// background of a handshake
+type tlsReloader struct {
+	certFile, keyFile string
+	logger            log.Logger
+
+	mu        sync.Mutex
+	checked   time.Time
+	reloading bool
+	hash      [sha256.Size]byte
+	cert      *tls.Certificate
+}
+
+// NewTLSConfig returns the tls config of the rpc server for the certificate and key files. When clientCAFile is set,
+// the client certificates signed by its CAs are verified; they are only required by the private and debug routes.
+// The certificate is reloaded when its files change, the client CA bundle is loaded once
+func NewTLSConfig(certFile, keyFile, clientCAFile string, logger log.Logger) (*tls.Config, error) {
+	r := &tlsReloader{certFile: certFile, keyFile: keyFile, logger: logger}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	c := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: r.getCertificate,
+	}
+	if clientCAFile != "" {
+		clientCA, err := LoadCertPool(clientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		c.ClientCAs, c.ClientAuth = clientCA, tls.VerifyClientCertIfGiven
+	}
+	return c, nil
+}
+
+// getCertificate returns the current certificate, checking the files in the background when they were not checked for
+// tlsReloadInterval
+func (r *tlsReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.reloading && time.Since(r.checked) >= tlsReloadInterval {
+		r.reloading = true
+		go func() {
+			if err := r.reload(); err != nil {
+				// keep serving the last valid certificate, the files may be in the middle of a rotation
+				r.logger.Error("unable to reload the rpc tls certificate: " + err.Error())
+			}
+		}()
+	}
+	return r.cert, nil
+}
+
+// reload loads the certificate again when the content of its files changed
+func (r *tlsReloader) reload() error {
+	certPEM, err := ioutil.ReadFile(r.certFile)
+	var keyPEM []byte
+	if err == nil {
+		keyPEM, err = ioutil.ReadFile(r.keyFile)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checked, r.reloading = time.Now(), false
+	if err != nil {
+		return err
+	}
+	hash := sha256.Sum256(append(certPEM, keyPEM...))
+	if r.cert != nil && hash == r.hash {
+		return nil
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return err
+	}
+	if r.cert != nil {
+		r.logger.Info("reloaded the rpc tls certificate " + r.certFile)
+	}
+	r.cert, r.hash = &cert, hash
+	return nil
+}
+
+// LoadCertPool returns the pool of the certificates of the pem file
+func LoadCertPool(file string) (*x509.CertPool, error) {
+	bz, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(bz) {
+		return nil, fmt.Errorf("no certificate found in %s", file)
+	}
+	return pool, nil
+}
+
+// hasClientCert returns whether the request was made with a client certificate verified by the client CA bundle
+func hasClientCert(r *http.Request) bool {
+	return r.TLS != nil && len(r.TLS.VerifiedChains) > 0
+}
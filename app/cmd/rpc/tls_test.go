@@ -0,0 +1,130 @@
+package rpc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/pokt-network/pocket-core/app"
+	"github.com/pokt-network/pocket-core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// newTestCert returns a certificate for localhost signed by parent (self signed when parent is nil)
+func newTestCert(t *testing.T, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, []byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	if parent == nil {
+		tmpl.IsCA, tmpl.BasicConstraintsValid, tmpl.KeyUsage = true, true, x509.KeyUsageCertSign|x509.KeyUsageDigitalSignature
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return cert, key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+func TestRPC_TLS(t *testing.T) {
+	dir := t.TempDir()
+	ca, caKey, caPem, _ := newTestCert(t, "ca", nil, nil)
+	_, _, serverPem, serverKey := newTestCert(t, "server", ca, caKey)
+	_, _, clientPem, clientKey := newTestCert(t, "client", ca, caKey)
+	write := func(name string, bz []byte) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(path, bz, 0600))
+		return path
+	}
+	caFile, certFile, keyFile := write("ca.pem", caPem), write("server.pem", serverPem), write("server.key", serverKey)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, types.ConfigDirName), 0700))
+	prev := app.GlobalConfig.PocketConfig
+	app.GlobalConfig.PocketConfig.DataDir = dir
+	app.GlobalConfig.PocketConfig.RPCTLSClientCAFile = caFile
+	defer func() { app.GlobalConfig.PocketConfig = prev }()
+	token, err := app.GetAuthTokens().Add("ops", []string{app.ScopeReadChains})
+	require.NoError(t, err)
+
+	prevInterval := tlsReloadInterval
+	tlsReloadInterval = 0
+	defer func() { tlsReloadInterval = prevInterval }()
+	tlsConfig, err := NewTLSConfig(certFile, keyFile, caFile, log.NewNopLogger())
+	require.NoError(t, err)
+	ok := func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		WriteResponse(w, "ok", r.URL.Path, r.Host)
+	}
+	srv := &http.Server{
+		Handler: Router(Routes{
+			Route{Name: "Public", Method: "POST", Path: "/v1/query/height", HandlerFunc: ok},
+			Route{Name: "Private", Method: "POST", Path: "/v1/private/chains", HandlerFunc: Authorized(app.ScopeReadChains, ok)},
+		}),
+		TLSConfig: tlsConfig,
+	}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = srv.ServeTLS(lis, "", "") }()
+	defer srv.Close()
+	url := "https://" + lis.Addr().String()
+
+	pool, err := LoadCertPool(caFile)
+	require.NoError(t, err)
+	clientCert, err := tls.X509KeyPair(clientPem, clientKey)
+	require.NoError(t, err)
+	call := func(path string, certs []tls.Certificate) (int, *x509.Certificate) {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool, Certificates: certs}, ForceAttemptHTTP2: true}}
+		defer client.CloseIdleConnections()
+		req, err := http.NewRequest("POST", url+path, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		// the server still negotiates http/2
+		assert.Equal(t, 2, resp.ProtoMajor)
+		return resp.StatusCode, resp.TLS.PeerCertificates[0]
+	}
+	// the public routes do not require a client certificate, the private ones do
+	code, served := call("/v1/query/height", nil)
+	assert.Equal(t, 200, code)
+	assert.Equal(t, "server", served.Subject.CommonName)
+	code, _ = call("/v1/private/chains", nil)
+	assert.Equal(t, 401, code)
+	code, _ = call("/v1/private/chains", []tls.Certificate{clientCert})
+	assert.Equal(t, 200, code)
+
+	// a rotated certificate is served without a restart, once the files were checked again in the background
+	_, _, rotatedPem, rotatedKey := newTestCert(t, "rotated", ca, caKey)
+	write("server.pem", rotatedPem)
+	write("server.key", rotatedKey)
+	assert.Eventually(t, func() bool {
+		_, served = call("/v1/query/height", nil)
+		return served.Subject.CommonName == "rotated"
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -20,6 +20,7 @@ import (
 	types2 "github.com/pokt-network/pocket-core/codec/types"
 	"github.com/pokt-network/pocket-core/crypto"
 	kb "github.com/pokt-network/pocket-core/crypto/keys"
+	"github.com/pokt-network/pocket-core/crypto/signer"
 	"github.com/pokt-network/pocket-core/store"
 	sdk "github.com/pokt-network/pocket-core/types"
 	"github.com/pokt-network/pocket-core/types/module"
@@ -348,6 +349,11 @@ func InitTendermint(keybase bool, chains *types.HostedBlockchains, logger log.Lo
 
 func InitKeyfiles() {
 	datadir := GlobalConfig.PocketConfig.DataDir
+	// the key is held by a signer daemon, nothing to load or create
+	if GlobalConfig.PocketConfig.RemoteSigner != "" {
+		initRemoteSigner(GlobalConfig.PocketConfig.RemoteSigner)
+		return
+	}
 	// Check if privvalkey file exist
 	if _, err := os.Stat(datadir + FS + GlobalConfig.TendermintConfig.PrivValidatorKey); err != nil {
 		// if not exist continue creating as other files may be missing
@@ -370,6 +376,16 @@ func InitKeyfiles() {
 	}
 }
 
+// initRemoteSigner connects to the signer daemon that signs the relay responses and the claim / proof txs of the node
+func initRemoteSigner(addr string) {
+	client, err := signer.NewClient(addr, signer.DefaultTimeout)
+	if err != nil {
+		log2.Fatal(err)
+	}
+	types.InitSigner(client)
+	log2.Printf("Using the remote signer at %s with the key: %s", addr, client.PublicKey().RawString())
+}
+
 func InitLogger() (logger log.Logger) {
 	logger = log.NewTMLoggerWithColorFn(log.NewSyncWriter(os.Stdout), func(keyvals ...interface{}) term.FgBgColor {
 		if keyvals[0] != kitlevel.Key() {
@@ -419,9 +435,71 @@ func MustGetKeybase() kb.Keybase {
 	return keys
 }
 
+// NewKeybase returns the keybase of the configured backend (keybase_backend)
+func NewKeybase() (kb.Keybase, error) {
+	return NewKeybaseWithBackend(GlobalConfig.PocketConfig.KeybaseBackend, GlobalConfig.PocketConfig.KeybaseReadOnly)
+}
+
+// NewKeybaseWithBackend returns the keybase of the data directory stored with the backend
+func NewKeybaseWithBackend(backend string, readOnly bool) (kb.Keybase, error) {
+	switch backend {
+	case kb.BackendDir:
+		return kb.NewKeystore(KeystoreDir(), readOnly), nil
+	case kb.BackendLevelDB, "":
+		if readOnly {
+			return kb.NewReadOnly(GlobalConfig.PocketConfig.KeybaseName, GlobalConfig.PocketConfig.DataDir), nil
+		}
+		return kb.New(GlobalConfig.PocketConfig.KeybaseName, GlobalConfig.PocketConfig.DataDir), nil
+	default:
+		return nil, fmt.Errorf("unknown keybase backend %s, expected %s or %s", backend, kb.BackendLevelDB, kb.BackendDir)
+	}
+}
+
+// KeystoreDir returns the directory of the keystore (keystore_dir), relative to the data directory unless absolute
+func KeystoreDir() string {
+	dir := GlobalConfig.PocketConfig.KeystoreDir
+	if fp.IsAbs(dir) {
+		return dir
+	}
+	return fp.Join(GlobalConfig.PocketConfig.DataDir, dir)
+}
+
+// MigrateKeybase copies every key of the configured keybase to the backend and switches the config to it.
+// The source keybase is left in place
+func MigrateKeybase(to string) (copied int, err error) {
+	from := GlobalConfig.PocketConfig.KeybaseBackend
+	if from == "" {
+		from = kb.BackendLevelDB
+	}
+	if from == to {
+		return 0, fmt.Errorf("the keybase is already stored with the %s backend", to)
+	}
+	if to != kb.BackendDir && to != kb.BackendLevelDB {
+		return 0, fmt.Errorf("unknown keybase backend %s, expected %s or %s", to, kb.BackendLevelDB, kb.BackendDir)
+	}
+	src, err := NewKeybaseWithBackend(from, true)
+	if err != nil {
+		return 0, err
+	}
+	dst, err := NewKeybaseWithBackend(to, false)
+	if err != nil {
+		return 0, err
+	}
+	copied, err = kb.Migrate(src, dst)
+	if err != nil {
+		return copied, err
+	}
+	GlobalConfig.PocketConfig.KeybaseBackend = to
+	writeConfigFile(GlobalConfig.PocketConfig.DataDir+FS+sdk.ConfigDirName+FS+sdk.ConfigFileName, nil)
+	return copied, nil
+}
+
 // get the global keybase
 func GetKeybase() (kb.Keybase, error) {
-	keys := kb.New(GlobalConfig.PocketConfig.KeybaseName, GlobalConfig.PocketConfig.DataDir)
+	keys, err := NewKeybase()
+	if err != nil {
+		return nil, err
+	}
 	kps, err := keys.List()
 	if err != nil {
 		return nil, err
@@ -551,9 +629,7 @@ func HotReloadChains(chains *types.HostedBlockchains) {
 				}
 				m[chain.ID] = chain
 			}
-			chains.L.Lock()
-			chains.M = m
-			chains.L.Unlock()
+			chains.Set(m)
 		}
 	}()
 }
@@ -0,0 +1,240 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	sdk "github.com/pokt-network/pocket-core/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+const (
+	StreamTypeBlock = "block"
+	StreamTypeTx    = "tx"
+	StreamTypeEvent = "event"
+
+	EventSourceBeginBlock = "begin_block"
+	EventSourceTx         = "tx"
+	EventSourceEndBlock   = "end_block"
+
+	streamHeightsCapacity = 100
+)
+
+// StreamFilter selects the messages of an event stream
+type StreamFilter struct {
+	Types      []string     // block, tx and/or event (empty = all)
+	TxQuery    *query.Query // txs (and their module events) must match this query (nil = all)
+	EventTypes []string     // module event types e.g. stake, jail, claim (empty = all)
+}
+
+// StreamMessage is a single message of an event stream
+type StreamMessage struct {
+	Type   string           `json:"type"`
+	Height int64            `json:"height"`
+	Block  *StreamBlockData `json:"block,omitempty"`
+	Tx     *StreamTxData    `json:"tx,omitempty"`
+	Event  *StreamEventData `json:"event,omitempty"`
+}
+
+type StreamBlockData struct {
+	Hash     string    `json:"hash"`
+	Time     time.Time `json:"time"`
+	NumTxs   int       `json:"num_txs"`
+	Proposer string    `json:"proposer"`
+}
+
+type StreamTxData struct {
+	Hash        string           `json:"hash"`
+	Index       uint32           `json:"index"`
+	Code        uint32           `json:"code"`
+	Codespace   string           `json:"codespace,omitempty"`
+	MessageType string           `json:"message_type"`
+	Signer      string           `json:"signer,omitempty"`
+	Recipient   string           `json:"recipient,omitempty"`
+	Events      sdk.StringEvents `json:"events"`
+}
+
+type StreamEventData struct {
+	Source string          `json:"source"`
+	TxHash string          `json:"tx_hash,omitempty"`
+	Event  sdk.StringEvent `json:"event"`
+}
+
+func (f StreamFilter) wants(streamType string) bool {
+	return len(f.Types) == 0 || sdk.ContainsString(f.Types, streamType)
+}
+
+func (f StreamFilter) wantsEvent(eventType string) bool {
+	return len(f.EventTypes) == 0 || sdk.ContainsString(f.EventTypes, eventType)
+}
+
+// StreamEvents sends the stream messages of every committed block, starting at fromHeight (zero = the next block),
+// until the context is done or send returns an error. The messages of a height are sent in execution order
+// (begin block events, txs and their events, end block events) followed by the block message itself, so the height
+// of the last received block message is the point to resume from.
+func (app PocketCoreApp) StreamEvents(ctx context.Context, subscriber string, fromHeight int64, filter StreamFilter, send func(StreamMessage) error) error {
+	tmClient := app.GetClient()
+	if !tmClient.IsRunning() {
+		// a remote (http) client must be running for subscriptions
+		if err := tmClient.Start(); err != nil {
+			return err
+		}
+	}
+	// new block headers are only used as a signal, every height is (re)read from the block store so no height is
+	// skipped when the subscription drops a signal or while catching up from an older height
+	q := tmtypes.QueryForEvent(tmtypes.EventNewBlockHeader).String()
+	heights, err := tmClient.Subscribe(ctx, subscriber, q, streamHeightsCapacity)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tmClient.Unsubscribe(context.Background(), subscriber, q) }()
+	if err := app.ValidateStreamHeight(fromHeight); err != nil {
+		return err
+	}
+	latest := app.LastBlockHeight()
+	next := fromHeight
+	if next <= 0 {
+		next = latest + 1
+	}
+	sendUntil := func(height int64) error {
+		for ; next <= height; next++ {
+			msgs, err := app.HeightStreamMessages(next, filter)
+			if err != nil {
+				return err
+			}
+			for _, msg := range msgs {
+				if err := send(msg); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if err = sendUntil(latest); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case res := <-heights:
+			header, ok := res.Data.(tmtypes.EventDataNewBlockHeader)
+			if !ok {
+				continue
+			}
+			if err = sendUntil(header.Header.Height); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// ValidateStreamHeight checks that a stream can resume from the height: within the max event lookback of the config (0 =
+// unlimited) and not pruned from the block store
+func (app PocketCoreApp) ValidateStreamHeight(fromHeight int64) error {
+	if fromHeight <= 0 {
+		return nil
+	}
+	latest := app.LastBlockHeight()
+	if lookback := GlobalConfig.PocketConfig.MaxEventLookback; lookback > 0 && latest-fromHeight >= lookback {
+		return fmt.Errorf("from_height %d is older than the max lookback of %d blocks, the lowest height is %d", fromHeight, lookback, latest-lookback+1)
+	}
+	if bs := app.BlockStore(); bs != nil && fromHeight < bs.Base() {
+		return fmt.Errorf("from_height %d is pruned, the lowest available height is %d", fromHeight, bs.Base())
+	}
+	return nil
+}
+
+// HeightStreamMessages returns the stream messages of a committed height that pass the filter
+func (app PocketCoreApp) HeightStreamMessages(height int64, filter StreamFilter) (msgs []StreamMessage, err error) {
+	tmClient := app.GetClient()
+	b, err := tmClient.Block(&height)
+	if err != nil {
+		return nil, err
+	}
+	results, err := tmClient.BlockResults(&height)
+	if err != nil {
+		return nil, err
+	}
+	blockEvents := func(source string, events []abci.Event) {
+		if !filter.wants(StreamTypeEvent) {
+			return
+		}
+		for _, e := range events {
+			if filter.wantsEvent(e.Type) {
+				msgs = append(msgs, StreamMessage{Type: StreamTypeEvent, Height: height, Event: &StreamEventData{Source: source, Event: sdk.StringifyEvent(e)}})
+			}
+		}
+	}
+	blockEvents(EventSourceBeginBlock, results.BeginBlockEvents)
+	for i, result := range results.TxsResults {
+		if i >= len(b.Block.Txs) {
+			break
+		}
+		txResult := &tmtypes.TxResult{Height: height, Index: uint32(i), Tx: b.Block.Txs[i], Result: *result}
+		if filter.TxQuery != nil {
+			match, err := filter.TxQuery.Matches(txStreamEvents(txResult))
+			if err != nil {
+				return nil, err
+			}
+			if !match {
+				continue
+			}
+		}
+		hash := fmt.Sprintf("%X", txResult.Tx.Hash())
+		if filter.wants(StreamTypeTx) {
+			tx := &StreamTxData{
+				Hash:        hash,
+				Index:       txResult.Index,
+				Code:        result.Code,
+				Codespace:   result.Codespace,
+				MessageType: result.MessageType,
+				Events:      sdk.StringifyEvents(result.Events),
+			}
+			if result.Signer != nil {
+				tx.Signer = sdk.Address(result.Signer).String()
+			}
+			if result.Recipient != nil {
+				tx.Recipient = sdk.Address(result.Recipient).String()
+			}
+			msgs = append(msgs, StreamMessage{Type: StreamTypeTx, Height: height, Tx: tx})
+		}
+		if filter.wants(StreamTypeEvent) {
+			for _, e := range result.Events {
+				if filter.wantsEvent(e.Type) {
+					msgs = append(msgs, StreamMessage{Type: StreamTypeEvent, Height: height, Event: &StreamEventData{Source: EventSourceTx, TxHash: hash, Event: sdk.StringifyEvent(e)}})
+				}
+			}
+		}
+	}
+	blockEvents(EventSourceEndBlock, results.EndBlockEvents)
+	if filter.wants(StreamTypeBlock) {
+		msgs = append(msgs, StreamMessage{Type: StreamTypeBlock, Height: height, Block: &StreamBlockData{
+			Hash:     b.BlockID.Hash.String(),
+			Time:     b.Block.Time,
+			NumTxs:   len(b.Block.Txs),
+			Proposer: b.Block.ProposerAddress.String(),
+		}})
+	}
+	return
+}
+
+// the composite keys a tx filter query is matched against: the tx events (type.key), the tx indexer keys and tm.event
+func txStreamEvents(result *tmtypes.TxResult) map[string][]string {
+	events := sdk.TxIndexValues(result)
+	events[sdk.TxFeePayerKey] = events[sdk.TxSignerKey]
+	for _, e := range result.Result.Events {
+		for _, attr := range e.Attributes {
+			key := e.Type + "." + string(attr.Key)
+			events[key] = append(events[key], string(attr.Value))
+		}
+	}
+	events[tmtypes.EventTypeKey] = []string{tmtypes.EventTx}
+	events[tmtypes.TxHashKey] = []string{fmt.Sprintf("%X", result.Tx.Hash())}
+	events[tmtypes.TxHeightKey] = []string{strconv.FormatInt(result.Height, 10)}
+	return events
+}
@@ -0,0 +1,188 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/pokt-network/pocket-core/crypto/signer"
+	sdk "github.com/pokt-network/pocket-core/types"
+	pocketTypes "github.com/pokt-network/pocket-core/x/pocketcore/types"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// EvidenceSummary is a piece of evidence of the local evidence store
+type EvidenceSummary struct {
+	ApplicationPubKey  string `json:"app_public_key"`
+	Chain              string `json:"chain"`
+	SessionBlockHeight int64  `json:"session_height"`
+	EvidenceType       string `json:"evidence_type"`
+	NumOfProofs        int64  `json:"num_of_proofs"`
+	Sealed             bool   `json:"sealed"`
+}
+
+// EvidenceExport is a piece of evidence with its proofs and merkle root, as exported for bug reports
+type EvidenceExport struct {
+	EvidenceSummary
+	MerkleRoot pocketTypes.HashRange `json:"merkle_root"`
+	Proofs     []pocketTypes.Proof   `json:"proofs"`
+}
+
+// EvidenceVerification is the merkle root of a piece of evidence compared to the one of its claim
+type EvidenceVerification struct {
+	EvidenceSummary
+	MerkleRoot       pocketTypes.HashRange  `json:"merkle_root"`
+	ClaimFound       bool                   `json:"claim_found"`
+	ClaimMerkleRoot  *pocketTypes.HashRange `json:"claim_merkle_root,omitempty"`
+	ClaimTotalProofs int64                  `json:"claim_total_proofs,omitempty"`
+	Match            bool                   `json:"match"`
+}
+
+// InitEvidenceStore opens the evidence database of the data dir
+// CONTRACT: the node must not be running
+func InitEvidenceStore() {
+	pocketTypes.InitConfig(nil, log.NewNopLogger(), GlobalConfig)
+}
+
+// ListEvidence returns every piece of evidence of the local evidence store
+func ListEvidence() []EvidenceSummary {
+	it := pocketTypes.EvidenceIterator()
+	defer it.Close()
+	res := make([]EvidenceSummary, 0)
+	for ; it.Valid(); it.Next() {
+		res = append(res, evidenceSummary(it.Value()))
+	}
+	return res
+}
+
+// GetEvidence returns a piece of evidence of the local evidence store
+func GetEvidence(appPubKey, chain, evidenceType string, sessionHeight int64) (pocketTypes.Evidence, error) {
+	header, et, err := evidenceHeader(appPubKey, chain, evidenceType, sessionHeight)
+	if err != nil {
+		return pocketTypes.Evidence{}, err
+	}
+	evidence, err := pocketTypes.GetEvidence(header, et, sdk.ZeroInt())
+	if err != nil {
+		return pocketTypes.Evidence{}, fmt.Errorf("evidence not found for the session")
+	}
+	return evidence, nil
+}
+
+// ExportEvidence returns a piece of evidence of the local evidence store with all of its proofs
+func ExportEvidence(appPubKey, chain, evidenceType string, sessionHeight int64) (res EvidenceExport, err error) {
+	evidence, err := GetEvidence(appPubKey, chain, evidenceType, sessionHeight)
+	if err != nil {
+		return
+	}
+	res.EvidenceSummary = evidenceSummary(evidence)
+	res.Proofs = make([]pocketTypes.Proof, 0, evidence.NumOfProofs)
+	if err = evidence.IterateProofs(func(_ int64, p pocketTypes.Proof) {
+		res.Proofs = append(res.Proofs, p)
+	}); err != nil {
+		return
+	}
+	res.MerkleRoot, err = evidence.MerkleRoot(evidence.SessionBlockHeight)
+	return
+}
+
+// GetEvidenceProof returns the proof at the index of a piece of evidence of the local evidence store, reading only the
+// record of that proof
+func GetEvidenceProof(appPubKey, chain, evidenceType string, sessionHeight int64, index int64) (pocketTypes.Proof, error) {
+	evidence, err := GetEvidence(appPubKey, chain, evidenceType, sessionHeight)
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 || index >= evidence.NumOfProofs {
+		return nil, fmt.Errorf("the evidence has %d proofs", evidence.NumOfProofs)
+	}
+	p := pocketTypes.GetProof(evidence.SessionHeader, evidence.EvidenceType, index)
+	if p == nil {
+		return nil, fmt.Errorf("the proof %d of the evidence is not found", index)
+	}
+	return p, nil
+}
+
+// DeleteEvidence removes a piece of evidence and its proofs from the local evidence store
+func DeleteEvidence(appPubKey, chain, evidenceType string, sessionHeight int64) error {
+	evidence, err := GetEvidence(appPubKey, chain, evidenceType, sessionHeight)
+	if err != nil {
+		return err
+	}
+	return pocketTypes.DeleteEvidence(evidence.SessionHeader, evidence.EvidenceType)
+}
+
+// VerifyEvidence recomputes the merkle root of a piece of evidence and compares it to the one of the claim of this node
+// in the local application state
+// CONTRACT: the node must not be running
+func VerifyEvidence(appPubKey, chain, evidenceType string, sessionHeight int64) (res EvidenceVerification, err error) {
+	evidence, err := GetEvidence(appPubKey, chain, evidenceType, sessionHeight)
+	if err != nil {
+		return
+	}
+	res.EvidenceSummary = evidenceSummary(evidence)
+	if res.MerkleRoot, err = evidence.MerkleRoot(evidence.SessionBlockHeight); err != nil {
+		return
+	}
+	src, err := NewLocalExportSource()
+	if err != nil {
+		return
+	}
+	defer src.Close()
+	local := src.(*localExportSource)
+	address, err := selfAddress()
+	if err != nil {
+		return
+	}
+	claim, er := local.app.QueryClaim(address.String(), appPubKey, chain, evidenceType, sessionHeight, local.blockStore.Height())
+	if er != nil {
+		// no claim: not submitted yet, or already proven or expired
+		return res, nil
+	}
+	res.ClaimFound = true
+	res.ClaimMerkleRoot = &claim.MerkleRoot
+	res.ClaimTotalProofs = claim.TotalProofs
+	res.Match = res.MerkleRoot.Equal(claim.MerkleRoot) && evidence.NumOfProofs == claim.TotalProofs
+	return
+}
+
+// selfAddress returns the address of the node: the key of the remote signer when one is configured, or else the
+// private validator key of the data dir
+func selfAddress() (sdk.Address, error) {
+	if addr := GlobalConfig.PocketConfig.RemoteSigner; addr != "" {
+		client, err := signer.NewClient(addr, signer.DefaultTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get the address of the remote signer: %s", err.Error())
+		}
+		return sdk.Address(client.PublicKey().Address()), nil
+	}
+	return sdk.Address(GetPrivValFile().Address), nil
+}
+
+func evidenceHeader(appPubKey, chain, evidenceType string, sessionHeight int64) (pocketTypes.SessionHeader, pocketTypes.EvidenceType, error) {
+	header := pocketTypes.SessionHeader{
+		ApplicationPubKey:  appPubKey,
+		Chain:              chain,
+		SessionBlockHeight: sessionHeight,
+	}
+	if err := header.ValidateHeader(); err != nil {
+		return header, 0, err
+	}
+	et, err := pocketTypes.EvidenceTypeFromString(evidenceType)
+	if err != nil {
+		return header, 0, err
+	}
+	return header, et, nil
+}
+
+func evidenceSummary(e pocketTypes.Evidence) EvidenceSummary {
+	et := "relay"
+	if e.EvidenceType == pocketTypes.ChallengeEvidence {
+		et = "challenge"
+	}
+	return EvidenceSummary{
+		ApplicationPubKey:  e.ApplicationPubKey,
+		Chain:              e.Chain,
+		SessionBlockHeight: e.SessionBlockHeight,
+		EvidenceType:       et,
+		NumOfProofs:        e.NumOfProofs,
+		Sealed:             pocketTypes.IsEvidenceSealed(e.SessionHeader, e.EvidenceType),
+	}
+}
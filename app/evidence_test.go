@@ -0,0 +1,67 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/pokt-network/pocket-core/crypto"
+	sdk "github.com/pokt-network/pocket-core/types"
+	pocketTypes "github.com/pokt-network/pocket-core/x/pocketcore/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestEvidenceInspection(t *testing.T) {
+	pocketTypes.InitConfig(&pocketTypes.HostedBlockchains{
+		M: make(map[string]pocketTypes.HostedBlockchain),
+	}, log.NewNopLogger(), sdk.DefaultTestingPocketConfig())
+	pocketTypes.ClearEvidence()
+	defer pocketTypes.ClearEvidence()
+	appPubKey := crypto.GenerateEd25519PrivKey().PublicKey().RawString()
+	header := pocketTypes.SessionHeader{
+		ApplicationPubKey:  appPubKey,
+		Chain:              "0001",
+		SessionBlockHeight: 1,
+	}
+	for i := int64(0); i < 5; i++ {
+		pocketTypes.SetProof(header, pocketTypes.RelayEvidence, pocketTypes.RelayProof{
+			Entropy:            i,
+			SessionBlockHeight: 1,
+			Blockchain:         "0001",
+			Token:              pocketTypes.AAT{ApplicationPublicKey: appPubKey},
+		}, sdk.NewInt(100))
+	}
+	list := ListEvidence()
+	assert.Len(t, list, 1)
+	assert.Equal(t, EvidenceSummary{
+		ApplicationPubKey:  appPubKey,
+		Chain:              "0001",
+		SessionBlockHeight: 1,
+		EvidenceType:       "relay",
+		NumOfProofs:        5,
+	}, list[0])
+	// the export holds every proof and the root the claim would have
+	export, err := ExportEvidence(appPubKey, "0001", "relay", 1)
+	assert.Nil(t, err)
+	assert.Len(t, export.Proofs, 5)
+	assert.Equal(t, int64(3), export.Proofs[3].(pocketTypes.RelayProof).Entropy)
+	// a single proof
+	p, err := GetEvidenceProof(appPubKey, "0001", "relay", 1, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, export.Proofs[3], p)
+	_, err = GetEvidenceProof(appPubKey, "0001", "relay", 1, 5)
+	assert.NotNil(t, err)
+	evidence, err := GetEvidence(appPubKey, "0001", "relay", 1)
+	assert.Nil(t, err)
+	assert.Equal(t, evidence.GenerateMerkleRoot(1), export.MerkleRoot)
+	// the seal is persisted
+	assert.True(t, ListEvidence()[0].Sealed)
+	_, err = ExportEvidence(appPubKey, "0001", "challenge", 1)
+	assert.NotNil(t, err)
+	_, err = ExportEvidence(appPubKey, "0001", "unknown", 1)
+	assert.NotNil(t, err)
+	// delete
+	assert.Nil(t, DeleteEvidence(appPubKey, "0001", "relay", 1))
+	assert.Len(t, ListEvidence(), 0)
+	assert.False(t, pocketTypes.IsEvidenceSealed(header, pocketTypes.RelayEvidence))
+	assert.NotNil(t, DeleteEvidence(appPubKey, "0001", "relay", 1))
+}
@@ -0,0 +1,415 @@
+package app
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pokt-network/pocket-core/codec"
+	codecTypes "github.com/pokt-network/pocket-core/codec/types"
+	storeTypes "github.com/pokt-network/pocket-core/store/types"
+	sdk "github.com/pokt-network/pocket-core/types"
+	appsTypes "github.com/pokt-network/pocket-core/x/apps/types"
+	"github.com/pokt-network/pocket-core/x/auth"
+	"github.com/pokt-network/pocket-core/x/auth/exported"
+	authTypes "github.com/pokt-network/pocket-core/x/auth/types"
+	govTypes "github.com/pokt-network/pocket-core/x/gov/types"
+	nodesTypes "github.com/pokt-network/pocket-core/x/nodes/types"
+	pocketTypes "github.com/pokt-network/pocket-core/x/pocketcore/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/rpc/client"
+	core_types "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/state"
+	tmStore "github.com/tendermint/tendermint/store"
+	tmtypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+)
+
+const (
+	exportSQLLastHeightKey = "last_height"
+	exportSQLCommitEvery   = 100 // heights per sqlite transaction
+	nodeActor              = "node"
+	appActor               = "app"
+)
+
+// the normalized tables of the sql export, every statement is idempotent so a range can be exported again
+var exportSQLSchema = []string{
+	`CREATE TABLE IF NOT EXISTS export_meta (key TEXT PRIMARY KEY, value TEXT NOT NULL)`,
+	`CREATE TABLE IF NOT EXISTS blocks (height INTEGER PRIMARY KEY, hash TEXT NOT NULL, time TEXT NOT NULL, proposer TEXT NOT NULL, num_txs INTEGER NOT NULL)`,
+	`CREATE TABLE IF NOT EXISTS txs (hash TEXT PRIMARY KEY, height INTEGER NOT NULL, idx INTEGER NOT NULL, code INTEGER NOT NULL, codespace TEXT, message_type TEXT, signer TEXT, recipient TEXT, fee TEXT, memo TEXT)`,
+	`CREATE INDEX IF NOT EXISTS txs_height ON txs (height)`,
+	`CREATE INDEX IF NOT EXISTS txs_signer ON txs (signer)`,
+	`CREATE TABLE IF NOT EXISTS messages (tx_hash TEXT PRIMARY KEY, height INTEGER NOT NULL, type TEXT NOT NULL, msg TEXT NOT NULL)`,
+	`CREATE INDEX IF NOT EXISTS messages_type ON messages (type, height)`,
+	`CREATE TABLE IF NOT EXISTS claims (tx_hash TEXT PRIMARY KEY, height INTEGER NOT NULL, node TEXT NOT NULL, app_pubkey TEXT NOT NULL, chain TEXT NOT NULL, session_height INTEGER NOT NULL, evidence_type INTEGER NOT NULL, total_proofs INTEGER NOT NULL, expiration_height INTEGER NOT NULL)`,
+	`CREATE TABLE IF NOT EXISTS proofs (tx_hash TEXT PRIMARY KEY, height INTEGER NOT NULL, node TEXT NOT NULL, app_pubkey TEXT NOT NULL, chain TEXT NOT NULL, session_height INTEGER NOT NULL, evidence_type INTEGER NOT NULL)`,
+	`CREATE TABLE IF NOT EXISTS stakes (tx_hash TEXT PRIMARY KEY, height INTEGER NOT NULL, actor TEXT NOT NULL, address TEXT NOT NULL, public_key TEXT NOT NULL, amount TEXT NOT NULL, chains TEXT NOT NULL, service_url TEXT, output TEXT)`,
+	`CREATE TABLE IF NOT EXISTS unstakes (tx_hash TEXT PRIMARY KEY, height INTEGER NOT NULL, actor TEXT NOT NULL, address TEXT NOT NULL)`,
+	`CREATE TABLE IF NOT EXISTS jailings (height INTEGER NOT NULL, address TEXT NOT NULL, reason TEXT, PRIMARY KEY (height, address))`,
+	`CREATE TABLE IF NOT EXISTS params (tx_hash TEXT PRIMARY KEY, height INTEGER NOT NULL, key TEXT NOT NULL, value TEXT NOT NULL, from_address TEXT NOT NULL)`,
+	`CREATE INDEX IF NOT EXISTS params_key ON params (key, height)`,
+	`CREATE TABLE IF NOT EXISTS balances (height INTEGER NOT NULL, address TEXT NOT NULL, balance TEXT NOT NULL, PRIMARY KEY (height, address))`,
+}
+
+// ExportSource is the chain data an sql export reads from
+type ExportSource interface {
+	LatestHeight() (int64, error)
+	Block(height int64) (*tmtypes.Block, error)
+	BlockResults(height int64) (*core_types.ResultBlockResults, error)
+	Accounts(height int64) ([]exported.Account, error)
+	Close()
+}
+
+// localExportSource reads the blockstore, state and application databases directly
+// CONTRACT: the node must not be running
+type localExportSource struct {
+	app          *PocketCoreApp
+	blockStore   *tmStore.BlockStore
+	blockStoreDB dbm.DB
+	stateDB      dbm.DB
+	appDB        dbm.DB
+}
+
+var _ ExportSource = &localExportSource{}
+
+func NewLocalExportSource() (ExportSource, error) {
+	db, err := OpenApplicationDB(GlobalConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error loading application database: %s", err.Error())
+	}
+	loggerFile, _ := os.Open(os.DevNull)
+	a := NewPocketCoreApp(nil, nil, nil, nil, log.NewTMLogger(loggerFile), db, false, GlobalConfig.PocketConfig.IavlCacheSize)
+	blockStore, _, blockStoreDB, stateDB, err := state.BlocksAndStateFromDB(&GlobalConfig.TendermintConfig, state.DefaultDBProvider)
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("error loading blockstore: %s", err.Error())
+	}
+	a.SetBlockstore(blockStore)
+	return &localExportSource{app: a, blockStore: blockStore, blockStoreDB: blockStoreDB, stateDB: stateDB, appDB: db}, nil
+}
+
+func (s *localExportSource) LatestHeight() (int64, error) {
+	return s.blockStore.Height(), nil
+}
+
+func (s *localExportSource) Block(height int64) (*tmtypes.Block, error) {
+	b := s.blockStore.LoadBlock(height)
+	if b == nil {
+		return nil, fmt.Errorf("block %d not found in the blockstore", height)
+	}
+	return b, nil
+}
+
+func (s *localExportSource) BlockResults(height int64) (*core_types.ResultBlockResults, error) {
+	res, err := state.LoadABCIResponses(s.stateDB, height)
+	if err != nil {
+		return nil, err
+	}
+	results := &core_types.ResultBlockResults{Height: height, TxsResults: res.DeliverTx}
+	if res.BeginBlock != nil {
+		results.BeginBlockEvents = res.BeginBlock.Events
+	}
+	if res.EndBlock != nil {
+		results.EndBlockEvents = res.EndBlock.Events
+	}
+	return results, nil
+}
+
+func (s *localExportSource) Accounts(height int64) ([]exported.Account, error) {
+	ctx, err := s.app.NewContext(height)
+	if err != nil {
+		return nil, err
+	}
+	return s.app.accountKeeper.GetAllAccounts(ctx), nil
+}
+
+func (s *localExportSource) Close() {
+	_ = s.appDB.Close()
+	_ = s.blockStoreDB.Close()
+	_ = s.stateDB.Close()
+}
+
+// remoteExportSource reads from the tendermint rpc of a running node
+type remoteExportSource struct {
+	client client.Client
+}
+
+var _ ExportSource = &remoteExportSource{}
+
+func NewRemoteExportSource() ExportSource {
+	return &remoteExportSource{client: getTMClient()}
+}
+
+func (s *remoteExportSource) LatestHeight() (int64, error) {
+	status, err := s.client.Status()
+	if err != nil {
+		return 0, err
+	}
+	return status.SyncInfo.LatestBlockHeight, nil
+}
+
+func (s *remoteExportSource) Block(height int64) (*tmtypes.Block, error) {
+	b, err := s.client.Block(&height)
+	if err != nil {
+		return nil, err
+	}
+	return b.Block, nil
+}
+
+func (s *remoteExportSource) BlockResults(height int64) (*core_types.ResultBlockResults, error) {
+	return s.client.BlockResults(&height)
+}
+
+// the store subspace query ignores the height (it iterates the latest tree), so the account keys are listed from the
+// latest state and every account is read at the height through a key query (accounts are never deleted)
+func (s *remoteExportSource) Accounts(height int64) ([]exported.Account, error) {
+	res, err := s.client.ABCIQueryWithOptions("/store/"+authTypes.StoreKey+"/subspace", authTypes.AddressStoreKeyPrefix, client.ABCIQueryOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if !res.Response.IsOK() {
+		return nil, fmt.Errorf("accounts query failed: %s", res.Response.Log)
+	}
+	var kvs []storeTypes.KVPair
+	if err = codec.NewCodec(codecTypes.NewInterfaceRegistry()).LegacyUnmarshalBinaryLengthPrefixed(res.Response.Value, &kvs); err != nil {
+		return nil, err
+	}
+	k := auth.Keeper{Cdc: Codec()}
+	ctx := sdk.NewContext(nil, abci.Header{Height: height}, false, log.NewNopLogger())
+	accounts := make([]exported.Account, 0, len(kvs))
+	for _, kv := range kvs {
+		res, err := s.client.ABCIQueryWithOptions("/store/"+authTypes.StoreKey+"/key", kv.Key, client.ABCIQueryOptions{Height: height})
+		if err != nil {
+			return nil, err
+		}
+		if !res.Response.IsOK() {
+			return nil, fmt.Errorf("account query failed: %s", res.Response.Log)
+		}
+		if len(res.Response.Value) == 0 {
+			// created after the height
+			continue
+		}
+		acc, err := k.DecodeAccount(res.Response.Value, ctx)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, acc)
+	}
+	return accounts, nil
+}
+
+func (s *remoteExportSource) Close() {}
+
+// sqliteDriver is the database/sql driver of the sql export, empty in the builds without cgo (see export_sql_sqlite.go)
+var sqliteDriver string
+
+// ExportSQL writes the blocks, txs, messages, claims, proofs, stakes, unstakes, jailings and param changes of the
+// heights [from, to] to normalized tables of the sqlite database at out, and the balance of every account at each
+// checkpoint height (a multiple of checkpoint, zero = no balances). A zero from continues after the last height
+// exported to out and a zero to is the latest height of the source, so running it repeatedly is incremental.
+func ExportSQL(src ExportSource, out string, from, to, checkpoint int64) (exportedFrom, exportedTo int64, err error) {
+	if sqliteDriver == "" {
+		return 0, 0, fmt.Errorf("the sql export needs the sqlite driver, which is only compiled in the builds with cgo (CGO_ENABLED=1)")
+	}
+	db, err := sql.Open(sqliteDriver, out)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer db.Close()
+	for _, stmt := range exportSQLSchema {
+		if _, err = db.Exec(stmt); err != nil {
+			return 0, 0, fmt.Errorf("unable to create the sql schema: %s", err.Error())
+		}
+	}
+	if from <= 0 {
+		var last string
+		switch err = db.QueryRow(`SELECT value FROM export_meta WHERE key = ?`, exportSQLLastHeightKey).Scan(&last); err {
+		case nil:
+			if from, err = strconv.ParseInt(last, 10, 64); err != nil {
+				return 0, 0, err
+			}
+			from++
+		case sql.ErrNoRows:
+			from = 1
+		default:
+			return 0, 0, err
+		}
+	}
+	if to <= 0 {
+		if to, err = src.LatestHeight(); err != nil {
+			return 0, 0, err
+		}
+	}
+	if from > to {
+		return from, to, nil // nothing new to export
+	}
+	var tx *sql.Tx
+	defer func() {
+		if tx != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	for height := from; height <= to; height++ {
+		if tx == nil {
+			if tx, err = db.Begin(); err != nil {
+				return 0, 0, err
+			}
+		}
+		if err = exportSQLHeight(tx, src, height, checkpoint); err != nil {
+			return 0, 0, fmt.Errorf("unable to export height %d: %s", height, err.Error())
+		}
+		if height%exportSQLCommitEvery == 0 || height == to {
+			if _, err = tx.Exec(`INSERT OR REPLACE INTO export_meta (key, value) VALUES (?, ?)`, exportSQLLastHeightKey, strconv.FormatInt(height, 10)); err != nil {
+				return 0, 0, err
+			}
+			if err = tx.Commit(); err != nil {
+				return 0, 0, err
+			}
+			tx = nil
+		}
+	}
+	return from, to, nil
+}
+
+func exportSQLHeight(tx *sql.Tx, src ExportSource, height, checkpoint int64) error {
+	b, err := src.Block(height)
+	if err != nil {
+		return err
+	}
+	results, err := src.BlockResults(height)
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec(`INSERT OR REPLACE INTO blocks (height, hash, time, proposer, num_txs) VALUES (?, ?, ?, ?, ?)`,
+		height, b.Hash().String(), b.Time.UTC().Format("2006-01-02T15:04:05.000000000Z"), b.ProposerAddress.String(), len(b.Txs)); err != nil {
+		return err
+	}
+	for i, result := range results.TxsResults {
+		if i >= len(b.Txs) {
+			break
+		}
+		if err = exportSQLTx(tx, height, uint32(i), b.Txs[i], result); err != nil {
+			return err
+		}
+	}
+	for _, events := range [][]abci.Event{results.BeginBlockEvents, results.EndBlockEvents} {
+		if err = exportSQLJailings(tx, height, events); err != nil {
+			return err
+		}
+	}
+	if checkpoint > 0 && height%checkpoint == 0 {
+		accounts, err := src.Accounts(height)
+		if err != nil {
+			return err
+		}
+		for _, acc := range accounts {
+			if _, err = tx.Exec(`INSERT OR REPLACE INTO balances (height, address, balance) VALUES (?, ?, ?)`,
+				height, acc.GetAddress().String(), acc.GetCoins().AmountOf(sdk.DefaultStakeDenom).String()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func exportSQLTx(tx *sql.Tx, height int64, index uint32, txBz tmtypes.Tx, result *abci.ResponseDeliverTx) error {
+	hash := fmt.Sprintf("%X", txBz.Hash())
+	var signer, recipient string
+	if result.Signer != nil {
+		signer = sdk.Address(result.Signer).String()
+	}
+	if result.Recipient != nil {
+		recipient = sdk.Address(result.Recipient).String()
+	}
+	stdTx, decodeErr := UnmarshalTx(txBz, height)
+	var fee, memo string
+	if decodeErr == nil {
+		fee, memo = stdTx.GetFee().String(), stdTx.GetMemo()
+	}
+	if _, err := tx.Exec(`INSERT OR REPLACE INTO txs (hash, height, idx, code, codespace, message_type, signer, recipient, fee, memo) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		hash, height, index, result.Code, result.Codespace, result.MessageType, signer, recipient, fee, memo); err != nil {
+		return err
+	}
+	if err := exportSQLJailings(tx, height, result.Events); err != nil {
+		return err
+	}
+	// undecodable txs only make it into the txs table, failed txs don't change the state so they're not normalized further
+	if decodeErr != nil || result.Code != 0 {
+		return nil
+	}
+	msg := stdTx.GetMsg()
+	msgJSON, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec(`INSERT OR REPLACE INTO messages (tx_hash, height, type, msg) VALUES (?, ?, ?, ?)`, hash, height, msg.Type(), string(msgJSON)); err != nil {
+		return err
+	}
+	switch m := msg.(type) {
+	case pocketTypes.MsgClaim:
+		_, err = tx.Exec(`INSERT OR REPLACE INTO claims (tx_hash, height, node, app_pubkey, chain, session_height, evidence_type, total_proofs, expiration_height) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			hash, height, m.FromAddress.String(), m.SessionHeader.ApplicationPubKey, m.SessionHeader.Chain, m.SessionHeader.SessionBlockHeight, int(m.EvidenceType), m.TotalProofs, m.ExpirationHeight)
+	case pocketTypes.MsgProof:
+		header := m.GetLeaf().SessionHeader()
+		_, err = tx.Exec(`INSERT OR REPLACE INTO proofs (tx_hash, height, node, app_pubkey, chain, session_height, evidence_type) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			hash, height, signer, header.ApplicationPubKey, header.Chain, header.SessionBlockHeight, int(m.EvidenceType))
+	case nodesTypes.MsgStake:
+		err = exportSQLStake(tx, hash, height, nodeActor, sdk.Address(m.PublicKey.Address()), m.PublicKey.RawString(), m.Value, m.Chains, m.ServiceUrl, m.Output)
+	case nodesTypes.LegacyMsgStake:
+		err = exportSQLStake(tx, hash, height, nodeActor, sdk.Address(m.PublicKey.Address()), m.PublicKey.RawString(), m.Value, m.Chains, m.ServiceUrl, nil)
+	case appsTypes.MsgStake:
+		err = exportSQLStake(tx, hash, height, appActor, sdk.Address(m.PubKey.Address()), m.PubKey.RawString(), m.Value, m.Chains, "", nil)
+	case nodesTypes.MsgBeginUnstake:
+		err = exportSQLUnstake(tx, hash, height, nodeActor, m.Address)
+	case nodesTypes.LegacyMsgBeginUnstake:
+		err = exportSQLUnstake(tx, hash, height, nodeActor, m.Address)
+	case appsTypes.MsgBeginUnstake:
+		err = exportSQLUnstake(tx, hash, height, appActor, m.Address)
+	case govTypes.MsgChangeParam:
+		_, err = tx.Exec(`INSERT OR REPLACE INTO params (tx_hash, height, key, value, from_address) VALUES (?, ?, ?, ?, ?)`,
+			hash, height, m.ParamKey, string(m.ParamVal), m.FromAddress.String())
+	}
+	return err
+}
+
+func exportSQLStake(tx *sql.Tx, hash string, height int64, actor string, addr sdk.Address, pubKey string, amount sdk.BigInt, chains []string, serviceURL string, output sdk.Address) error {
+	// the legacy and app stakes have no output address
+	var outputAddr sql.NullString
+	if output != nil {
+		outputAddr = sql.NullString{String: output.String(), Valid: true}
+	}
+	_, err := tx.Exec(`INSERT OR REPLACE INTO stakes (tx_hash, height, actor, address, public_key, amount, chains, service_url, output) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		hash, height, actor, addr.String(), pubKey, amount.String(), strings.Join(chains, ","), serviceURL, outputAddr)
+	return err
+}
+
+func exportSQLUnstake(tx *sql.Tx, hash string, height int64, actor string, addr sdk.Address) error {
+	_, err := tx.Exec(`INSERT OR REPLACE INTO unstakes (tx_hash, height, actor, address) VALUES (?, ?, ?, ?)`, hash, height, actor, addr.String())
+	return err
+}
+
+// the jail events emitted by the nodes module (in the begin/end blocker or by a tx)
+func exportSQLJailings(tx *sql.Tx, height int64, events []abci.Event) error {
+	for _, e := range events {
+		if e.Type != nodesTypes.EventTypeJail {
+			continue
+		}
+		var addr, reason string
+		for _, attr := range e.Attributes {
+			switch string(attr.Key) {
+			case nodesTypes.AttributeKeyAddress:
+				addr = string(attr.Value)
+			case nodesTypes.AttributeKeyReason:
+				reason = string(attr.Value)
+			}
+		}
+		if _, err := tx.Exec(`INSERT OR REPLACE INTO jailings (height, address, reason) VALUES (?, ?, ?)`, height, addr, reason); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+//go:build cgo
+// +build cgo
+
+package app
+
+import (
+	_ "github.com/mattn/go-sqlite3" // sqlite driver for the sql export
+)
+
+// go-sqlite3 is a cgo library, the sql export is not available in the builds without cgo
+func init() {
+	sqliteDriver = "sqlite3"
+}
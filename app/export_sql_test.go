@@ -0,0 +1,91 @@
+package app
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pokt-network/pocket-core/codec"
+	sdk "github.com/pokt-network/pocket-core/types"
+	"github.com/pokt-network/pocket-core/x/nodes"
+	"github.com/stretchr/testify/assert"
+	tmTypes "github.com/tendermint/tendermint/types"
+)
+
+func TestExportSQL(t *testing.T) {
+	if sqliteDriver == "" {
+		t.Skip("the sqlite driver needs cgo")
+	}
+	codec.UpgradeHeight = 2
+	_ = memCodecMod(true)
+	_, kb, cleanup := NewInMemoryTendermintNodeProto(t, oneAppTwoNodeGenesis())
+	time.Sleep(time.Second * 2)
+	cb, err := kb.GetCoinbase()
+	assert.Nil(t, err)
+	kp, err := kb.Create("test")
+	assert.Nil(t, err)
+	_, _, evtChan := subscribeTo(t, tmTypes.EventNewBlock)
+	<-evtChan // Wait for block
+	memCli, stopCli, evtChan := subscribeTo(t, tmTypes.EventTx)
+	tx, err := nodes.Send(memCodec(), memCli, kb, cb.GetAddress(), kp.GetAddress(), "test", sdk.NewInt(1000), true)
+	assert.Nil(t, err)
+	<-evtChan // Wait for tx
+	txRes, err := PCA.QueryTx(tx.TxHash, false)
+	assert.Nil(t, err)
+
+	out := filepath.Join(t.TempDir(), "export.sqlite")
+	src := &remoteExportSource{client: PCA.GetClient()}
+	from, to, err := ExportSQL(src, out, 0, txRes.Height-1, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), from)
+	assert.Equal(t, txRes.Height-1, to)
+	// incremental: continues after the last exported height
+	from, to, err = ExportSQL(src, out, 0, txRes.Height, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, txRes.Height, from)
+	assert.Equal(t, txRes.Height, to)
+	// up to date
+	from, to, err = ExportSQL(src, out, 0, txRes.Height, 1)
+	assert.Nil(t, err)
+	assert.True(t, from > to)
+
+	// the local source reads the same databases as the node, the in memory node keeps them all in one db
+	localOut := filepath.Join(t.TempDir(), "export-local.sqlite")
+	local := &localExportSource{app: PCA, blockStore: PCA.BlockStore(), stateDB: inMemDB}
+	from, to, err = ExportSQL(local, localOut, 0, txRes.Height, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), from)
+	assert.Equal(t, txRes.Height, to)
+	localDB, err := sql.Open(sqliteDriver, localOut)
+	assert.Nil(t, err)
+	defer localDB.Close()
+
+	db, err := sql.Open(sqliteDriver, out)
+	assert.Nil(t, err)
+	defer db.Close()
+	var count int64
+	assert.Nil(t, db.QueryRow(`SELECT COUNT(*) FROM blocks`).Scan(&count))
+	assert.Equal(t, txRes.Height, count)
+	var msgType, signer string
+	assert.Nil(t, db.QueryRow(`SELECT m.type, t.signer FROM messages m JOIN txs t ON t.hash = m.tx_hash WHERE m.tx_hash = ?`, tx.TxHash).Scan(&msgType, &signer))
+	assert.Equal(t, "send", msgType)
+	assert.Equal(t, cb.GetAddress().String(), signer)
+	var balance string
+	assert.Nil(t, db.QueryRow(`SELECT balance FROM balances WHERE height = ? AND address = ?`, txRes.Height, kp.GetAddress().String()).Scan(&balance))
+	assert.Equal(t, "1000", balance)
+	// balances are read at their height: the account did not exist before the send
+	assert.Equal(t, sql.ErrNoRows, db.QueryRow(`SELECT balance FROM balances WHERE height = ? AND address = ?`, txRes.Height-1, kp.GetAddress().String()).Scan(&balance))
+	// the local export matches the remote one
+	for _, q := range []string{`SELECT COUNT(*) FROM blocks`, `SELECT COUNT(*) FROM txs`, `SELECT COUNT(*) FROM messages`, `SELECT COUNT(*) FROM balances`} {
+		var remoteCount, localCount int64
+		assert.Nil(t, db.QueryRow(q).Scan(&remoteCount))
+		assert.Nil(t, localDB.QueryRow(q).Scan(&localCount))
+		assert.Equal(t, remoteCount, localCount, q)
+	}
+	assert.Nil(t, localDB.QueryRow(`SELECT balance FROM balances WHERE height = ? AND address = ?`, txRes.Height, kp.GetAddress().String()).Scan(&balance))
+	assert.Equal(t, "1000", balance)
+
+	cleanup()
+	stopCli()
+}
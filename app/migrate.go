@@ -0,0 +1,216 @@
+package app
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	bam "github.com/pokt-network/pocket-core/baseapp"
+	sdk "github.com/pokt-network/pocket-core/types"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+)
+
+const migrationDirPrefix = "migrate-"
+
+// pocketDB is a database owned by pocket core that respects the configured db backend
+type pocketDB struct {
+	name string
+	dir  string
+}
+
+// the pocket owned databases that are migrated between backends
+func pocketDBs(config sdk.Config) []pocketDB {
+	dataDir := filepath.Join(config.TendermintConfig.RootDir, config.TendermintConfig.DBPath)
+	dbs := []pocketDB{
+		{name: sdk.ApplicationDBName, dir: dataDir},
+		{name: sdk.TransactionIndexerDBName, dir: dataDir},
+		{name: config.PocketConfig.EvidenceDBName, dir: config.PocketConfig.DataDir},
+	}
+	if config.PocketConfig.AccountHistory {
+		dbs = append(dbs, pocketDB{name: sdk.AccountHistoryDBName, dir: dataDir})
+	}
+	return dbs
+}
+
+// MigrateDB copies the application, transaction indexer and evidence databases from one tm-db backend to another.
+// Every copy is made into a temporary directory and verified (key/value digest and, for the application db, the
+// multistore root hash) before the databases are swapped: the originals are moved aside as <name>.db.<from>.bak and
+// the copies renamed into place. A failed swap is rolled back. The config file is updated to the new backend at the end.
+// CONTRACT: the node must not be running
+func MigrateDB(from, to dbm.BackendType) error {
+	if from == to {
+		return fmt.Errorf("source and destination backends are the same: %s", from)
+	}
+	if to == dbm.MemDBBackend || to == dbm.GoLevelMemDBBackend {
+		return fmt.Errorf("cannot migrate to the in memory backend %s", to)
+	}
+	if err := migrateDBs(pocketDBs(GlobalConfig), from, to); err != nil {
+		return err
+	}
+	GlobalConfig.TendermintConfig.DBBackend = string(to)
+	writeConfigFile(GlobalConfig.PocketConfig.DataDir+FS+sdk.ConfigDirName+FS+sdk.ConfigFileName, nil)
+	return nil
+}
+
+// migrateDBs copies and verifies every database into a temporary directory before touching the originals, then swaps
+// them in place, restoring the originals if any swap fails
+func migrateDBs(dbs []pocketDB, from, to dbm.BackendType) error {
+	stagingDirs := make([]string, len(dbs))
+	defer func() {
+		for _, dir := range stagingDirs {
+			if dir != "" {
+				_ = os.RemoveAll(dir)
+			}
+		}
+	}()
+	for i, d := range dbs {
+		dir, err := ioutil.TempDir(d.dir, migrationDirPrefix+string(to)+"-")
+		if err != nil {
+			return fmt.Errorf("unable to create the staging directory of %s: %s", d.name, err.Error())
+		}
+		stagingDirs[i] = dir
+		if err := migrateDB(d, dir, from, to); err != nil {
+			return fmt.Errorf("unable to migrate %s: %s", d.name, err.Error())
+		}
+	}
+	for i, d := range dbs {
+		if err := swapDB(d, stagingDirs[i], from); err != nil {
+			// put back the originals of the databases swapped so far
+			for j := i - 1; j >= 0; j-- {
+				if er := restoreDB(dbs[j], from); er != nil {
+					return fmt.Errorf("%s; unable to restore %s: %s", err.Error(), dbs[j].name, er.Error())
+				}
+			}
+			return err
+		}
+	}
+	// the originals are kept as backups
+	return nil
+}
+
+// swapDB moves the original database aside and the migrated one into place, the original is moved back on failure
+func swapDB(d pocketDB, stagingDir string, from dbm.BackendType) error {
+	dbPath := filepath.Join(d.dir, d.name+".db")
+	if err := os.Rename(dbPath, dbPath+"."+string(from)+".bak"); err != nil {
+		return fmt.Errorf("unable to backup %s: %s", dbPath, err.Error())
+	}
+	if err := os.Rename(filepath.Join(stagingDir, d.name+".db"), dbPath); err != nil {
+		if er := os.Rename(dbPath+"."+string(from)+".bak", dbPath); er != nil {
+			return fmt.Errorf("unable to move migrated %s into place: %s; unable to restore it: %s", d.name, err.Error(), er.Error())
+		}
+		return fmt.Errorf("unable to move migrated %s into place: %s", d.name, err.Error())
+	}
+	return nil
+}
+
+// restoreDB removes the migrated database and moves the original back into place
+func restoreDB(d pocketDB, from dbm.BackendType) error {
+	dbPath := filepath.Join(d.dir, d.name+".db")
+	if err := os.RemoveAll(dbPath); err != nil {
+		return err
+	}
+	return os.Rename(dbPath+"."+string(from)+".bak", dbPath)
+}
+
+func migrateDB(d pocketDB, stagingDir string, from, to dbm.BackendType) error {
+	opts := GlobalConfig.TendermintConfig.LevelDBOptions.ToGoLevelDBOpts()
+	src, err := sdk.NewDB(d.name, d.dir, from, opts)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := sdk.NewDB(d.name, stagingDir, to, opts)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+	if err = copyDB(src, dst); err != nil {
+		return err
+	}
+	srcDigest, err := dbDigest(src)
+	if err != nil {
+		return err
+	}
+	dstDigest, err := dbDigest(dst)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(srcDigest, dstDigest) {
+		return fmt.Errorf("digest mismatch after copy: %X != %X", srcDigest, dstDigest)
+	}
+	if d.name != sdk.ApplicationDBName {
+		return nil
+	}
+	srcRoot, err := appRootHash(src)
+	if err != nil {
+		return err
+	}
+	dstRoot, err := appRootHash(dst)
+	if err != nil {
+		return err
+	}
+	if srcRoot.Version != dstRoot.Version || !bytes.Equal(srcRoot.Hash, dstRoot.Hash) {
+		return fmt.Errorf("application root hash mismatch after copy: %X@%d != %X@%d", srcRoot.Hash, srcRoot.Version, dstRoot.Hash, dstRoot.Version)
+	}
+	return nil
+}
+
+// copy every key/value pair from src to dst in batches
+func copyDB(src, dst dbm.DB) error {
+	const batchSize = 10000
+	it, err := src.Iterator(nil, nil)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	batch := dst.NewBatch()
+	count := 0
+	for ; it.Valid(); it.Next() {
+		batch.Set(it.Key(), it.Value())
+		count++
+		if count%batchSize == 0 {
+			if err = batch.Write(); err != nil {
+				return err
+			}
+			batch.Close()
+			batch = dst.NewBatch()
+		}
+	}
+	defer batch.Close()
+	return batch.WriteSync()
+}
+
+// sha256 over the length prefixed key/value pairs in iteration order
+func dbDigest(db dbm.DB) ([]byte, error) {
+	it, err := db.Iterator(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	h := sha256.New()
+	l := make([]byte, 8)
+	for ; it.Valid(); it.Next() {
+		for _, bz := range [][]byte{it.Key(), it.Value()} {
+			binary.BigEndian.PutUint64(l, uint64(len(bz)))
+			h.Write(l)
+			h.Write(bz)
+		}
+	}
+	return h.Sum(nil), nil
+}
+
+// the latest committed multistore root of an application database
+func appRootHash(db dbm.DB) (sdk.CommitID, error) {
+	a := NewPocketBaseApp(log.NewNopLogger(), db, false, GlobalConfig.PocketConfig.IavlCacheSize)
+	a.MountKVStores(a.Keys)
+	a.MountTransientStores(a.Tkeys)
+	if err := a.LoadLatestVersion(a.Keys[bam.MainStoreKey]); err != nil {
+		return sdk.CommitID{}, err
+	}
+	return a.LastCommitID(), nil
+}
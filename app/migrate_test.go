@@ -0,0 +1,99 @@
+package app
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	bam "github.com/pokt-network/pocket-core/baseapp"
+	sdk "github.com/pokt-network/pocket-core/types"
+	"github.com/pokt-network/pocket-core/x/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestCopyDB(t *testing.T) {
+	src, dst := dbm.NewMemDB(), dbm.NewMemDB()
+	for i := 0; i < 25000; i++ {
+		src.Set([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d", i)))
+	}
+	assert.Nil(t, copyDB(src, dst))
+	srcDigest, err := dbDigest(src)
+	assert.Nil(t, err)
+	dstDigest, err := dbDigest(dst)
+	assert.Nil(t, err)
+	assert.Equal(t, srcDigest, dstDigest)
+	// a single changed value must change the digest
+	dst.Set([]byte("key0"), []byte("changed"))
+	dstDigest, err = dbDigest(dst)
+	assert.Nil(t, err)
+	assert.NotEqual(t, srcDigest, dstDigest)
+}
+
+func TestMigrateDBSameBackend(t *testing.T) {
+	assert.NotNil(t, MigrateDB(dbm.GoLevelDBBackend, dbm.GoLevelDBBackend))
+	assert.NotNil(t, MigrateDB(dbm.GoLevelDBBackend, dbm.MemDBBackend))
+}
+
+func TestMigrateDBs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "migrate")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	// populate an application db with a committed multistore and a plain db
+	appDB, err := sdk.NewDB(sdk.ApplicationDBName, dir, dbm.GoLevelDBBackend, nil)
+	assert.Nil(t, err)
+	a := NewPocketBaseApp(log.NewNopLogger(), appDB, false, GlobalConfig.PocketConfig.IavlCacheSize)
+	a.MountKVStores(a.Keys)
+	a.MountTransientStores(a.Tkeys)
+	assert.Nil(t, a.LoadLatestVersion(a.Keys[bam.MainStoreKey]))
+	for i := 0; i < 100; i++ {
+		a.Store().GetKVStore(a.Keys[auth.StoreKey]).Set([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d", i)))
+	}
+	commitID := a.Store().Commit()
+	appDigest, err := dbDigest(appDB)
+	assert.Nil(t, err)
+	assert.Nil(t, appDB.Close())
+	txDB, err := sdk.NewDB(sdk.TransactionIndexerDBName, dir, dbm.GoLevelDBBackend, nil)
+	assert.Nil(t, err)
+	for i := 0; i < 100; i++ {
+		assert.Nil(t, txDB.Set([]byte(fmt.Sprintf("tx%d", i)), []byte(fmt.Sprintf("result%d", i))))
+	}
+	txDigest, err := dbDigest(txDB)
+	assert.Nil(t, err)
+	assert.Nil(t, txDB.Close())
+	// goleveldb is the only persistent backend built without tags, migrate it onto itself
+	dbs := []pocketDB{{name: sdk.ApplicationDBName, dir: dir}, {name: sdk.TransactionIndexerDBName, dir: dir}}
+	assert.Nil(t, migrateDBs(dbs, dbm.GoLevelDBBackend, dbm.GoLevelDBBackend))
+	// the migrated dbs are in place and the originals are kept as backups
+	for _, d := range dbs {
+		_, err := os.Stat(filepath.Join(dir, d.name+".db."+string(dbm.GoLevelDBBackend)+".bak"))
+		assert.Nil(t, err)
+	}
+	files, err := ioutil.ReadDir(dir)
+	assert.Nil(t, err)
+	for _, f := range files {
+		assert.False(t, strings.HasPrefix(f.Name(), migrationDirPrefix), f.Name())
+	}
+	appDB, err = sdk.NewDB(sdk.ApplicationDBName, dir, dbm.GoLevelDBBackend, nil)
+	assert.Nil(t, err)
+	defer appDB.Close()
+	digest, err := dbDigest(appDB)
+	assert.Nil(t, err)
+	assert.Equal(t, appDigest, digest)
+	root, err := appRootHash(appDB)
+	assert.Nil(t, err)
+	assert.Equal(t, commitID, root)
+	txDB, err = sdk.NewDB(sdk.TransactionIndexerDBName, dir, dbm.GoLevelDBBackend, nil)
+	assert.Nil(t, err)
+	defer txDB.Close()
+	digest, err = dbDigest(txDB)
+	assert.Nil(t, err)
+	assert.Equal(t, txDigest, digest)
+	bz, err := txDB.Get([]byte("tx42"))
+	assert.Nil(t, err)
+	assert.Equal(t, "result42", string(bz))
+}
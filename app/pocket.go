@@ -3,6 +3,8 @@ package app
 import (
 	"encoding/json"
 	"fmt"
+	"time"
+
 	"github.com/tendermint/tendermint/libs/os"
 
 	bam "github.com/pokt-network/pocket-core/baseapp"
@@ -42,6 +44,8 @@ type PocketCoreApp struct {
 	nodesKeeper   nodesKeeper.Keeper
 	govKeeper     govKeeper.Keeper
 	pocketKeeper  pocketKeeper.Keeper
+	// the (optional) balance change log of the accounts
+	accountHistory *sdk.AccountHistory
 	// Module Manager
 	mm *module.Manager
 }
@@ -57,7 +61,7 @@ func NewPocketBaseApp(logger log.Logger, db db.DB, cache bool, iavlCacheSize int
 	// setup the key value store Keys
 	k := sdk.NewKVStoreKeys(bam.MainStoreKey, auth.StoreKey, nodesTypes.StoreKey, appsTypes.StoreKey, gov.StoreKey, pocketTypes.StoreKey)
 	// setup the transient store Keys
-	tkeys := sdk.NewTransientStoreKeys(nodesTypes.TStoreKey, appsTypes.TStoreKey, pocketTypes.TStoreKey, gov.TStoreKey)
+	tkeys := sdk.NewTransientStoreKeys(nodesTypes.TStoreKey, appsTypes.TStoreKey, pocketTypes.TStoreKey, gov.TStoreKey, auth.TStoreKey)
 	// add params Keys too
 	// Create the application
 	return &PocketCoreApp{
@@ -96,7 +100,56 @@ func (app *PocketCoreApp) BeginBlocker(ctx sdk.Ctx, req abci.RequestBeginBlock)
 
 // setups all of the end blockers for each module
 func (app *PocketCoreApp) EndBlocker(ctx sdk.Ctx, req abci.RequestEndBlock) abci.ResponseEndBlock {
-	return app.mm.EndBlock(ctx, req)
+	res := app.mm.EndBlock(ctx, req)
+	// every balance change of the block is known once the end blockers ran, they're stored after the commit
+	if app.accountHistory != nil {
+		if changes := app.accountKeeper.GetBalanceChanges(ctx); len(changes) != 0 {
+			app.accountHistory.Buffer(changes)
+		}
+	}
+	return res
+}
+
+// Commit commits the block and stores its account history, then precomputes the sessions of the node in the background
+// when the block starts a session
+func (app *PocketCoreApp) Commit() abci.ResponseCommit {
+	res := app.BaseApp.Commit()
+	if app.accountHistory != nil {
+		if err := app.accountHistory.Flush(); err != nil {
+			app.Logger().Error(fmt.Sprintf("unable to store the account history of height %d, retrying after the next block: %s", app.LastBlockHeight(), err.Error()))
+		}
+	}
+	if pocketTypes.GlobalPocketConfig.PrefetchSessions {
+		go app.prefetchSessions(app.LastBlockHeight())
+	}
+	return res
+}
+
+// prefetchSessions warms the session cache with the sessions of the node when the height is a session block, unless
+// the node is catching up
+func (app *PocketCoreApp) prefetchSessions(height int64) {
+	ctx, err := app.NewContext(height)
+	if err != nil || !app.pocketKeeper.IsSessionBlock(ctx) || app.pocketKeeper.TmNode == nil {
+		return
+	}
+	status, err := app.pocketKeeper.TmNode.Status()
+	if err != nil || status.SyncInfo.CatchingUp {
+		return
+	}
+	start := time.Now()
+	sessions, er := app.pocketKeeper.PrefetchSessions(ctx)
+	if er != nil {
+		ctx.Logger().Error(fmt.Sprintf("unable to prefetch the sessions of height %d: %s", height, er.Error()))
+		return
+	}
+	elapsed := time.Since(start)
+	pocketTypes.GlobalServiceMetric().SetSessionPrefetch(sessions, float64(elapsed.Milliseconds()))
+	ctx.Logger().Info(fmt.Sprintf("prefetched %d sessions of height %d in %s", sessions, height, elapsed))
+}
+
+// SetAccountHistory sets the store of the account balance change log (nil = no log)
+func (app *PocketCoreApp) SetAccountHistory(history *sdk.AccountHistory) {
+	app.accountHistory = history
 }
 
 // ModuleAccountAddrs returns all the pcInstance's module account addresses.
@@ -47,6 +47,14 @@ func (app PocketCoreApp) QueryTx(hash string, prove bool) (res *core_types.Resul
 	return
 }
 
+// QueryUnconfirmedTxs returns the txs of the mempool, up to the limit (at most 100), with the count and size of all of
+// them
+func (app PocketCoreApp) QueryUnconfirmedTxs(limit int) (res *core_types.ResultUnconfirmedTxs, err error) {
+	tmClient := app.GetClient()
+	defer func() { _ = tmClient.Stop() }()
+	return tmClient.UnconfirmedTxs(limit)
+}
+
 func (app PocketCoreApp) QueryAccountTxs(addr string, page, perPage int, prove bool, sort string) (res *core_types.ResultTxSearch, err error) {
 	tmClient := app.GetClient()
 	defer func() { _ = tmClient.Stop() }()
@@ -81,6 +89,43 @@ func (app PocketCoreApp) QueryBlockTxs(height int64, page, perPage int, prove bo
 	return
 }
 
+// QueryTxSearch searches the transaction indexer using a tendermint style query of AND combined conditions
+// e.g. "tx.message_type='claim' AND tx.chain='0021' AND tx.height>=100 AND tx.height<=200"
+func (app PocketCoreApp) QueryTxSearch(query string, page, perPage int, prove bool, sort string) (res *core_types.ResultTxSearch, err error) {
+	tmClient := app.GetClient()
+	defer func() { _ = tmClient.Stop() }()
+	page, perPage = checkPagination(page, perPage)
+	res, err = tmClient.TxSearch(query, prove, page, perPage, checkSort(sort))
+	return
+}
+
+// AccountHistoryResult is a page of the balance change log of an account
+type AccountHistoryResult struct {
+	Changes    []sdk.AccountChange `json:"changes"`
+	TotalCount int                 `json:"total_count"`
+}
+
+// QueryAccountHistory returns the balance changes of an account (height, signed delta and reason) when the node keeps
+// the account history
+func (app PocketCoreApp) QueryAccountHistory(addr string, page, perPage int, sort string) (res *AccountHistoryResult, err error) {
+	if app.accountHistory == nil {
+		return nil, fmt.Errorf("the account history is disabled on this node, see account_history in the config")
+	}
+	a, err := sdk.AddressFromHex(addr)
+	if err != nil {
+		return nil, err
+	}
+	page, perPage = checkPagination(page, perPage)
+	changes, total, err := app.accountHistory.Get(a, page, perPage, checkSort(sort))
+	if err != nil {
+		return nil, err
+	}
+	if changes == nil {
+		changes = []sdk.AccountChange{}
+	}
+	return &AccountHistoryResult{Changes: changes, TotalCount: total}, nil
+}
+
 func (app PocketCoreApp) QueryAllBlockTxs(height int64, page, perPage int) (res *core_types.ResultTxSearch, err error) {
 	res = &core_types.ResultTxSearch{}
 	tmClient := app.GetClient()
@@ -196,6 +241,10 @@ func (app PocketCoreApp) QueryHostedChains() (res map[string]pocketTypes.HostedB
 	return app.pocketKeeper.GetHostedBlockchains().M, nil
 }
 
+func (app PocketCoreApp) HostedChains() *pocketTypes.HostedBlockchains {
+	return app.pocketKeeper.GetHostedBlockchains()
+}
+
 func (app PocketCoreApp) SetHostedChains(req map[string]pocketTypes.HostedBlockchain) (res map[string]pocketTypes.HostedBlockchain, err error) {
 	return app.pocketKeeper.SetHostedBlockchains(req).M, nil
 }
@@ -510,6 +559,14 @@ func (app PocketCoreApp) HandleDispatch(header pocketTypes.SessionHeader) (res *
 	return app.pocketKeeper.HandleDispatch(ctx, header)
 }
 
+func (app PocketCoreApp) HandleBatchDispatch(appPubKey string, chains []string, sessionBlockHeight int64) (res *pocketTypes.BatchDispatchResponse, err error) {
+	ctx, err := app.NewContext(app.LastBlockHeight())
+	if err != nil {
+		return nil, err
+	}
+	return app.pocketKeeper.HandleBatchDispatch(ctx, appPubKey, chains, sessionBlockHeight)
+}
+
 func (app PocketCoreApp) HandleRelay(r pocketTypes.Relay) (res *pocketTypes.RelayResponse, dispatch *pocketTypes.DispatchResponse, err error) {
 	ctx, err := app.NewContext(app.LastBlockHeight())
 	if err != nil {
@@ -526,7 +583,10 @@ func (app PocketCoreApp) HandleRelay(r pocketTypes.Relay) (res *pocketTypes.Rela
 	res, err = app.pocketKeeper.HandleRelay(ctx, r)
 	var err1 error
 	if err != nil && pocketTypes.ErrorWarrantsDispatch(err) {
-		dispatch, err1 = app.HandleDispatch(r.Proof.SessionHeader())
+		// dispatch the latest session
+		header := r.Proof.SessionHeader()
+		header.SessionBlockHeight = 0
+		dispatch, err1 = app.HandleDispatch(header)
 		if err1 != nil {
 			return
 		}
@@ -0,0 +1,49 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/pokt-network/pocket-core/crypto"
+	"github.com/pokt-network/pocket-core/crypto/signer"
+	"github.com/tendermint/tendermint/privval"
+)
+
+// RunRemoteSigner runs the reference signer daemon with the private validator key (and sign state) of the datadir.
+// The relay responses and claim / proof txs of the allowed types are signed on the unix socket at socketAddr, and
+// when consensus is allowed the daemon dials the privval listener of the node at nodeAddr (priv_validator_laddr)
+func RunRemoteSigner(socketAddr string, allowed []string, nodeAddr, chainID string) error {
+	datadir := GlobalConfig.PocketConfig.DataDir
+	pv := privval.LoadFilePV(datadir+FS+GlobalConfig.TendermintConfig.PrivValidatorKey, datadir+FS+GlobalConfig.TendermintConfig.PrivValidatorState)
+	key, err := crypto.PrivKeyToPrivateKey(pv.Key.PrivKey)
+	if err != nil {
+		return err
+	}
+	logger := InitLogger().With("module", "signer")
+	var socketTypes []string
+	for _, msgType := range allowed {
+		if msgType != signer.MsgTypeConsensus {
+			socketTypes = append(socketTypes, msgType)
+			continue
+		}
+		if nodeAddr == "" {
+			return fmt.Errorf("the node address of the privval listener is needed to sign consensus messages")
+		}
+		consensus, err := signer.StartConsensusSigner(nodeAddr, chainID, pv, logger)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = consensus.Stop() }()
+		logger.Info("signing consensus messages for the node at " + nodeAddr)
+	}
+	server, err := signer.NewServer(key, socketTypes, logger)
+	if err != nil {
+		return err
+	}
+	l, err := signer.Listen(socketAddr)
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+	logger.Info(fmt.Sprintf("signing %v with the key %s on %s", socketTypes, key.PublicKey().RawString(), socketAddr))
+	return server.Serve(l)
+}
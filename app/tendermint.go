@@ -1,6 +1,7 @@
 package app
 
 import (
+	"fmt"
 	"github.com/pokt-network/pocket-core/codec"
 	"io"
 	"os"
@@ -13,6 +14,7 @@ import (
 	"github.com/tendermint/tendermint/p2p"
 	pvm "github.com/tendermint/tendermint/privval"
 	"github.com/tendermint/tendermint/proxy"
+	tmtypes "github.com/tendermint/tendermint/types"
 	dbm "github.com/tendermint/tm-db"
 )
 
@@ -30,6 +32,15 @@ func NewClient(c config, creator AppCreator) (*node.Node, *PocketCoreApp, error)
 		return nil, nil, err
 	}
 	transactionIndexer := sdk.NewTransactionIndexer(txDB)
+	// setup the (optional) account history
+	var accountHistory *sdk.AccountHistory
+	if GlobalConfig.PocketConfig.AccountHistory {
+		historyDB, err := OpenAccountHistoryDB(GlobalConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+		accountHistory = sdk.NewAccountHistory(historyDB)
+	}
 	// open the tracewriter
 	traceWriter, err := openTraceWriter(c.TraceWriter)
 	if err != nil {
@@ -42,12 +53,22 @@ func NewClient(c config, creator AppCreator) (*node.Node, *PocketCoreApp, error)
 	}
 	// upgrade the privVal file
 	app := creator(c.Logger, appDB, traceWriter)
+	app.SetAccountHistory(accountHistory)
 	PCA = app
+	// consensus is signed by a remote signer over the privval socket when it listens for one
+	var privValidator tmtypes.PrivValidator
+	if c.TmConfig.PrivValidatorListenAddr == "" {
+		// the key of a remote signer is not on the node, a local file pv would sign the consensus with another key
+		if GlobalConfig.PocketConfig.RemoteSigner != "" {
+			return nil, nil, fmt.Errorf("remote_signer is set without priv_validator_laddr: set it and allow the consensus msgs in the remote signer")
+		}
+		privValidator = pvm.LoadOrGenFilePV(c.TmConfig.PrivValidatorKeyFile(), c.TmConfig.PrivValidatorStateFile())
+	}
 	// create & start tendermint node
 	tmNode, err := node.NewNode(app,
 		c.TmConfig,
 		codec.GetCodecUpgradeHeight(),
-		pvm.LoadOrGenFilePV(c.TmConfig.PrivValidatorKeyFile(), c.TmConfig.PrivValidatorStateFile()),
+		privValidator,
 		nodeKey,
 		proxy.NewLocalClientCreator(app),
 		transactionIndexer,
@@ -65,12 +86,17 @@ func NewClient(c config, creator AppCreator) (*node.Node, *PocketCoreApp, error)
 
 func OpenApplicationDB(config sdk.Config) (dbm.DB, error) {
 	dataDir := filepath.Join(config.TendermintConfig.RootDir, GlobalConfig.TendermintConfig.DBPath)
-	return sdk.NewLevelDB(sdk.ApplicationDBName, dataDir, config.TendermintConfig.LevelDBOptions.ToGoLevelDBOpts())
+	return sdk.NewDB(sdk.ApplicationDBName, dataDir, dbm.BackendType(config.TendermintConfig.DBBackend), config.TendermintConfig.LevelDBOptions.ToGoLevelDBOpts())
 }
 
 func OpenTxIndexerDB(config sdk.Config) (dbm.DB, error) {
 	dataDir := filepath.Join(config.TendermintConfig.RootDir, GlobalConfig.TendermintConfig.DBPath)
-	return sdk.NewLevelDB(sdk.TransactionIndexerDBName, dataDir, config.TendermintConfig.LevelDBOptions.ToGoLevelDBOpts())
+	return sdk.NewDB(sdk.TransactionIndexerDBName, dataDir, dbm.BackendType(config.TendermintConfig.DBBackend), config.TendermintConfig.LevelDBOptions.ToGoLevelDBOpts())
+}
+
+func OpenAccountHistoryDB(config sdk.Config) (dbm.DB, error) {
+	dataDir := filepath.Join(config.TendermintConfig.RootDir, GlobalConfig.TendermintConfig.DBPath)
+	return sdk.NewDB(sdk.AccountHistoryDBName, dataDir, dbm.BackendType(config.TendermintConfig.DBBackend), config.TendermintConfig.LevelDBOptions.ToGoLevelDBOpts())
 }
 
 func openTraceWriter(traceWriterFile string) (w io.Writer, err error) {
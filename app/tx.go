@@ -1,12 +1,21 @@
 package app
 
 import (
+	"sort"
+
 	sdk "github.com/pokt-network/pocket-core/types"
+	"github.com/pokt-network/pocket-core/x/auth"
 	"github.com/pokt-network/pocket-core/x/auth/util"
 )
 
 // SendRawTx - Deliver tx bytes to node
 func (app PocketCoreApp) SendRawTx(fromAddr string, txBytes []byte) (sdk.TxResponse, error) {
+	return app.BroadcastRawTx(fromAddr, txBytes, util.BroadcastSync)
+}
+
+// BroadcastRawTx - Deliver tx bytes to node, returning right away (async), after the check of the mempool (sync) or
+// after the tx is included in a block (commit)
+func (app PocketCoreApp) BroadcastRawTx(fromAddr string, txBytes []byte, mode util.BroadcastType) (sdk.TxResponse, error) {
 	fa, err := sdk.AddressFromHex(fromAddr)
 	if err != nil {
 		return sdk.TxResponse{}, err
@@ -18,6 +27,64 @@ func (app PocketCoreApp) SendRawTx(fromAddr string, txBytes []byte) (sdk.TxRespo
 		Client:      tmClient,
 		FromAddress: fa,
 	}
-	cliCtx.BroadcastMode = util.BroadcastSync
+	cliCtx.BroadcastMode = mode
 	return cliCtx.BroadcastTx(txBytes)
 }
+
+// TxSimulation is the outcome of a tx run on the current state without broadcasting it
+type TxSimulation struct {
+	Tx             auth.StdTx
+	Result         sdk.Result
+	Fee            sdk.Coins       // the fee of the tx, charged in full
+	MinFee         sdk.BigInt      // the minimum fee of the message of the tx
+	BalanceChanges []BalanceChange // the changed accounts of the signers, the recipient and the fee collector, by address
+}
+
+// BalanceChange is the balance of an account before and after a simulated tx
+type BalanceChange struct {
+	Address sdk.Address `json:"address"`
+	Before  sdk.BigInt  `json:"before"`
+	After   sdk.BigInt  `json:"after"`
+	Delta   sdk.BigInt  `json:"delta"`
+}
+
+// SimulateRawTx runs the tx bytes on a copy of the state, with the validation of the ante handler (but the signature)
+// and the handler of its message, and returns their outcome. Nothing is broadcast or written
+func (app PocketCoreApp) SimulateRawTx(txBytes []byte) (res TxSimulation, err error) {
+	tx, err := UnmarshalTx(txBytes, app.LastBlockHeight())
+	if err != nil {
+		return
+	}
+	result, signer, before, after := app.BaseApp.SimulateTx(txBytes, tx)
+	res = TxSimulation{
+		Tx:     tx,
+		Result: result,
+		Fee:    tx.GetFee(),
+		MinFee: app.accountKeeper.GetParams(before).FeeMultiplier.GetFee(tx.GetMsg()),
+	}
+	addrs := make(map[string]sdk.Address)
+	feeCollector := app.accountKeeper.GetModuleAddress(auth.FeeCollectorName)
+	addrs[feeCollector.String()] = feeCollector
+	for _, a := range tx.GetSigners() {
+		addrs[a.String()] = a
+	}
+	if a := tx.GetMsg().GetRecipient(); a != nil {
+		addrs[a.String()] = a
+	}
+	if signer != nil {
+		a := sdk.Address(signer.Address())
+		addrs[a.String()] = a
+	}
+	for _, a := range addrs {
+		b := app.accountKeeper.GetCoins(before, a).AmountOf(sdk.DefaultStakeDenom)
+		f := app.accountKeeper.GetCoins(after, a).AmountOf(sdk.DefaultStakeDenom)
+		if b.Equal(f) {
+			continue
+		}
+		res.BalanceChanges = append(res.BalanceChanges, BalanceChange{Address: a, Before: b, After: f, Delta: f.Sub(b)})
+	}
+	sort.Slice(res.BalanceChanges, func(i, j int) bool {
+		return res.BalanceChanges[i].Address.String() < res.BalanceChanges[j].Address.String()
+	})
+	return res, nil
+}
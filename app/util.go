@@ -10,9 +10,12 @@ import (
 
 	"github.com/pokt-network/pocket-core/crypto"
 	sdk "github.com/pokt-network/pocket-core/types"
+	appsTypes "github.com/pokt-network/pocket-core/x/apps/types"
 	"github.com/pokt-network/pocket-core/x/auth"
 	"github.com/pokt-network/pocket-core/x/auth/types"
+	nodesTypes "github.com/pokt-network/pocket-core/x/nodes/types"
 	pocketKeeper "github.com/pokt-network/pocket-core/x/pocketcore/keeper"
+	pocketTypes "github.com/pokt-network/pocket-core/x/pocketcore/types"
 )
 
 func GenerateAAT(appPubKey, clientPubKey string, key crypto.PrivateKey) (aatjson []byte, err error) {
@@ -120,3 +123,38 @@ func UnmarshalTx(txBytes []byte, height int64) (types.StdTx, error) {
 	}
 	return tx.(auth.StdTx), nil
 }
+
+// TxMsgChains returns the relay chains of the msg of the tx bytes: the chains of a stake and the chain of the session of
+// a claim or a proof. The codec upgrade height isn't known offline, so the proto encoding is tried when the encoding of
+// the height fails
+func TxMsgChains(txBytes []byte, height int64) []string {
+	var tx auth.StdTx
+	if err := Codec().UnmarshalBinaryLengthPrefixed(txBytes, &tx, height); err != nil {
+		if err = Codec().UnmarshalBinaryLengthPrefixed(txBytes, &tx, -1); err != nil {
+			return nil
+		}
+	}
+	msg := tx.Msg
+	if msg == nil {
+		return nil
+	}
+	// convert to value for switch consistency
+	if reflect.ValueOf(msg).Kind() == reflect.Ptr {
+		msg = reflect.Indirect(reflect.ValueOf(msg)).Interface().(sdk.Msg)
+	}
+	switch msg := msg.(type) {
+	case nodesTypes.MsgStake:
+		return msg.Chains
+	case nodesTypes.LegacyMsgStake:
+		return msg.Chains
+	case appsTypes.MsgStake:
+		return msg.Chains
+	case pocketTypes.MsgClaim:
+		return []string{msg.SessionHeader.Chain}
+	case pocketTypes.MsgProof:
+		if msg.Leaf != nil {
+			return []string{msg.Leaf.SessionHeader().Chain}
+		}
+	}
+	return nil
+}
@@ -7,9 +7,11 @@ import (
 
 	"github.com/pokt-network/pocket-core/crypto"
 	sdk "github.com/pokt-network/pocket-core/types"
+	authTypes "github.com/pokt-network/pocket-core/x/auth/types"
 	"github.com/pokt-network/pocket-core/x/gov"
 	"github.com/pokt-network/pocket-core/x/nodes"
 	"github.com/pokt-network/pocket-core/x/nodes/types"
+	pocketTypes "github.com/pokt-network/pocket-core/x/pocketcore/types"
 	"github.com/stretchr/testify/assert"
 	tmTypes "github.com/tendermint/tendermint/types"
 )
@@ -54,3 +56,33 @@ func TestBuildSignMultisig(t *testing.T) {
 	cleanup()
 	stopCli()
 }
+
+func TestTxMsgChains(t *testing.T) {
+	claim := &pocketTypes.MsgClaim{SessionHeader: pocketTypes.SessionHeader{Chain: "0001"}}
+	pk := crypto.GenerateEd25519PrivKey().PublicKey()
+	signature := authTypes.StdSignature{PublicKey: pk}
+	tests := []struct {
+		name   string
+		msg    sdk.ProtoMsg
+		height int64
+		chains []string
+	}{
+		{"amino claim", claim, 0, []string{"0001"}},
+		{"proto claim", claim, -1, []string{"0001"}},
+		{"amino legacy stake", &types.LegacyMsgStake{PublicKey: pk, Chains: []string{"0001", "0002"}}, 0, []string{"0001", "0002"}},
+		{"proto stake", &types.MsgStake{PublicKey: pk, Chains: []string{"0001", "0002"}}, -1, []string{"0001", "0002"}},
+		{"no chains", &types.MsgSend{}, -1, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txBytes, err := Codec().MarshalBinaryLengthPrefixed(&authTypes.StdTx{Msg: tt.msg, Signature: signature}, tt.height)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.chains, TxMsgChains(txBytes, tt.height))
+		})
+	}
+	// the codec upgrade height isn't known offline: a proto tx of an amino height
+	txBytes, err := Codec().MarshalBinaryLengthPrefixed(&authTypes.StdTx{Msg: claim, Signature: signature}, -1)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"0001"}, TxMsgChains(txBytes, 0))
+	assert.Nil(t, TxMsgChains([]byte("not a tx"), 0))
+}
@@ -24,6 +24,7 @@ import (
 	"runtime/debug"
 	"sort"
 	"strings"
+	"sync"
 	"syscall"
 
 	"errors"
@@ -99,6 +100,7 @@ type BaseApp struct {
 	// deliverState is set in InitChain and BeginBlock and cleared on Commit.
 	// See methods setCheckState and setDeliverState.
 	checkState   *state          // for CheckTx
+	checkMtx     sync.RWMutex    // guards the reset of checkState, for the reads outside of the abci calls
 	deliverState *state          // for DeliverTx
 	voteInfos    []abci.VoteInfo // absent validators from begin block
 
@@ -350,9 +352,12 @@ func (app *BaseApp) IsSealed() bool { return app.sealed }
 func (app *BaseApp) setCheckState(header abci.Header) { // todo <- modified here
 	ms := app.cms
 	context := sdk.NewContext(ms, header, true, app.logger).WithAppVersion(app.appVersion).WithBlockStore(app.blockstore)
+	app.checkMtx.Lock()
+	defer app.checkMtx.Unlock()
 	app.checkState = &state{
-		ms:  ms.CacheMultiStore(),
-		ctx: context,
+		ms:      ms.CacheMultiStore(),
+		ctx:     context,
+		version: ms.LastCommitID().Version,
 	}
 }
 
@@ -848,8 +853,8 @@ func validateBasicTxMsgs(msg sdk.Msg) sdk.Error {
 }
 
 // retrieve the context for the tx w/ txBytes and other memoized values.
-func (app *BaseApp) getContextForTx(mode runTxMode, txBytes []byte) (ctx sdk.Ctx) {
-	ctx = app.getState(mode).ctx.
+func (app *BaseApp) getContextForTx(base sdk.Ctx, mode runTxMode, txBytes []byte) (ctx sdk.Ctx) {
+	ctx = base.
 		WithTxBytes(txBytes).
 		WithVoteInfos(app.voteInfos).
 		WithConsensusParams(app.consensusParams)
@@ -913,7 +918,10 @@ func (app *BaseApp) runMsg(ctx sdk.Ctx, msg sdk.Msg, mode runTxMode, signer cryp
 		GasUsed:   0,
 		Events:    events,
 	}
-
+	// a simulation reports why the message failed, whatever the abci logging
+	if mode == runTxModeSimulate && result.Log == "" {
+		result.Log = msgResult.Log
+	}
 	return result
 }
 
@@ -968,17 +976,24 @@ func (app *BaseApp) cacheTxContext(ctx sdk.Ctx, txBytes []byte) (
 // further details on transaction execution, reference the BaseApp SDK
 // documentation.
 func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk.Result, signer crypto.PublicKey) {
+	result, signer, _ = app.runTxWithContext(app.getState(mode).ctx, mode, txBytes, tx)
+	return
+}
+
+// runTxWithContext is runTx on the base context, and also returns the context the messages ran with. In simulate
+// mode, the state of the context is the discarded copy of the base state after the tx
+func (app *BaseApp) runTxWithContext(base sdk.Ctx, mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk.Result, signer crypto.PublicKey, ctx sdk.Ctx) {
 	// NOTE: GasWanted should be returned by the AnteHandler. GasUsed is
 	// determined by the GasMeter. We need access to the context to get the gas
 	// meter so we initialize upfront.
 	var gasWanted uint64
 
-	ctx := app.getContextForTx(mode, txBytes)
+	ctx = app.getContextForTx(base, mode, txBytes)
 	ms := ctx.MultiStore()
 
 	// only run the tx if there is block gas remaining
 	if mode == runTxModeDeliver && ctx.BlockGasMeter().IsOutOfGas() {
-		return sdk.ErrOutOfGas("no block gas left to run tx").Result(), nil
+		return sdk.ErrOutOfGas("no block gas left to run tx").Result(), nil, ctx
 	}
 
 	var startingGas uint64
@@ -1026,7 +1041,7 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 	}()
 	var msgs = tx.GetMsg()
 	if err := validateBasicTxMsgs(msgs); err != nil {
-		return err.Result(), nil
+		return err.Result(), nil, ctx
 	}
 
 	if app.anteHandler != nil {
@@ -1057,14 +1072,22 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 		gasWanted = result.GasWanted
 
 		if abort {
-			return result, signer
+			return result, signer, ctx
 		}
 
-		if mode == runTxModeDeliver {
+		// the simulation state is a discarded copy, the messages run on it after the fees are deducted
+		if mode == runTxModeDeliver || mode == runTxModeSimulate {
 			msCache.Write()
 		}
 	}
 
+	// a simulation runs the messages on its copy of the check state, they must not reach the committed stores
+	if mode == runTxModeSimulate {
+		result = app.runMsg(ctx, msgs, mode, signer)
+		result.GasWanted = gasWanted
+		return result, signer, ctx
+	}
+
 	// Create a new context based off of the existing context with a cache wrapped
 	// multi-store in case message processing fails.
 	runMsgCtx, newMS := app.txContext(ctx, txBytes) // todo edit here!!!
@@ -1073,7 +1096,7 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 
 	// Safety check: don't write the cache state unless we're in DeliverTx.
 	if mode != runTxModeDeliver {
-		return result, signer
+		return result, signer, runMsgCtx
 	}
 
 	// only update state if all messages pass
@@ -1081,7 +1104,7 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 		newMS.CacheMultiStore().Write() // todo edit here!!!
 	}
 
-	return result, signer
+	return result, signer, runMsgCtx
 }
 
 // EndBlock implements the ABCI interface.
@@ -1172,8 +1195,9 @@ func (app *BaseApp) halt() {
 // State
 
 type state struct {
-	ms  sdk.CacheMultiStore
-	ctx sdk.Context
+	ms      sdk.CacheMultiStore
+	ctx     sdk.Context
+	version int64 // the committed version of the state
 }
 
 func (st *state) CacheMultiStore() sdk.CacheMultiStore {
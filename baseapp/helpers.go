@@ -3,6 +3,8 @@ package baseapp
 import (
 	"regexp"
 
+	"github.com/pokt-network/pocket-core/crypto"
+	rootMulti "github.com/pokt-network/pocket-core/store/rootmulti"
 	abci "github.com/tendermint/tendermint/abci/types"
 
 	sdk "github.com/pokt-network/pocket-core/types"
@@ -22,6 +24,22 @@ func (app *BaseApp) Simulate(txBytes []byte, tx sdk.Tx) (result sdk.Result) {
 	return
 }
 
+// SimulateTx runs the tx on a discarded copy of a snapshot of the last committed state and returns its result, with
+// the contexts of the snapshot before and after the tx. The signature of the tx is not verified
+func (app *BaseApp) SimulateTx(txBytes []byte, tx sdk.Tx) (result sdk.Result, signer crypto.PublicKey, before, after sdk.Ctx) {
+	app.checkMtx.RLock()
+	check := app.checkState
+	app.checkMtx.RUnlock()
+	before = check.ctx
+	ms, err := app.cms.(*rootMulti.Store).CacheMultiStoreWithVersion(check.version)
+	if err != nil {
+		return sdk.ErrInternal(err.Error()).Result(), nil, before, before
+	}
+	before = before.WithMultiStore(ms)
+	result, signer, after = app.runTxWithContext(before, runTxModeSimulate, txBytes, tx)
+	return result, signer, before, after
+}
+
 // nolint
 func (app *BaseApp) Deliver(tx sdk.Tx) (result sdk.Result) {
 	result, _ = app.runTx(runTxModeDeliver, nil, tx)
@@ -0,0 +1,143 @@
+package keys
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/pokt-network/pocket-core/types"
+	dbm "github.com/tendermint/tm-db"
+)
+
+const (
+	BackendLevelDB = "leveldb" // a leveldb database in the data directory (default)
+	BackendDir     = "dir"     // one armored json file per key in the keystore directory
+)
+
+// ErrReadOnly is returned when writing to a read-only keybase
+var ErrReadOnly = errors.New("the keybase is read-only")
+
+// Backend stores the (encrypted) key pairs of a keybase by address
+type Backend interface {
+	// List returns every key pair ordered by address
+	List() ([]KeyPair, error)
+	// Get returns the key pair of the address or an error when it is not found
+	Get(address types.Address) (KeyPair, error)
+	// Set stores the key pair, replacing the one of the same address
+	Set(kp KeyPair) error
+	// Insert stores the key pair unless one of the same address is stored, atomically
+	Insert(kp KeyPair) error
+	// Delete removes the key pair of the address
+	Delete(address types.Address) error
+	Close() error
+}
+
+var _ Backend = dbBackend{}
+
+// dbBackend stores the key pairs in a database keyed by address
+type dbBackend struct {
+	db dbm.DB
+	l  *sync.Mutex // held by the read-modify-writes
+}
+
+func newDBBackend(db dbm.DB) Backend {
+	return dbBackend{db: db, l: &sync.Mutex{}}
+}
+
+func (b dbBackend) List() ([]KeyPair, error) {
+	var res []KeyPair
+	iter, err := b.db.Iterator(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		kp, err := readKeyPair(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, kp)
+	}
+	return res, nil
+}
+
+func (b dbBackend) Get(address types.Address) (KeyPair, error) {
+	ik, _ := b.db.Get(addrKey(address))
+	if len(ik) == 0 {
+		return KeyPair{}, fmt.Errorf("key with address %s not found", address)
+	}
+	return readKeyPair(ik)
+}
+
+func (b dbBackend) Set(kp KeyPair) error {
+	return b.db.SetSync(addrKey(kp.GetAddress()), writeKeyPair(kp))
+}
+
+func (b dbBackend) Insert(kp KeyPair) error {
+	b.l.Lock()
+	defer b.l.Unlock()
+	exists, err := b.db.Has(addrKey(kp.GetAddress()))
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errKeyExists(kp.GetAddress())
+	}
+	return b.Set(kp)
+}
+
+func (b dbBackend) Delete(address types.Address) error {
+	return b.db.DeleteSync(addrKey(address))
+}
+
+func (b dbBackend) Close() error {
+	return b.db.Close()
+}
+
+// readOnlyBackend rejects every write to the backend, for signing only processes
+type readOnlyBackend struct {
+	Backend
+}
+
+func (readOnlyBackend) Set(KeyPair) error {
+	return ErrReadOnly
+}
+
+func (readOnlyBackend) Insert(KeyPair) error {
+	return ErrReadOnly
+}
+
+func (readOnlyBackend) Delete(types.Address) error {
+	return ErrReadOnly
+}
+
+func errKeyExists(address types.Address) error {
+	return errors.New("Cannot overwrite key with address: " + address.String())
+}
+
+// Migrate copies every key pair of from that is not in to, still encrypted, and returns the number of keys copied.
+// A key of from that is in to with a different armor is an error
+func Migrate(from, to Keybase) (copied int, err error) {
+	kps, err := from.List()
+	if err != nil {
+		return 0, err
+	}
+	for _, kp := range kps {
+		existing, err := to.Get(kp.GetAddress())
+		if err == nil {
+			if existing.PrivKeyArmor != kp.PrivKeyArmor {
+				return copied, fmt.Errorf("a different key with address %s is already in the destination", kp.GetAddress())
+			}
+			continue
+		}
+		if err = to.ImportKeyPair(kp); err != nil {
+			return copied, err
+		}
+		// verify the copy
+		if existing, err = to.Get(kp.GetAddress()); err != nil || existing.PrivKeyArmor != kp.PrivKeyArmor || !existing.PublicKey.Equals(kp.PublicKey) {
+			return copied, fmt.Errorf("the key with address %s was not copied correctly", kp.GetAddress())
+		}
+		copied++
+	}
+	return copied, nil
+}
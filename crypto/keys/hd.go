@@ -0,0 +1,95 @@
+package keys
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/go-bip39"
+	"github.com/pokt-network/pocket-core/crypto"
+)
+
+const (
+	// CoinType is the SLIP-44 coin type of POKT
+	CoinType = 635
+	// MnemonicEntropySize is the entropy of new mnemonics in bits (24 words)
+	MnemonicEntropySize = 256
+
+	hardenedOffset = uint32(0x80000000)
+	slip10Curve    = "ed25519 seed"
+)
+
+// HDPath returns the SLIP-10 derivation path of the account index: m/44'/635'/0'/0'/<index>'
+func HDPath(index uint32) string {
+	return fmt.Sprintf("m/44'/%d'/0'/0'/%d'", CoinType, index)
+}
+
+// NewMnemonic returns a new random BIP-39 mnemonic
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(MnemonicEntropySize)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// DeriveKey derives the ed25519 private key of the SLIP-10 path (every level hardened) from the BIP-39 mnemonic
+func DeriveKey(mnemonic, path string) (crypto.PrivateKey, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(normalizeMnemonic(mnemonic), "")
+	if err != nil {
+		return nil, fmt.Errorf("invalid mnemonic: %s", err.Error())
+	}
+	return deriveKeyFromSeed(seed, path)
+}
+
+func deriveKeyFromSeed(seed []byte, path string) (crypto.PrivateKey, error) {
+	indexes, err := parseHDPath(path)
+	if err != nil {
+		return nil, err
+	}
+	key, chainCode := slip10Hash([]byte(slip10Curve), seed)
+	for _, i := range indexes {
+		data := make([]byte, 1+len(key)+4)
+		copy(data[1:], key)
+		binary.BigEndian.PutUint32(data[1+len(key):], i)
+		key, chainCode = slip10Hash(chainCode, data)
+	}
+	var pk crypto.Ed25519PrivateKey
+	copy(pk[:], ed25519.NewKeyFromSeed(key))
+	return pk, nil
+}
+
+func slip10Hash(key, data []byte) (il, ir []byte) {
+	h := hmac.New(sha512.New, key)
+	_, _ = h.Write(data)
+	sum := h.Sum(nil)
+	return sum[:32], sum[32:]
+}
+
+// parseHDPath returns the (hardened) indexes of the path; ed25519 only supports hardened derivation
+func parseHDPath(path string) ([]uint32, error) {
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 || parts[0] != "m" {
+		return nil, fmt.Errorf("invalid derivation path %q, expected m/<index>'/...", path)
+	}
+	indexes := make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		if !strings.HasSuffix(part, "'") {
+			return nil, fmt.Errorf("invalid derivation path %q: ed25519 only supports hardened indexes (<index>')", path)
+		}
+		i, err := strconv.ParseUint(strings.TrimSuffix(part, "'"), 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid derivation path %q: %s", path, err.Error())
+		}
+		indexes = append(indexes, uint32(i)+hardenedOffset)
+	}
+	return indexes, nil
+}
+
+func normalizeMnemonic(mnemonic string) string {
+	return strings.Join(strings.Fields(mnemonic), " ")
+}
@@ -0,0 +1,55 @@
+package keys
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// SLIP-0010 ed25519 test vector 1
+func TestDeriveKeyFromSeed(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	tests := []struct {
+		path string
+		key  string
+	}{
+		{"m/0'", "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3"},
+		{"m/0'/1'", "b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2"},
+		{"m/0'/1'/2'/2'/1000000000'", "8f94d394a8e8fd6b1bc2f3f49f5c47e385281d5c17e65324b0f62483e37e8793"},
+	}
+	for _, tt := range tests {
+		pk, err := deriveKeyFromSeed(seed, tt.path)
+		require.NoError(t, err, tt.path)
+		assert.Equal(t, tt.key, hex.EncodeToString(pk.RawBytes()[:32]), tt.path)
+	}
+	_, err := deriveKeyFromSeed(seed, "m/0'/1")
+	assert.Error(t, err)
+	_, err = deriveKeyFromSeed(seed, "0'/1'")
+	assert.Error(t, err)
+}
+
+func TestKeybase_Recover(t *testing.T) {
+	kb := NewInMemory()
+	kp, mnemonic, err := kb.CreateMnemonic("1234")
+	require.NoError(t, err)
+	assert.Equal(t, HDPath(0), kp.HDPath)
+	// the same mnemonic derives the same keys on another keybase
+	other := NewInMemory()
+	recovered, err := other.Recover(mnemonic, "5678", 0)
+	require.NoError(t, err)
+	assert.Equal(t, kp.GetAddress(), recovered.GetAddress())
+	second, err := other.Recover("  "+mnemonic+"\n", "5678", 1)
+	require.NoError(t, err)
+	assert.NotEqual(t, kp.GetAddress(), second.GetAddress())
+	assert.Equal(t, "m/44'/635'/0'/0'/1'", second.HDPath)
+	// the path is kept with the key
+	stored, err := other.Get(second.GetAddress())
+	require.NoError(t, err)
+	assert.Equal(t, second.HDPath, stored.HDPath)
+	_, err = other.Recover(mnemonic, "5678", 1)
+	assert.Error(t, err)
+	_, err = other.Recover("not a mnemonic", "5678", 0)
+	assert.Error(t, err)
+}
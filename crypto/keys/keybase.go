@@ -14,23 +14,28 @@ import (
 
 var _ Keybase = &dbKeybase{}
 
-// dbKeybase combines encryption and storage implementation to provide
+// dbKeybase combines encryption and a storage backend to provide
 // a full-featured key manager
 type dbKeybase struct {
-	db       dbm.DB
+	backend  Backend
 	coinbase KeyPair
 }
 
 // newDbKeybase creates a new keybase instance using the passed DB for reading and writing keys.
 func newDbKeybase(db dbm.DB) Keybase {
+	return newBackendKeybase(newDBBackend(db))
+}
+
+// newBackendKeybase creates a new keybase instance reading and writing keys with the backend.
+func newBackendKeybase(backend Backend) Keybase {
 	return &dbKeybase{
-		db: db,
+		backend: backend,
 	}
 }
 
 // NewInMemory creates a transient keybase on top of in-memory storage
 // instance useful for testing purposes and on-the-fly key generation.
-func NewInMemory() Keybase { return &dbKeybase{db: dbm.NewMemDB()} }
+func NewInMemory() Keybase { return newDbKeybase(dbm.NewMemDB()) }
 
 func (kb *dbKeybase) GetCoinbase() (KeyPair, error) {
 	if kb.coinbase.PrivKeyArmor == "" {
@@ -57,26 +62,12 @@ func (kb *dbKeybase) SetCoinbase(address types.Address) error {
 
 // List returns the keys from storage in alphabetical order.
 func (kb dbKeybase) List() ([]KeyPair, error) {
-	var res []KeyPair
-	iter, _ := kb.db.Iterator(nil, nil)
-	defer iter.Close()
-	for ; iter.Valid(); iter.Next() {
-		kp, err := readKeyPair(iter.Value())
-		if err != nil {
-			return nil, err
-		}
-		res = append(res, kp)
-	}
-	return res, nil
+	return kb.backend.List()
 }
 
 // Get returns the public information about one key.
 func (kb dbKeybase) Get(address types.Address) (KeyPair, error) {
-	ik, _ := kb.db.Get(addrKey(address))
-	if len(ik) == 0 {
-		return KeyPair{}, fmt.Errorf("key with address %s not found", address)
-	}
-	return readKeyPair(ik)
+	return kb.backend.Get(address)
 }
 
 // Delete removes key forever, but we must present the
@@ -95,7 +86,7 @@ func (kb dbKeybase) Delete(address types.Address, passphrase string) error {
 		return err
 	}
 
-	return kb.db.DeleteSync(addrKey(kp.GetAddress()))
+	return kb.backend.Delete(kp.GetAddress())
 }
 
 // Delete without passphrase verification
@@ -105,7 +96,7 @@ func (kb *dbKeybase) UnsafeDelete(address types.Address) error {
 	if err != nil {
 		return err
 	}
-	return kb.db.DeleteSync(addrKey(kp.GetAddress()))
+	return kb.backend.Delete(kp.GetAddress())
 }
 
 // Update changes the passphrase with which an already stored key is
@@ -125,7 +116,7 @@ func (kb dbKeybase) Update(address types.Address, oldpass string, newpass string
 		return err
 	}
 
-	_, err = kb.writeLocalKeyPair(privKey, newpass, "")
+	_, err = kb.writeLocalKeyPair(privKey, newpass, "", kp.HDPath, true)
 	if err != nil {
 		return err
 	}
@@ -162,13 +153,39 @@ func (kb dbKeybase) Sign(address types.Address, passphrase string, msg []byte) (
 // Create a new KeyPair and encrypt it to disk using encryptPassphrase
 func (kb dbKeybase) Create(encryptPassphrase string) (KeyPair, error) {
 	privKey := crypto.PrivateKey(crypto.Ed25519PrivateKey{}).GenPrivateKey()
-	kp, err := kb.writeLocalKeyPair(privKey, encryptPassphrase, "")
+	kp, err := kb.writeLocalKeyPair(privKey, encryptPassphrase, "", "", false)
 	if err != nil {
 		return kp, err
 	}
 	return kp, nil
 }
 
+// CreateMnemonic creates a new mnemonic and the KeyPair of its first account (index 0), encrypted to disk using
+// encryptPassphrase. The mnemonic is not stored, it is the backup of every key derived from it
+func (kb dbKeybase) CreateMnemonic(encryptPassphrase string) (KeyPair, string, error) {
+	mnemonic, err := NewMnemonic()
+	if err != nil {
+		return KeyPair{}, "", err
+	}
+	kp, err := kb.Recover(mnemonic, encryptPassphrase, 0)
+	return kp, mnemonic, err
+}
+
+// Recover derives the KeyPair of the account index of the mnemonic and encrypts it to disk using encryptPassphrase.
+// It returns an error if a key with the same address exists.
+func (kb dbKeybase) Recover(mnemonic, encryptPassphrase string, index uint32) (KeyPair, error) {
+	hdPath := HDPath(index)
+	privKey, err := DeriveKey(mnemonic, hdPath)
+	if err != nil {
+		return KeyPair{}, err
+	}
+	address := types.Address(privKey.PublicKey().Address())
+	if _, err := kb.Get(address); err == nil {
+		return KeyPair{}, errors.New("Cannot overwrite key with address: " + address.String())
+	}
+	return kb.writeLocalKeyPair(privKey, encryptPassphrase, "", hdPath, false)
+}
+
 // ImportPrivKey imports a private key in ASCII armor format.
 // It returns an error if a key with the same address exists or a wrong decryptPassphrase is
 // supplied.
@@ -184,7 +201,16 @@ func (kb dbKeybase) ImportPrivKey(armor, decryptPassphrase, encryptPassphrase st
 	if _, err := kb.Get(Address); err == nil {
 		return KeyPair{}, errors.New("Cannot overwrite key with address: " + Address.String())
 	}
-	return kb.writeLocalKeyPair(privKey, encryptPassphrase, "")
+	return kb.writeLocalKeyPair(privKey, encryptPassphrase, "", "", false)
+}
+
+// ImportKeyPair stores an encrypted key pair as is, e.g. when migrating between backends.
+// It returns an error if a key with the same address exists.
+func (kb dbKeybase) ImportKeyPair(kp KeyPair) error {
+	if kp.PublicKey == nil || kp.PrivKeyArmor == "" {
+		return errors.New("the key pair is missing its public key or private key armor")
+	}
+	return kb.backend.Insert(kp)
 }
 
 // ExportPrivKeyEncryptedArmor finds the KeyPair by the address, decrypts the armor private key,
@@ -207,7 +233,7 @@ func (kb dbKeybase) ImportPrivateKeyObject(privateKey [64]byte, encryptPassphras
 	if _, err := kb.Get(Address); err == nil {
 		return KeyPair{}, errors.New("Cannot overwrite key with address: " + Address.String())
 	}
-	return kb.writeLocalKeyPair(ed25519PK, encryptPassphrase, "")
+	return kb.writeLocalKeyPair(ed25519PK, encryptPassphrase, "", "", false)
 }
 
 // ExportPrivateKeyObject exports raw PrivKey object.
@@ -231,11 +257,12 @@ func (kb dbKeybase) ExportPrivateKeyObject(address types.Address, passphrase str
 
 // CloseDB releases the lock and closes the storage backend.
 func (kb dbKeybase) CloseDB() {
-	_ = kb.db.Close()
+	_ = kb.backend.Close()
 }
 
 // Private interface
-func (kb dbKeybase) writeLocalKeyPair(priv crypto.PrivateKey, passphrase, hint string) (KeyPair, error) {
+// writeLocalKeyPair encrypts and stores the key, replacing a stored key of the same address only when overwrite is set
+func (kb dbKeybase) writeLocalKeyPair(priv crypto.PrivateKey, passphrase, hint, hdPath string, overwrite bool) (KeyPair, error) {
 	// encrypt private key using passphrase
 	privArmor, err := mintkey.EncryptArmorPrivKey(priv, passphrase, hint)
 	if err != nil || privArmor == "" {
@@ -245,18 +272,18 @@ func (kb dbKeybase) writeLocalKeyPair(priv crypto.PrivateKey, passphrase, hint s
 	// make Info
 	pub := priv.PublicKey()
 	localKeyPair := NewKeyPair(pub, privArmor)
-	kb.writeKeyPair(localKeyPair)
+	localKeyPair.HDPath = hdPath
+	write := kb.backend.Insert
+	if overwrite {
+		write = kb.backend.Set
+	}
+	if err = write(localKeyPair); err != nil {
+		return KeyPair{}, err
+	}
 
 	return localKeyPair, nil
 }
 
-func (kb dbKeybase) writeKeyPair(kp KeyPair) {
-	// write the info by key
-	key := addrKey(kp.GetAddress())
-	serializedInfo := writeKeyPair(kp)
-	_ = kb.db.SetSync(key, serializedInfo)
-}
-
 func addrKey(address types.Address) []byte {
 	return []byte(address.String())
 }
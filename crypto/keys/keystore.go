@@ -0,0 +1,179 @@
+package keys
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pokt-network/pocket-core/crypto"
+	"github.com/pokt-network/pocket-core/crypto/keys/mintkey"
+	"github.com/pokt-network/pocket-core/types"
+	cmn "github.com/tendermint/tendermint/libs/os"
+)
+
+const (
+	keyFileExt   = ".json"
+	lockFileName = ".lock"
+)
+
+// keyFile is the content of a key file of the keystore: the mintkey armor of the private key, with the address
+// and public key in the clear. It can be imported as is with `pocket accounts import-armored`
+type keyFile struct {
+	Address string `json:"address"`
+	PubKey  string `json:"pubkey"`
+	HDPath  string `json:"hd_path,omitempty"`
+	mintkey.ArmoredJson
+}
+
+var _ Backend = dirBackend{}
+
+// dirBackend stores every key pair in its own file of a directory. The directory is locked (shared to read,
+// exclusive to write) for every operation, so several processes can share the keystore
+type dirBackend struct {
+	dir string
+}
+
+// NewKeystore creates a keybase storing every key in its own armored json file of dir.
+// A read-only keystore rejects every write, for signing only processes.
+func NewKeystore(dir string, readOnly bool) Keybase {
+	if readOnly {
+		return newBackendKeybase(readOnlyBackend{dirBackend{dir: dir}})
+	}
+	if err := cmn.EnsureDir(dir, 0700); err != nil {
+		panic(fmt.Sprintf("failed to create Keystore directory: %s", err))
+	}
+	return newBackendKeybase(dirBackend{dir: dir})
+}
+
+func (b dirBackend) List() ([]KeyPair, error) {
+	unlock, err := lockDir(b.dir, false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	files, err := ioutil.ReadDir(b.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var names []string
+	for _, f := range files {
+		if !f.IsDir() && strings.HasSuffix(f.Name(), keyFileExt) {
+			names = append(names, f.Name())
+		}
+	}
+	sort.Strings(names)
+	var res []KeyPair
+	for _, name := range names {
+		kp, err := readKeyFile(filepath.Join(b.dir, name))
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, kp)
+	}
+	return res, nil
+}
+
+func (b dirBackend) Get(address types.Address) (KeyPair, error) {
+	unlock, err := lockDir(b.dir, false)
+	if err != nil {
+		return KeyPair{}, err
+	}
+	defer unlock()
+	kp, err := readKeyFile(b.path(address))
+	if os.IsNotExist(err) {
+		return KeyPair{}, fmt.Errorf("key with address %s not found", address)
+	}
+	return kp, err
+}
+
+func (b dirBackend) Set(kp KeyPair) error {
+	return b.write(kp, true)
+}
+
+func (b dirBackend) Insert(kp KeyPair) error {
+	return b.write(kp, false)
+}
+
+// write stores the key file of kp under the exclusive lock, replacing an existing one only when overwrite is set
+func (b dirBackend) write(kp KeyPair, overwrite bool) error {
+	var armor mintkey.ArmoredJson
+	if err := json.Unmarshal([]byte(kp.PrivKeyArmor), &armor); err != nil {
+		return fmt.Errorf("invalid private key armor: %s", err.Error())
+	}
+	bz, err := json.MarshalIndent(keyFile{
+		Address:     kp.GetAddress().String(),
+		PubKey:      kp.PublicKey.RawString(),
+		HDPath:      kp.HDPath,
+		ArmoredJson: armor,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	unlock, err := lockDir(b.dir, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	path := b.path(kp.GetAddress())
+	if !overwrite {
+		if _, err = os.Stat(path); err == nil {
+			return errKeyExists(kp.GetAddress())
+		} else if !os.IsNotExist(err) {
+			return err
+		}
+	}
+	// write and rename, so a reader never sees a partial file
+	if err = ioutil.WriteFile(path+".tmp", bz, 0600); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+func (b dirBackend) Delete(address types.Address) error {
+	unlock, err := lockDir(b.dir, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return os.Remove(b.path(address))
+}
+
+func (b dirBackend) Close() error {
+	return nil
+}
+
+func (b dirBackend) path(address types.Address) string {
+	return filepath.Join(b.dir, address.String()+keyFileExt)
+}
+
+func readKeyFile(path string) (KeyPair, error) {
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return KeyPair{}, err
+	}
+	var f keyFile
+	if err = json.Unmarshal(bz, &f); err != nil {
+		return KeyPair{}, fmt.Errorf("invalid key file %s: %s", path, err.Error())
+	}
+	pub, err := crypto.NewPublicKey(f.PubKey)
+	if err != nil {
+		return KeyPair{}, fmt.Errorf("invalid public key in key file %s: %s", path, err.Error())
+	}
+	if !strings.EqualFold(pub.Address().String(), f.Address) {
+		return KeyPair{}, fmt.Errorf("the public key of key file %s does not match its address", path)
+	}
+	armor, err := json.Marshal(f.ArmoredJson)
+	if err != nil {
+		return KeyPair{}, err
+	}
+	kp := NewKeyPair(pub, string(armor))
+	kp.HDPath = f.HDPath
+	return kp, nil
+}
@@ -0,0 +1,158 @@
+package keys
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeystore(t *testing.T) {
+	dir, cleanup := NewTestCaseDir(t)
+	defer cleanup()
+	kb := NewKeystore(dir, false)
+	kp1, err := kb.Create("1234")
+	require.NoError(t, err)
+	kp2, err := kb.Create("5678")
+	require.NoError(t, err)
+	// one file per key, readable by another instance
+	other := NewKeystore(dir, false)
+	kps, err := other.List()
+	require.NoError(t, err)
+	require.Len(t, kps, 2)
+	got, err := other.Get(kp1.GetAddress())
+	require.NoError(t, err)
+	assert.True(t, got.PublicKey.Equals(kp1.PublicKey))
+	msg := []byte("msg")
+	sig, pub, err := other.Sign(kp1.GetAddress(), "1234", msg)
+	require.NoError(t, err)
+	assert.True(t, pub.VerifyBytes(msg, sig))
+	// the key file is a mintkey armor that can be imported as is
+	bz, err := ioutil.ReadFile(filepath.Join(dir, kp2.GetAddress().String()+keyFileExt))
+	require.NoError(t, err)
+	imported, err := NewInMemory().ImportPrivKey(string(bz), "5678", "new")
+	require.NoError(t, err)
+	assert.Equal(t, kp2.GetAddress(), imported.GetAddress())
+	// update and delete
+	require.NoError(t, kb.Update(kp2.GetAddress(), "5678", "9012"))
+	_, _, err = other.Sign(kp2.GetAddress(), "9012", msg)
+	require.NoError(t, err)
+	require.Error(t, kb.Delete(kp1.GetAddress(), "wrong"))
+	require.NoError(t, kb.Delete(kp1.GetAddress(), "1234"))
+	_, err = other.Get(kp1.GetAddress())
+	require.Error(t, err)
+}
+
+func TestKeystore_ReadOnly(t *testing.T) {
+	dir, cleanup := NewTestCaseDir(t)
+	defer cleanup()
+	kp, err := NewKeystore(dir, false).Create("1234")
+	require.NoError(t, err)
+	kb := NewKeystore(dir, true)
+	// signing works
+	_, _, err = kb.Sign(kp.GetAddress(), "1234", []byte("msg"))
+	require.NoError(t, err)
+	// writing does not
+	_, err = kb.Create("1234")
+	assert.Equal(t, ErrReadOnly, err)
+	assert.Equal(t, ErrReadOnly, kb.Update(kp.GetAddress(), "1234", "5678"))
+	assert.Equal(t, ErrReadOnly, kb.UnsafeDelete(kp.GetAddress()))
+	kps, err := kb.List()
+	require.NoError(t, err)
+	assert.Len(t, kps, 1)
+	// so does the read-only leveldb keybase
+	kp, err = New("keybase", dir).Create("1234")
+	require.NoError(t, err)
+	lazy := NewReadOnly("keybase", dir)
+	_, err = lazy.Create("1234")
+	assert.Equal(t, ErrReadOnly, err)
+	_, _, err = lazy.Sign(kp.GetAddress(), "1234", []byte("msg"))
+	require.NoError(t, err)
+	// which opens the db read-only, so a missing db is not created
+	_, err = NewReadOnly("missing", dir).List()
+	assert.Error(t, err)
+	_, err = os.Stat(filepath.Join(dir, "missing.db"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestKeystore_Concurrent(t *testing.T) {
+	dir, cleanup := NewTestCaseDir(t)
+	defer cleanup()
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			kb := NewKeystore(dir, false)
+			_, err := kb.Create("1234")
+			assert.NoError(t, err)
+			_, err = kb.List()
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	kps, err := NewKeystore(dir, false).List()
+	require.NoError(t, err)
+	assert.Len(t, kps, 4)
+}
+
+func TestKeybase_ImportKeyPairConcurrent(t *testing.T) {
+	dir, cleanup := NewTestCaseDir(t)
+	defer cleanup()
+	kp, err := NewInMemory().Create("1234")
+	require.NoError(t, err)
+	memory := NewInMemory()
+	for name, newKeybase := range map[string]func() Keybase{
+		"memory": func() Keybase { return memory },
+		"dir":    func() Keybase { return NewKeystore(dir, false) },
+	} {
+		// a single import stores the key, the others are rejected
+		var wg sync.WaitGroup
+		var l sync.Mutex
+		imported := 0
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if newKeybase().ImportKeyPair(kp) == nil {
+					l.Lock()
+					imported++
+					l.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 1, imported, name)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	dir, cleanup := NewTestCaseDir(t)
+	defer cleanup()
+	from := New("keybase", dir)
+	kp1, err := from.Create("1234")
+	require.NoError(t, err)
+	_, err = from.Create("5678")
+	require.NoError(t, err)
+	to := NewKeystore(filepath.Join(dir, "keystore"), false)
+	copied, err := Migrate(from, to)
+	require.NoError(t, err)
+	assert.Equal(t, 2, copied)
+	// migrating again copies nothing
+	copied, err = Migrate(from, to)
+	require.NoError(t, err)
+	assert.Equal(t, 0, copied)
+	_, _, err = to.Sign(kp1.GetAddress(), "1234", []byte("msg"))
+	require.NoError(t, err)
+	// a different key with the same address is not overwritten
+	require.NoError(t, to.UnsafeDelete(kp1.GetAddress()))
+	kp1.PrivKeyArmor, err = from.ExportPrivKeyEncryptedArmor(kp1.GetAddress(), "1234", "other", "")
+	require.NoError(t, err)
+	require.NoError(t, to.ImportKeyPair(kp1))
+	_, err = Migrate(from, to)
+	require.Error(t, err)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/pokt-network/pocket-core/crypto"
 	cmn "github.com/tendermint/tendermint/libs/os"
+	dbm "github.com/tendermint/tm-db"
 
 	"github.com/pokt-network/pocket-core/types"
 	sdk "github.com/pokt-network/pocket-core/types"
@@ -16,6 +17,7 @@ var _ Keybase = &lazyKeybase{}
 type lazyKeybase struct {
 	name     string
 	dir      string
+	readOnly bool
 	coinbase KeyPair
 }
 
@@ -28,6 +30,26 @@ func New(name, dir string) Keybase {
 	return &lazyKeybase{name: name, dir: dir}
 }
 
+// NewReadOnly creates a new instance of a lazy keybase that rejects every write, for signing only processes.
+func NewReadOnly(name, dir string) Keybase {
+	return &lazyKeybase{name: name, dir: dir, readOnly: true}
+}
+
+// openDB opens the leveldb of the keybase, read-only for a read-only keybase so the process never writes to it
+func (lkb lazyKeybase) openDB() (dbm.DB, error) {
+	opts := config.DefaultLevelDBOpts().ToGoLevelDBOpts()
+	opts.ReadOnly = lkb.readOnly
+	return sdk.NewLevelDB(lkb.name, lkb.dir, opts)
+}
+
+// keybase returns the keybase of the opened db
+func (lkb lazyKeybase) keybase(db dbm.DB) Keybase {
+	if lkb.readOnly {
+		return newBackendKeybase(readOnlyBackend{newDBBackend(db)})
+	}
+	return newDbKeybase(db)
+}
+
 func (kb *lazyKeybase) GetCoinbase() (KeyPair, error) {
 	if kb.coinbase.PrivKeyArmor == "" {
 		kps, err := kb.List()
@@ -52,113 +74,143 @@ func (kb *lazyKeybase) SetCoinbase(address types.Address) error {
 }
 
 func (lkb lazyKeybase) List() ([]KeyPair, error) {
-	db, err := sdk.NewLevelDB(lkb.name, lkb.dir, config.DefaultLevelDBOpts().ToGoLevelDBOpts())
+	db, err := lkb.openDB()
 	if err != nil {
 		return nil, err
 	}
 	defer db.Close()
 
-	return newDbKeybase(db).List()
+	return lkb.keybase(db).List()
 }
 
 func (lkb lazyKeybase) Get(address types.Address) (KeyPair, error) {
-	db, err := sdk.NewLevelDB(lkb.name, lkb.dir, config.DefaultLevelDBOpts().ToGoLevelDBOpts())
+	db, err := lkb.openDB()
 	if err != nil {
 		return KeyPair{}, err
 	}
 	defer db.Close()
 
-	return newDbKeybase(db).Get(address)
+	return lkb.keybase(db).Get(address)
 }
 
 func (lkb lazyKeybase) Delete(address types.Address, passphrase string) error {
-	db, err := sdk.NewLevelDB(lkb.name, lkb.dir, config.DefaultLevelDBOpts().ToGoLevelDBOpts())
+	db, err := lkb.openDB()
 	if err != nil {
 		return err
 	}
 	defer db.Close()
 
-	return newDbKeybase(db).Delete(address, passphrase)
+	return lkb.keybase(db).Delete(address, passphrase)
 }
 
 func (lkb *lazyKeybase) UnsafeDelete(address sdk.Address) error {
-	db, err := sdk.NewLevelDB(lkb.name, lkb.dir, config.DefaultLevelDBOpts().ToGoLevelDBOpts())
+	db, err := lkb.openDB()
 	if err != nil {
 		return err
 	}
 	defer db.Close()
 
-	return newDbKeybase(db).UnsafeDelete(address)
+	return lkb.keybase(db).UnsafeDelete(address)
 }
 
 func (lkb lazyKeybase) Update(address types.Address, oldpass string, newpass string) error {
-	db, err := sdk.NewLevelDB(lkb.name, lkb.dir, config.DefaultLevelDBOpts().ToGoLevelDBOpts())
+	db, err := lkb.openDB()
 	if err != nil {
 		return err
 	}
 	defer db.Close()
 
-	return newDbKeybase(db).Update(address, oldpass, newpass)
+	return lkb.keybase(db).Update(address, oldpass, newpass)
 }
 
 func (lkb lazyKeybase) Sign(address types.Address, passphrase string, msg []byte) ([]byte, crypto.PublicKey, error) {
-	db, err := sdk.NewLevelDB(lkb.name, lkb.dir, config.DefaultLevelDBOpts().ToGoLevelDBOpts())
+	db, err := lkb.openDB()
 	if err != nil {
 		return nil, nil, err
 	}
 	defer db.Close()
 
-	return newDbKeybase(db).Sign(address, passphrase, msg)
+	return lkb.keybase(db).Sign(address, passphrase, msg)
 }
 
 func (lkb lazyKeybase) Create(encryptPassphrase string) (KeyPair, error) {
-	db, err := sdk.NewLevelDB(lkb.name, lkb.dir, config.DefaultLevelDBOpts().ToGoLevelDBOpts())
+	db, err := lkb.openDB()
 	if err != nil {
 		return KeyPair{}, err
 	}
 	defer db.Close()
 
-	return newDbKeybase(db).Create(encryptPassphrase)
+	return lkb.keybase(db).Create(encryptPassphrase)
+}
+
+func (lkb lazyKeybase) CreateMnemonic(encryptPassphrase string) (KeyPair, string, error) {
+	db, err := lkb.openDB()
+	if err != nil {
+		return KeyPair{}, "", err
+	}
+	defer db.Close()
+
+	return lkb.keybase(db).CreateMnemonic(encryptPassphrase)
+}
+
+func (lkb lazyKeybase) Recover(mnemonic, encryptPassphrase string, index uint32) (KeyPair, error) {
+	db, err := lkb.openDB()
+	if err != nil {
+		return KeyPair{}, err
+	}
+	defer db.Close()
+
+	return lkb.keybase(db).Recover(mnemonic, encryptPassphrase, index)
 }
 
 func (lkb lazyKeybase) ImportPrivKey(armor, decryptPassphrase, encryptPassphrase string) (KeyPair, error) {
-	db, err := sdk.NewLevelDB(lkb.name, lkb.dir, config.DefaultLevelDBOpts().ToGoLevelDBOpts())
+	db, err := lkb.openDB()
 	if err != nil {
 		return KeyPair{}, err
 	}
 	defer db.Close()
 
-	return newDbKeybase(db).ImportPrivKey(armor, decryptPassphrase, encryptPassphrase)
+	return lkb.keybase(db).ImportPrivKey(armor, decryptPassphrase, encryptPassphrase)
+}
+
+func (lkb lazyKeybase) ImportKeyPair(kp KeyPair) error {
+	db, err := lkb.openDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return lkb.keybase(db).ImportKeyPair(kp)
 }
 
 func (lkb lazyKeybase) ExportPrivKeyEncryptedArmor(address types.Address, decryptPassphrase, encryptPassphrase, hint string) (armor string, err error) {
-	db, err := sdk.NewLevelDB(lkb.name, lkb.dir, config.DefaultLevelDBOpts().ToGoLevelDBOpts())
+	db, err := lkb.openDB()
 	if err != nil {
 		return "", err
 	}
 	defer db.Close()
 
-	return newDbKeybase(db).ExportPrivKeyEncryptedArmor(address, decryptPassphrase, encryptPassphrase, hint)
+	return lkb.keybase(db).ExportPrivKeyEncryptedArmor(address, decryptPassphrase, encryptPassphrase, hint)
 }
 
 func (lkb lazyKeybase) ImportPrivateKeyObject(privateKey [64]byte, encryptPassphrase string) (KeyPair, error) {
-	db, err := sdk.NewLevelDB(lkb.name, lkb.dir, config.DefaultLevelDBOpts().ToGoLevelDBOpts())
+	db, err := lkb.openDB()
 	if err != nil {
 		return KeyPair{}, err
 	}
 	defer db.Close()
 
-	return newDbKeybase(db).ImportPrivateKeyObject(privateKey, encryptPassphrase)
+	return lkb.keybase(db).ImportPrivateKeyObject(privateKey, encryptPassphrase)
 }
 
 func (lkb lazyKeybase) ExportPrivateKeyObject(address types.Address, passphrase string) (crypto.PrivateKey, error) {
-	db, err := sdk.NewLevelDB(lkb.name, lkb.dir, config.DefaultLevelDBOpts().ToGoLevelDBOpts())
+	db, err := lkb.openDB()
 	if err != nil {
 		return nil, err
 	}
 	defer db.Close()
 
-	return newDbKeybase(db).ExportPrivateKeyObject(address, passphrase)
+	return lkb.keybase(db).ExportPrivateKeyObject(address, passphrase)
 }
 
 func (lkb lazyKeybase) CloseDB() {}
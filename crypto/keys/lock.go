@@ -0,0 +1,35 @@
+//go:build !windows
+// +build !windows
+
+package keys
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// lockDir takes the lock of the keystore directory, exclusive to write or shared to read, and returns its release.
+// Reading a directory that was never written to (no lock file) takes no lock
+func lockDir(dir string, exclusive bool) (unlock func(), err error) {
+	path := filepath.Join(dir, lockFileName)
+	how, flag := syscall.LOCK_SH, os.O_RDONLY
+	if exclusive {
+		how, flag = syscall.LOCK_EX, os.O_RDWR|os.O_CREATE
+	}
+	f, err := os.OpenFile(path, flag, 0600)
+	if err != nil {
+		if !exclusive && os.IsNotExist(err) {
+			return func() {}, nil
+		}
+		return nil, err
+	}
+	if err = syscall.Flock(int(f.Fd()), how); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return func() {
+		_ = syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		_ = f.Close()
+	}, nil
+}
@@ -0,0 +1,35 @@
+package keys
+
+import (
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sys/windows"
+)
+
+// lockDir takes the lock of the keystore directory, exclusive to write or shared to read, and returns its release.
+// Reading a directory that was never written to (no lock file) takes no lock
+func lockDir(dir string, exclusive bool) (unlock func(), err error) {
+	path := filepath.Join(dir, lockFileName)
+	var how uint32
+	flag := os.O_RDONLY
+	if exclusive {
+		how, flag = windows.LOCKFILE_EXCLUSIVE_LOCK, os.O_RDWR|os.O_CREATE
+	}
+	f, err := os.OpenFile(path, flag, 0600)
+	if err != nil {
+		if !exclusive && os.IsNotExist(err) {
+			return func() {}, nil
+		}
+		return nil, err
+	}
+	ol := new(windows.Overlapped)
+	if err = windows.LockFileEx(windows.Handle(f.Fd()), how, 0, 1, 0, ol); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return func() {
+		_ = windows.UnlockFileEx(windows.Handle(f.Fd()), 0, 1, 0, ol)
+		_ = f.Close()
+	}, nil
+}
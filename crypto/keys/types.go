@@ -26,9 +26,18 @@ type Keybase interface {
 	// Create a new KeyPair and encrypt it to disk using encryptPassphrase
 	Create(encryptPassphrase string) (KeyPair, error)
 
+	// CreateMnemonic creates a new mnemonic and the KeyPair of its first account, encrypted using encryptPassphrase
+	CreateMnemonic(encryptPassphrase string) (kp KeyPair, mnemonic string, err error)
+
+	// Recover the KeyPair of the account index of the mnemonic and encrypt it to disk using encryptPassphrase
+	Recover(mnemonic, encryptPassphrase string, index uint32) (KeyPair, error)
+
 	// ImportPrivKey using Armored private key string. Decrypts armor with decryptPassphrase, and stores locally using encryptPassphrase
 	ImportPrivKey(armor, decryptPassphrase, encryptPassphrase string) (KeyPair, error)
 
+	// ImportKeyPair stores an encrypted key pair as is, e.g. when migrating between backends
+	ImportKeyPair(kp KeyPair) error
+
 	// ExportPrivKeyArmor using Armored private key string. Decrypts armor with decryptPassphrase, and encrypts result armor using the encryptPassphrase
 	ExportPrivKeyEncryptedArmor(address types.Address, decryptPassphrase, encryptPassphrase, hint string) (armor string, err error)
 
@@ -46,6 +55,7 @@ type Keybase interface {
 type KeyPair struct {
 	PublicKey    crypto.PublicKey `json:"pubkey"`
 	PrivKeyArmor string           `json:"privkey.armor"`
+	HDPath       string           `json:"hd_path,omitempty"` // the derivation path of a key recovered from a mnemonic
 }
 
 // NewKeyPair with the given public key and priv armor key
@@ -0,0 +1,179 @@
+package signer
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/pokt-network/pocket-core/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/privval"
+	tmTypes "github.com/tendermint/tendermint/types"
+)
+
+// the size of a relay proof hash (sha3-256)
+const proofHashSize = 32
+
+// the amino names of the msgs a claim / proof tx may carry
+var txMsgNames = map[string]string{
+	MsgTypeClaim: "pocketcore/claim",
+	MsgTypeProof: "pocketcore/proof",
+}
+
+// Server is the reference signer daemon
+type Server struct {
+	key     crypto.PrivateKey
+	allowed map[string]bool
+	logger  log.Logger
+}
+
+// NewServer returns a daemon signing with key the message types of the allow-list
+func NewServer(key crypto.PrivateKey, allowed []string, logger log.Logger) (*Server, error) {
+	s := &Server{key: key, allowed: make(map[string]bool), logger: logger}
+	for _, msgType := range allowed {
+		if !isMsgType(msgType) {
+			return nil, fmt.Errorf("unknown message type %s, expected one of %v", msgType, MsgTypes)
+		}
+		s.allowed[msgType] = true
+	}
+	return s, nil
+}
+
+// Listen listens on the unix socket at addr (unix://<path> or <path>), replacing a stale socket file.
+// Only the owner of the daemon may connect
+func Listen(addr string) (net.Listener, error) {
+	path := SocketPath(addr)
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.Chmod(path, 0600); err != nil {
+		_ = l.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+// Serve answers the requests of the connections of l until it is closed
+func (s *Server) Serve(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+	dec, enc := json.NewDecoder(conn), json.NewEncoder(conn)
+	for {
+		var req Request
+		if err := dec.Decode(&req); err != nil {
+			return
+		}
+		res := s.respond(req)
+		if res.Error != "" {
+			s.logger.Error("signer request rejected", "type", req.Type, "msg_type", req.MsgType, "err", res.Error)
+		}
+		if err := enc.Encode(res); err != nil {
+			return
+		}
+	}
+}
+
+func (s *Server) respond(req Request) Response {
+	switch req.Type {
+	case RequestPubKey:
+		return Response{PubKey: encodePubKey(s.key.PublicKey())}
+	case RequestSign:
+		if err := s.checkMsg(req.MsgType, req.Msg); err != nil {
+			return Response{Error: err.Error()}
+		}
+		sig, err := s.key.Sign(SignBytes(req.MsgType, req.Msg))
+		if err != nil {
+			return Response{Error: err.Error()}
+		}
+		return Response{Signature: sig}
+	default:
+		return Response{Error: fmt.Sprintf("unknown request type %s", req.Type)}
+	}
+}
+
+func (s *Server) checkMsg(msgType string, msg []byte) error {
+	if !s.allowed[msgType] {
+		return fmt.Errorf("message type %s is not allowed", msgType)
+	}
+	return ValidateMsg(msgType, msg)
+}
+
+// ValidateMsg checks that msg looks like a message of type msgType
+func ValidateMsg(msgType string, msg []byte) error {
+	switch msgType {
+	case MsgTypeRelayResponse:
+		// the hash seed of a relay response: the payload and the hash of its proof, without a signature
+		var seed struct {
+			Signature *string `json:"signature"`
+			Payload   *string `json:"payload"`
+			Proof     string  `json:"Proof"`
+		}
+		dec := json.NewDecoder(bytes.NewReader(msg))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&seed); err != nil {
+			return fmt.Errorf("the %s is not a relay response hash seed: %s", msgType, err.Error())
+		}
+		if seed.Signature == nil || *seed.Signature != "" || seed.Payload == nil {
+			return fmt.Errorf("the %s is not a relay response hash seed", msgType)
+		}
+		if proofHash, err := hex.DecodeString(seed.Proof); err != nil || len(proofHash) != proofHashSize {
+			return fmt.Errorf("the proof of the %s is not a sha3-256 hash: %q", msgType, seed.Proof)
+		}
+		return nil
+	case MsgTypeClaim, MsgTypeProof:
+		var signDoc struct {
+			ChainID string `json:"chain_id"`
+			Msg     struct {
+				Type string `json:"type"`
+			} `json:"msg"`
+		}
+		if err := json.Unmarshal(msg, &signDoc); err != nil {
+			return fmt.Errorf("the %s is not a tx sign doc: %s", msgType, err.Error())
+		}
+		if signDoc.Msg.Type != txMsgNames[msgType] {
+			return fmt.Errorf("the %s tx carries a %q msg", msgType, signDoc.Msg.Type)
+		}
+		return nil
+	case MsgTypeConsensus:
+		return fmt.Errorf("consensus messages are signed over the privval protocol")
+	default:
+		return fmt.Errorf("unknown message type %s", msgType)
+	}
+}
+
+// StartConsensusSigner dials the privval listener of the node at nodeAddr (priv_validator_laddr) and signs its
+// votes and proposals with pv
+func StartConsensusSigner(nodeAddr, chainID string, pv tmTypes.PrivValidator, logger log.Logger) (*privval.SignerServer, error) {
+	dialer := privval.DialUnixFn(SocketPath(nodeAddr))
+	endpoint := privval.NewSignerDialerEndpoint(logger, dialer)
+	server := privval.NewSignerServer(endpoint, chainID, pv)
+	if err := server.Start(); err != nil {
+		return nil, err
+	}
+	return server, nil
+}
+
+func isMsgType(msgType string) bool {
+	for _, t := range MsgTypes {
+		if t == msgType {
+			return true
+		}
+	}
+	return false
+}
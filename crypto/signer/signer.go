@@ -0,0 +1,131 @@
+// Package signer keeps the node (servicer / validator) key off the internet facing host.
+//
+// A signer daemon holding the key listens on a unix socket, the node connects to it and asks for signatures of
+// relay responses and claim / proof txs with newline delimited json requests. Every request names the type of the
+// message, which the daemon checks against its allow-list and against the message itself: a relay response is sent as
+// its hash seed (so the daemon sees the proof hash it signs for) and the signature is over the hash, see SignBytes.
+// Consensus messages (votes and proposals) use the tendermint privval protocol instead, see StartConsensusSigner.
+package signer
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/pokt-network/pocket-core/crypto"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	MsgTypeRelayResponse = "relay_response" // the hash seed of a relay response, signed by its sha3-256 hash
+	MsgTypeClaim         = "claim"          // the sign bytes of a claim tx
+	MsgTypeProof         = "proof"          // the sign bytes of a proof tx
+	MsgTypeConsensus     = "consensus"      // votes and proposals, over the privval protocol
+
+	RequestPubKey = "pub_key"
+	RequestSign   = "sign"
+
+	DefaultTimeout = 5 * time.Second
+)
+
+// MsgTypes are all the message types a signer can be allowed to sign
+var MsgTypes = []string{MsgTypeRelayResponse, MsgTypeClaim, MsgTypeProof, MsgTypeConsensus}
+
+// Request is a single request to the signer daemon
+type Request struct {
+	Type    string `json:"type"`
+	MsgType string `json:"msg_type,omitempty"`
+	Msg     []byte `json:"msg,omitempty"`
+}
+
+// Response is the answer of the signer daemon to a request
+type Response struct {
+	PubKey    string `json:"pub_key,omitempty"` // hex
+	Signature []byte `json:"signature,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Client signs with the key of a signer daemon
+type Client struct {
+	path    string
+	timeout time.Duration
+	pubKey  crypto.PublicKey
+}
+
+// NewClient connects to the signer daemon at addr (unix://<path> or <path>) and retrieves its public key
+func NewClient(addr string, timeout time.Duration) (*Client, error) {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	c := &Client{path: SocketPath(addr), timeout: timeout}
+	res, err := c.request(Request{Type: RequestPubKey})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the public key of the signer at %s: %s", addr, err.Error())
+	}
+	c.pubKey, err = crypto.NewPublicKey(res.PubKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key from the signer at %s: %s", addr, err.Error())
+	}
+	return c, nil
+}
+
+// PublicKey returns the public key of the daemon, retrieved when connecting
+func (c *Client) PublicKey() crypto.PublicKey {
+	return c.pubKey
+}
+
+// Sign asks the daemon for the signature of a message of type msgType
+func (c *Client) Sign(msgType string, msg []byte) ([]byte, error) {
+	res, err := c.request(Request{Type: RequestSign, MsgType: msgType, Msg: msg})
+	if err != nil {
+		return nil, err
+	}
+	// a daemon that swapped its key would otherwise make the node send invalid relays and txs
+	if !c.pubKey.VerifyBytes(SignBytes(msgType, msg), res.Signature) {
+		return nil, fmt.Errorf("the signer returned an invalid %s signature", msgType)
+	}
+	return res.Signature, nil
+}
+
+func (c *Client) request(req Request) (res Response, err error) {
+	conn, err := net.DialTimeout("unix", c.path, c.timeout)
+	if err != nil {
+		return res, err
+	}
+	defer conn.Close()
+	if err = conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+		return res, err
+	}
+	if err = json.NewEncoder(conn).Encode(req); err != nil {
+		return res, err
+	}
+	if err = json.NewDecoder(conn).Decode(&res); err != nil {
+		return res, err
+	}
+	if res.Error != "" {
+		return res, fmt.Errorf("signer: %s", res.Error)
+	}
+	return res, nil
+}
+
+// SignBytes returns the bytes signed for a message of type msgType: the sha3-256 hash of a relay response hash seed,
+// the message itself otherwise
+func SignBytes(msgType string, msg []byte) []byte {
+	if msgType == MsgTypeRelayResponse {
+		hash := sha3.Sum256(msg)
+		return hash[:]
+	}
+	return msg
+}
+
+// SocketPath returns the file path of a unix://<path> address
+func SocketPath(addr string) string {
+	return strings.TrimPrefix(addr, "unix://")
+}
+
+func encodePubKey(pk crypto.PublicKey) string {
+	return hex.EncodeToString(pk.RawBytes())
+}
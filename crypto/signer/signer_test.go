@@ -0,0 +1,75 @@
+package signer
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pokt-network/pocket-core/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+	"golang.org/x/crypto/sha3"
+)
+
+func startTestServer(t *testing.T, allowed ...string) (crypto.PrivateKey, string) {
+	dir, err := os.MkdirTemp("", "signer")
+	require.Nil(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	key := crypto.GenerateEd25519PrivKey()
+	server, err := NewServer(key, allowed, log.NewNopLogger())
+	require.Nil(t, err)
+	addr := "unix://" + filepath.Join(dir, "signer.sock")
+	l, err := Listen(addr)
+	require.Nil(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+	go func() { _ = server.Serve(l) }()
+	return key, addr
+}
+
+func TestClient_Sign(t *testing.T) {
+	key, addr := startTestServer(t, MsgTypeRelayResponse, MsgTypeClaim)
+	client, err := NewClient(addr, 0)
+	require.Nil(t, err)
+	assert.True(t, key.PublicKey().Equals(client.PublicKey()))
+	// relay response hash seed, signed by its hash
+	proofHash := sha3.Sum256([]byte("proof"))
+	seed := []byte(`{"signature":"","payload":"response","Proof":"` + hex.EncodeToString(proofHash[:]) + `"}`)
+	sig, err := client.Sign(MsgTypeRelayResponse, seed)
+	assert.Nil(t, err)
+	hash := sha3.Sum256(seed)
+	assert.True(t, key.PublicKey().VerifyBytes(hash[:], sig))
+	for _, invalid := range []string{
+		string(hash[:]),
+		`{"signature":"","payload":"response","Proof":"not a hash"}`,
+		`{"signature":"","payload":"response","Proof":"` + hex.EncodeToString(proofHash[:16]) + `"}`,
+		`{"signature":"ab","payload":"response","Proof":"` + hex.EncodeToString(proofHash[:]) + `"}`,
+		`{"payload":"response","Proof":"` + hex.EncodeToString(proofHash[:]) + `"}`,
+		`{"signature":"","payload":"response","Proof":"` + hex.EncodeToString(proofHash[:]) + `","extra":1}`,
+	} {
+		_, err = client.Sign(MsgTypeRelayResponse, []byte(invalid))
+		assert.NotNil(t, err, invalid)
+	}
+	// claim sign doc
+	claim := []byte(`{"chain_id":"pocket","entropy":"1","fee":[],"memo":"","msg":{"type":"pocketcore/claim","value":{}}}`)
+	_, err = client.Sign(MsgTypeClaim, claim)
+	assert.Nil(t, err)
+	send := []byte(`{"chain_id":"pocket","entropy":"1","fee":[],"memo":"","msg":{"type":"posmint/Send","value":{}}}`)
+	_, err = client.Sign(MsgTypeClaim, send)
+	assert.NotNil(t, err)
+	// not in the allow-list
+	proof := []byte(`{"chain_id":"pocket","entropy":"1","fee":[],"memo":"","msg":{"type":"pocketcore/proof","value":{}}}`)
+	_, err = client.Sign(MsgTypeProof, proof)
+	assert.NotNil(t, err)
+}
+
+func TestNewServer_UnknownMsgType(t *testing.T) {
+	_, err := NewServer(crypto.GenerateEd25519PrivKey(), []string{"send"}, log.NewNopLogger())
+	assert.NotNil(t, err)
+}
+
+func TestNewClient_NoDaemon(t *testing.T) {
+	_, err := NewClient(filepath.Join(os.TempDir(), "no-signer.sock"), 0)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,155 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sync"
+
+	dbm "github.com/tendermint/tm-db"
+)
+
+const (
+	AccountHistoryDBName = "account_history"
+
+	// the reasons of an account balance change
+	BalanceChangeTx          = "tx" // any change of a tx besides its fee, see TxHash
+	BalanceChangeFee         = "fee"
+	BalanceChangeRelayReward = "relay_reward"
+	BalanceChangeBlockReward = "block_reward"
+	BalanceChangeSlash       = "slash"
+	BalanceChangeDAOTransfer = "dao_transfer"
+	BalanceChangeBlock       = "block" // any other begin / end block change e.g. the payout of an unstake
+)
+
+type balanceChangeReasonKey struct{}
+
+type balanceChangeAccountKey struct{}
+
+// AccountChange is a single entry of the balance change log of an account
+type AccountChange struct {
+	Height  int64   `json:"height"`
+	Address Address `json:"address"`
+	Delta   BigInt  `json:"delta"` // signed amount of the stake denom
+	Reason  string  `json:"reason"`
+	TxHash  string  `json:"tx_hash,omitempty"`
+}
+
+// WithBalanceChangeReason tags every balance change made with the returned context with the reason
+func WithBalanceChangeReason(ctx Ctx, reason string) Ctx {
+	return ctx.WithValue(balanceChangeReasonKey{}, reason)
+}
+
+// BalanceChangeReason returns the reason of the balance changes made with this context
+func BalanceChangeReason(ctx Ctx) string {
+	if reason, ok := ctx.Value(balanceChangeReasonKey{}).(string); ok && reason != "" {
+		return reason
+	}
+	if len(ctx.TxBytes()) != 0 {
+		return BalanceChangeTx
+	}
+	return BalanceChangeBlock
+}
+
+// WithBalanceChangeAccount records the balance changes made with the returned context against the account instead of
+// the account whose coins moved (e.g. a slash burns the coins of the staked pool on behalf of the slashed node)
+func WithBalanceChangeAccount(ctx Ctx, addr Address) Ctx {
+	return ctx.WithValue(balanceChangeAccountKey{}, addr)
+}
+
+// BalanceChangeAccount returns the account the balance changes made with this context are recorded against, if any
+func BalanceChangeAccount(ctx Ctx) (Address, bool) {
+	addr, ok := ctx.Value(balanceChangeAccountKey{}).(Address)
+	return addr, ok && len(addr) != 0
+}
+
+// AccountHistory stores the balance change log of every account, ordered by height
+type AccountHistory struct {
+	store   dbm.DB
+	l       sync.Mutex
+	pending []AccountChange
+}
+
+func NewAccountHistory(store dbm.DB) *AccountHistory {
+	return &AccountHistory{store: store}
+}
+
+// Buffer queues the changes of a block until the next Flush
+func (h *AccountHistory) Buffer(changes []AccountChange) {
+	h.l.Lock()
+	defer h.l.Unlock()
+	h.pending = append(h.pending, changes...)
+}
+
+// Flush stores the buffered changes. They stay buffered when the write fails, so the next flush retries them
+func (h *AccountHistory) Flush() error {
+	h.l.Lock()
+	defer h.l.Unlock()
+	if len(h.pending) == 0 {
+		return nil
+	}
+	if err := h.AddBatch(h.pending); err != nil {
+		return err
+	}
+	h.pending = nil
+	return nil
+}
+
+// AddBatch stores the changes of one or more blocks in the order they were made. Rewriting a height (e.g. a replayed
+// block) overwrites its previous entries
+func (h *AccountHistory) AddBatch(changes []AccountChange) error {
+	storeBatch := h.store.NewBatch()
+	defer storeBatch.Close()
+	index := 0
+	for i, change := range changes {
+		// the index is the order of the change within its height
+		if i != 0 && change.Height != changes[i-1].Height {
+			index = 0
+		}
+		bz, err := json.Marshal(change)
+		if err != nil {
+			return err
+		}
+		storeBatch.Set(accountChangeKey(change.Address, change.Height, index), bz)
+		index++
+	}
+	return storeBatch.WriteSync()
+}
+
+// Get returns a page of the changes of an account and the total number of changes
+func (h *AccountHistory) Get(addr Address, page, perPage int, sort string) (res []AccountChange, total int, err error) {
+	if page <= 0 || perPage <= 0 || perPage > maxPerPage {
+		return nil, 0, fmt.Errorf("invalid pagination: page %d per page %d", page, perPage)
+	}
+	start, end := accountChangeKey(addr, 0, 0), accountChangeKey(addr, math.MaxInt64, 0)
+	var it dbm.Iterator
+	switch sort {
+	case SortAscending:
+		it, err = h.store.Iterator(start, end)
+	case SortDescending:
+		it, err = h.store.ReverseIterator(start, end)
+	default:
+		return nil, 0, fmt.Errorf("sorting order: %v not supported", sort)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	defer it.Close()
+	skip := (page - 1) * perPage
+	for ; it.Valid(); it.Next() {
+		if total >= skip && len(res) < perPage {
+			var change AccountChange
+			if err = json.Unmarshal(it.Value(), &change); err != nil {
+				return nil, 0, err
+			}
+			res = append(res, change)
+		}
+		total++
+	}
+	return
+}
+
+// keys are ordered by address, height and the order of the change within the height
+func accountChangeKey(addr Address, height int64, index int) []byte {
+	return []byte(fmt.Sprintf("%s/%s/%s", addr.String(), elenEncoder.EncodeInt(int(height)), elenEncoder.EncodeInt(index)))
+}
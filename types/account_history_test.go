@@ -0,0 +1,83 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestAccountHistory_Get(t *testing.T) {
+	history := NewAccountHistory(dbm.NewMemDB())
+	addr, other := Address(make([]byte, AddrLen)), Address(make([]byte, AddrLen))
+	addr[0], other[0] = 1, 2
+	for height := int64(1); height <= 12; height++ {
+		assert.Nil(t, history.AddBatch([]AccountChange{
+			{Height: height, Address: addr, Delta: NewInt(height), Reason: BalanceChangeRelayReward},
+			{Height: height, Address: other, Delta: NewInt(-height), Reason: BalanceChangeFee},
+			{Height: height, Address: addr, Delta: NewInt(-1), Reason: BalanceChangeFee},
+		}))
+	}
+	res, total, err := history.Get(addr, 1, 5, SortDescending)
+	assert.Nil(t, err)
+	assert.Equal(t, 24, total)
+	assert.Len(t, res, 5)
+	// newest first, in reverse order within a height
+	assert.Equal(t, int64(12), res[0].Height)
+	assert.Equal(t, BalanceChangeFee, res[0].Reason)
+	assert.Equal(t, BalanceChangeRelayReward, res[1].Reason)
+	assert.True(t, res[1].Delta.Equal(NewInt(12)))
+	assert.Equal(t, int64(10), res[4].Height)
+	// oldest first
+	res, total, err = history.Get(addr, 5, 5, SortAscending)
+	assert.Nil(t, err)
+	assert.Equal(t, 24, total)
+	assert.Len(t, res, 4)
+	assert.Equal(t, int64(11), res[0].Height)
+	assert.True(t, res[0].Address.Equals(addr))
+	// past the last page
+	res, total, err = history.Get(other, 3, 10, SortAscending)
+	assert.Nil(t, err)
+	assert.Equal(t, 12, total)
+	assert.Empty(t, res)
+	// replaying a height overwrites its entries
+	assert.Nil(t, history.AddBatch([]AccountChange{{Height: 12, Address: addr, Delta: NewInt(12), Reason: BalanceChangeRelayReward}}))
+	_, total, err = history.Get(addr, 1, 5, SortDescending)
+	assert.Nil(t, err)
+	assert.Equal(t, 24, total)
+	_, _, err = history.Get(addr, 0, 5, SortDescending)
+	assert.NotNil(t, err)
+	_, _, err = history.Get(addr, 1, 5, "random")
+	assert.NotNil(t, err)
+}
+
+func TestAccountHistory_Flush(t *testing.T) {
+	history := NewAccountHistory(dbm.NewMemDB())
+	addr := Address(make([]byte, AddrLen))
+	addr[0] = 1
+	history.Buffer([]AccountChange{{Height: 1, Address: addr, Delta: NewInt(1), Reason: BalanceChangeRelayReward}})
+	history.Buffer([]AccountChange{
+		{Height: 2, Address: addr, Delta: NewInt(2), Reason: BalanceChangeRelayReward},
+		{Height: 2, Address: addr, Delta: NewInt(-1), Reason: BalanceChangeFee},
+	})
+	// nothing is stored before the flush
+	_, total, err := history.Get(addr, 1, 5, SortAscending)
+	assert.Nil(t, err)
+	assert.Zero(t, total)
+	assert.Nil(t, history.Flush())
+	res, total, err := history.Get(addr, 1, 5, SortAscending)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, total)
+	assert.Equal(t, int64(1), res[0].Height)
+	assert.Equal(t, BalanceChangeFee, res[2].Reason)
+	// the buffer is emptied
+	assert.Nil(t, history.Flush())
+	_, total, err = history.Get(addr, 1, 5, SortAscending)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, total)
+	// a flushed height is overwritten by a replay of it
+	assert.Nil(t, history.AddBatch([]AccountChange{{Height: 2, Address: addr, Delta: NewInt(2), Reason: BalanceChangeRelayReward}}))
+	_, total, err = history.Get(addr, 1, 5, SortAscending)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, total)
+}
@@ -1,10 +1,13 @@
 package types
 
 import (
-	"github.com/tendermint/tendermint/config"
-	db "github.com/tendermint/tm-db"
+	"net"
+	"net/url"
 	"sync"
 	"time"
+
+	"github.com/tendermint/tendermint/config"
+	db "github.com/tendermint/tm-db"
 )
 
 // TmConfig is the structure that holds the SDK configuration parameters.
@@ -17,33 +20,110 @@ type SDKConfig struct {
 }
 
 type PocketConfig struct {
-	DataDir                  string `json:"data_dir"`
-	GenesisName              string `json:"genesis_file"`
-	ChainsName               string `json:"chains_name"`
-	EvidenceDBName           string `json:"evidence_db_name"`
-	TendermintURI            string `json:"tendermint_uri"`
-	KeybaseName              string `json:"keybase_name"`
-	RPCPort                  string `json:"rpc_port"`
-	ClientBlockSyncAllowance int    `json:"client_block_sync_allowance"`
-	MaxEvidenceCacheEntires  int    `json:"max_evidence_cache_entries"`
-	MaxSessionCacheEntries   int    `json:"max_session_cache_entries"`
-	JSONSortRelayResponses   bool   `json:"json_sort_relay_responses"`
-	RemoteCLIURL             string `json:"remote_cli_url"`
-	UserAgent                string `json:"user_agent"`
-	ValidatorCacheSize       int64  `json:"validator_cache_size"`
-	ApplicationCacheSize     int64  `json:"application_cache_size"`
-	RPCTimeout               int64  `json:"rpc_timeout"`
-	PrometheusAddr           string `json:"pocket_prometheus_port"`
-	PrometheusMaxOpenfiles   int    `json:"prometheus_max_open_files"`
-	MaxClaimAgeForProofRetry int    `json:"max_claim_age_for_proof_retry"`
-	ProofPrevalidation       bool   `json:"proof_prevalidation"`
-	CtxCacheSize             int    `json:"ctx_cache_size"`
-	ABCILogging              bool   `json:"abci_logging"`
-	RelayErrors              bool   `json:"show_relay_errors"`
-	DisableTxEvents          bool   `json:"disable_tx_events"`
-	Cache                    bool   `json:"-"`
-	IavlCacheSize            int64  `json:"iavl_cache_size"`
-	ChainsHotReload          bool   `json:"chains_hot_reload"`
+	DataDir                  string                    `json:"data_dir"`
+	GenesisName              string                    `json:"genesis_file"`
+	ChainsName               string                    `json:"chains_name"`
+	EvidenceDBName           string                    `json:"evidence_db_name"`
+	TendermintURI            string                    `json:"tendermint_uri"`
+	KeybaseName              string                    `json:"keybase_name"`
+	KeybaseBackend           string                    `json:"keybase_backend"`
+	KeystoreDir              string                    `json:"keystore_dir"`
+	KeybaseReadOnly          bool                      `json:"keybase_read_only"`
+	RPCPort                  string                    `json:"rpc_port"`
+	RPCTLSCertFile           string                    `json:"rpc_tls_cert_file"`
+	RPCTLSKeyFile            string                    `json:"rpc_tls_key_file"`
+	RPCTLSClientCAFile       string                    `json:"rpc_tls_client_ca_file"`
+	RPCGroups                map[string]RPCGroupConfig `json:"rpc_groups"`
+	GRPCAddr                 string                    `json:"grpc_addr"`
+	ClientBlockSyncAllowance int                       `json:"client_block_sync_allowance"`
+	MaxEvidenceCacheEntires  int                       `json:"max_evidence_cache_entries"`
+	MaxSessionCacheEntries   int                       `json:"max_session_cache_entries"`
+	JSONSortRelayResponses   bool                      `json:"json_sort_relay_responses"`
+	RemoteCLIURL             string                    `json:"remote_cli_url"`
+	RemoteCLIAdminURL        string                    `json:"remote_cli_admin_url"`
+	RemoteCLICAFile          string                    `json:"remote_cli_ca_file"`
+	RemoteCLICertFile        string                    `json:"remote_cli_cert_file"`
+	RemoteCLIKeyFile         string                    `json:"remote_cli_key_file"`
+	UserAgent                string                    `json:"user_agent"`
+	ValidatorCacheSize       int64                     `json:"validator_cache_size"`
+	ApplicationCacheSize     int64                     `json:"application_cache_size"`
+	RPCTimeout               int64                     `json:"rpc_timeout"`
+	PrometheusAddr           string                    `json:"pocket_prometheus_port"`
+	PrometheusMaxOpenfiles   int                       `json:"prometheus_max_open_files"`
+	MaxClaimAgeForProofRetry int                       `json:"max_claim_age_for_proof_retry"`
+	ProofPrevalidation       bool                      `json:"proof_prevalidation"`
+	CtxCacheSize             int                       `json:"ctx_cache_size"`
+	ABCILogging              bool                      `json:"abci_logging"`
+	RelayErrors              bool                      `json:"show_relay_errors"`
+	DisableTxEvents          bool                      `json:"disable_tx_events"`
+	Cache                    bool                      `json:"-"`
+	IavlCacheSize            int64                     `json:"iavl_cache_size"`
+	ChainsHotReload          bool                      `json:"chains_hot_reload"`
+	MaxEventSubscribers      int                       `json:"max_event_subscribers"`
+	EventOrigins             []string                  `json:"event_origins"`
+	MaxEventLookback         int64                     `json:"max_event_lookback"`
+	AccountHistory           bool                      `json:"account_history"`
+	RemoteSigner             string                    `json:"remote_signer"`
+	MaxRelayBodySize         int64                     `json:"max_relay_body_size"`
+	PrefetchSessions         bool                      `json:"prefetch_sessions"`
+}
+
+// RPCGroupConfig is the listener of a group of rpc routes. The groups without a listen address are served on the
+// rpc port; the groups with the same listen address share their listener
+type RPCGroupConfig struct {
+	ListenAddr    string   `json:"listen_addr"`
+	Timeout       int64    `json:"timeout"`
+	MaxConcurrent int      `json:"max_concurrent"`
+	CORSOrigins   []string `json:"cors_origins"`
+}
+
+// the groups of rpc routes
+const (
+	RPCGroupRelay   = "relay"
+	RPCGroupQuery   = "query"
+	RPCGroupPrivate = "private"
+	RPCGroupDebug   = "debug"
+)
+
+var RPCGroups = []string{RPCGroupRelay, RPCGroupQuery, RPCGroupPrivate, RPCGroupDebug}
+
+// DefaultRPCGroups serves the relay and query routes on the rpc port, and the private and debug routes on localhost only
+func DefaultRPCGroups() map[string]RPCGroupConfig {
+	return map[string]RPCGroupConfig{
+		RPCGroupRelay:   {CORSOrigins: []string{"*"}},
+		RPCGroupQuery:   {},
+		RPCGroupPrivate: {ListenAddr: DefaultRPCAdminListenAddr},
+		RPCGroupDebug:   {ListenAddr: DefaultRPCAdminListenAddr},
+	}
+}
+
+// RPCGroup returns the config of a route group, or its default when the group is not configured
+func (c PocketConfig) RPCGroup(group string) RPCGroupConfig {
+	if g, ok := c.RPCGroups[group]; ok {
+		return g
+	}
+	return DefaultRPCGroups()[group]
+}
+
+// AdminCLIURL returns remote_cli_admin_url, or when it is empty the host of remote_cli_url on the port of the private group
+func (c PocketConfig) AdminCLIURL() (string, error) {
+	if c.RemoteCLIAdminURL != "" {
+		return c.RemoteCLIAdminURL, nil
+	}
+	addr := c.RPCGroup(RPCGroupPrivate).ListenAddr
+	if addr == "" {
+		return c.RemoteCLIURL, nil
+	}
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", err
+	}
+	u, err := url.Parse(c.RemoteCLIURL)
+	if err != nil {
+		return "", err
+	}
+	u.Host = net.JoinHostPort(u.Hostname(), port)
+	return u.String(), nil
 }
 
 type Config struct {
@@ -59,12 +139,20 @@ type AuthToken struct {
 const (
 	DefaultDDName                      = ".pocket"
 	DefaultKeybaseName                 = "pocket-keybase"
+	DefaultKeybaseBackend              = "leveldb"
+	DefaultKeystoreDir                 = "keystore"
+	DefaultKeybaseReadOnly             = false
 	DefaultPVKName                     = "priv_val_key.json"
 	DefaultPVSName                     = "priv_val_state.json"
 	DefaultNKName                      = "node_key.json"
 	DefaultChainsName                  = "chains.json"
 	DefaultGenesisName                 = "genesis.json"
 	DefaultRPCPort                     = "8081"
+	DefaultRPCTLSCertFile              = ""
+	DefaultRPCTLSKeyFile               = ""
+	DefaultRPCTLSClientCAFile          = ""
+	DefaultRPCAdminListenAddr          = "127.0.0.1:8085"
+	DefaultGRPCAddr                    = ""
 	DefaultEvidenceDBName              = "pocket_evidence"
 	DefaultTMURI                       = "tcp://localhost:26657"
 	DefaultMaxSessionCacheEntries      = 500
@@ -83,6 +171,10 @@ const (
 	PlaceholderURL                     = "http://127.0.0.1:8081"
 	PlaceholderServiceURL              = PlaceholderURL
 	DefaultRemoteCLIURL                = "http://localhost:8081"
+	DefaultRemoteCLIAdminURL           = ""
+	DefaultRemoteCLICAFile             = ""
+	DefaultRemoteCLICertFile           = ""
+	DefaultRemoteCLIKeyFile            = ""
 	DefaultUserAgent                   = ""
 	DefaultValidatorCacheSize          = 40000
 	DefaultApplicationCacheSize        = DefaultValidatorCacheSize / 4
@@ -95,8 +187,16 @@ const (
 	DefaultABCILogging                 = false
 	DefaultRelayErrors                 = true
 	AuthFileName                       = "auth.json"
+	AuthTokensFileName                 = "auth_tokens.json"
+	AuditLogFileName                   = "audit.log"
 	DefaultIavlCacheSize               = 5000000
 	DefaultChainHotReload              = false
+	DefaultMaxEventSubscribers         = 0
+	DefaultMaxEventLookback            = 1000
+	DefaultAccountHistory              = false
+	DefaultRemoteSigner                = ""
+	DefaultMaxRelayBodySize            = 1048576
+	DefaultPrefetchSessions            = true
 )
 
 func DefaultConfig(dataDir string) Config {
@@ -109,12 +209,24 @@ func DefaultConfig(dataDir string) Config {
 			EvidenceDBName:           DefaultEvidenceDBName,
 			TendermintURI:            DefaultTMURI,
 			KeybaseName:              DefaultKeybaseName,
+			KeybaseBackend:           DefaultKeybaseBackend,
+			KeystoreDir:              DefaultKeystoreDir,
+			KeybaseReadOnly:          DefaultKeybaseReadOnly,
 			RPCPort:                  DefaultRPCPort,
+			RPCTLSCertFile:           DefaultRPCTLSCertFile,
+			RPCTLSKeyFile:            DefaultRPCTLSKeyFile,
+			RPCTLSClientCAFile:       DefaultRPCTLSClientCAFile,
+			RPCGroups:                DefaultRPCGroups(),
+			GRPCAddr:                 DefaultGRPCAddr,
 			ClientBlockSyncAllowance: DefaultClientBlockSyncAllowance,
 			MaxEvidenceCacheEntires:  DefaultMaxEvidenceCacheEntries,
 			MaxSessionCacheEntries:   DefaultMaxSessionCacheEntries,
 			JSONSortRelayResponses:   DefaultJSONSortRelayResponses,
 			RemoteCLIURL:             DefaultRemoteCLIURL,
+			RemoteCLIAdminURL:        DefaultRemoteCLIAdminURL,
+			RemoteCLICAFile:          DefaultRemoteCLICAFile,
+			RemoteCLICertFile:        DefaultRemoteCLICertFile,
+			RemoteCLIKeyFile:         DefaultRemoteCLIKeyFile,
 			UserAgent:                DefaultUserAgent,
 			ValidatorCacheSize:       DefaultValidatorCacheSize,
 			ApplicationCacheSize:     DefaultApplicationCacheSize,
@@ -129,6 +241,13 @@ func DefaultConfig(dataDir string) Config {
 			DisableTxEvents:          DefaultRPCDisableTransactionEvents,
 			IavlCacheSize:            DefaultIavlCacheSize,
 			ChainsHotReload:          DefaultChainHotReload,
+			MaxEventSubscribers:      DefaultMaxEventSubscribers,
+			EventOrigins:             []string{},
+			MaxEventLookback:         DefaultMaxEventLookback,
+			AccountHistory:           DefaultAccountHistory,
+			RemoteSigner:             DefaultRemoteSigner,
+			MaxRelayBodySize:         DefaultMaxRelayBodySize,
+			PrefetchSessions:         DefaultPrefetchSessions,
 		},
 	}
 	c.TendermintConfig.LevelDBOptions = config.DefaultLevelDBOpts()
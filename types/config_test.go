@@ -0,0 +1,27 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPocketConfig_AdminCLIURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		config PocketConfig
+		want   string
+	}{
+		{"configured", PocketConfig{RemoteCLIURL: "http://localhost:8081", RemoteCLIAdminURL: "https://admin:9000"}, "https://admin:9000"},
+		{"default private group", PocketConfig{RemoteCLIURL: "https://node.example:8081"}, "https://node.example:8085"},
+		{"private group on the rpc port", PocketConfig{RemoteCLIURL: "http://localhost:8081", RPCGroups: map[string]RPCGroupConfig{RPCGroupPrivate: {}}}, "http://localhost:8081"},
+		{"private group on its own port", PocketConfig{RemoteCLIURL: "http://10.0.0.1:8081", RPCGroups: map[string]RPCGroupConfig{RPCGroupPrivate: {ListenAddr: "0.0.0.0:9090"}}}, "http://10.0.0.1:9090"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.config.AdminCLIURL()
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
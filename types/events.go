@@ -74,6 +74,15 @@ func NewAttribute(k, v string) Attribute {
 	return Attribute{k, v}
 }
 
+// NewChainAttributes returns a chain attribute for every relay chain, used to index txs by chain.
+func NewChainAttributes(chains ...string) []Attribute {
+	attrs := make([]Attribute, 0, len(chains))
+	for _, chain := range chains {
+		attrs = append(attrs, NewAttribute(AttributeKeyChain, chain))
+	}
+	return attrs
+}
+
 // EmptyEvents returns an empty slice of events.
 func EmptyEvents() Events {
 	return make(Events, 0)
@@ -123,6 +132,7 @@ var (
 	AttributeKeyModule = "module"
 	AttributeKeySender = "sender"
 	AttributeKeyAmount = "amount"
+	AttributeKeyChain  = "chain"
 )
 
 type (
@@ -12,6 +12,7 @@ import (
 	"github.com/tendermint/tendermint/types"
 	dbm "github.com/tendermint/tm-db"
 	"math"
+	"strconv"
 )
 
 var (
@@ -29,6 +30,10 @@ const (
 	TxSignerKey         = "tx.signer"
 	TxRecipientKey      = "tx.recipient"
 	TxHashKey           = "tx.hash"
+	TxMessageTypeKey    = "tx.message_type"
+	TxChainKey          = "tx.chain"
+	TxCodeKey           = "tx.code"
+	TxFeePayerKey       = "tx.fee_payer" // the fee is always deducted from the signer, so this is an alias of tx.signer
 	SortAscending       = "asc"
 	SortDescending      = "desc"
 	AuthCodespace       = "auth"
@@ -53,27 +58,9 @@ func (t *TransactionIndexer) AddBatch(b *txindex.Batch) error {
 		if result.Result.Codespace == AuthCodespace && result.Result.Code < AnteHandlerMaxError {
 			continue // don't index any ante handler level errors
 		}
-		hash := result.Tx.Hash()
-
-		// index tx by sender
-		if result.Result.Signer != nil {
-			storeBatch.Set(keyForSigner(result), hash)
-		}
-
-		// index tx by recipient
-		if result.Result.Recipient != nil {
-			storeBatch.Set(keyForRecipient(result), hash)
-		}
-
-		// index tx by height
-		storeBatch.Set(keyForHeight(result), hash)
-
-		// index tx by hash
-		rawBytes, err := cdc.MarshalBinaryBare(result, 0) // TODO make protobuf compatible
-		if err != nil {
+		if err := indexResult(storeBatch, result); err != nil {
 			return err
 		}
-		storeBatch.Set(hash, rawBytes)
 	}
 
 	return storeBatch.WriteSync()
@@ -85,28 +72,25 @@ func (t *TransactionIndexer) Index(result *types.TxResult) error {
 	if result.Result.Codespace == AuthCodespace && result.Result.Code < AnteHandlerMaxError {
 		return nil // no indexing for ante handler level errors
 	}
-	hash := result.Tx.Hash()
-	// index tx by sender
-	if result.Result.Signer != nil {
-		storeBatch.Set(keyForSigner(result), hash)
+	if err := indexResult(storeBatch, result); err != nil {
+		return err
 	}
+	return storeBatch.WriteSync()
+}
 
-	// index tx by recipient
-	if result.Result.Recipient != nil {
-		storeBatch.Set(keyForRecipient(result), hash)
+// index the tx by every key in indexKeys and store the result by hash
+func indexResult(storeBatch dbm.Batch, result *types.TxResult) error {
+	hash := result.Tx.Hash()
+	for _, key := range indexKeys(result) {
+		storeBatch.Set(key, hash)
 	}
-
-	// index tx by height
-	storeBatch.Set(keyForHeight(result), hash)
-
 	// index tx by hash
 	rawBytes, err := cdc.MarshalBinaryBare(result, 0) // TODO make protobuf compatible
 	if err != nil {
 		return err
 	}
 	storeBatch.Set(hash, rawBytes)
-
-	return storeBatch.WriteSync()
+	return nil
 }
 
 func (t *TransactionIndexer) Get(hash []byte) (*types.TxResult, error) {
@@ -128,34 +112,195 @@ func (t *TransactionIndexer) Get(hash []byte) (*types.TxResult, error) {
 	return txResult, nil
 }
 
-// NOTE: Only supports op.Equal for hash, height, signer, or recipient, we only support op.Equal for simplicity and
-// optimization of our use case
+// TxChains returns the relay chains of the msg of the tx bytes, decoded at the height of the tx
+type TxChains func(txBytes []byte, height int64) []string
+
+// Reindex re-indexes every stored tx result under the current index keys, so the txs indexed before an index key was
+// added (e.g. message type, chain and code) can be searched by it. The txs stored before their chains were emitted as
+// event attributes get the chains of their msg, read with txChains (nil = not backfilled), as a message event. Returns
+// the number of txs re-indexed
+func (t *TransactionIndexer) Reindex(txChains TxChains) (int, error) {
+	const batchSize = 1000
+	it, err := PrefixIterator(t.store, []byte(TxHeightKey+sep), SortAscending)
+	if err != nil {
+		return 0, err
+	}
+	defer it.Close()
+	storeBatch := t.store.NewBatch()
+	count := 0
+	for ; it.Valid(); it.Next() {
+		result, err := t.Get(it.Value())
+		if err != nil {
+			storeBatch.Close()
+			return count, err
+		}
+		if result == nil {
+			continue
+		}
+		if txChains != nil && len(TxIndexValues(result)[TxChainKey]) == 0 {
+			if chains := txChains(result.Tx, result.Height); len(chains) != 0 {
+				result.Result.Events = append(result.Result.Events, NewEvent(EventTypeMessage, NewChainAttributes(chains...)...))
+			}
+		}
+		if err = indexResult(storeBatch, result); err != nil {
+			storeBatch.Close()
+			return count, err
+		}
+		count++
+		if count%batchSize == 0 {
+			if err = storeBatch.Write(); err != nil {
+				storeBatch.Close()
+				return count, err
+			}
+			storeBatch.Close()
+			storeBatch = t.store.NewBatch()
+		}
+	}
+	defer storeBatch.Close()
+	return count, storeBatch.WriteSync()
+}
+
+// NOTE: Supports AND combined conditions of op.Equal for hash, signer (fee payer), recipient, message type, chain and code,
+// and op.Equal, op.Less, op.LessEqual, op.Greater and op.GreaterEqual for height. The most selective equal condition is
+// iterated (bounded by the height range) and the rest of the conditions are matched against the results
 func (t *TransactionIndexer) Search(ctx context.Context, q *query.Query) (res []*types.TxResult, total int, err error) {
-	condition, err := q.Condition()
+	conditions, err := q.Conditions()
 	if err != nil {
-		return nil, 0, errors.Wrap(err, "error during parsing condition from query")
+		return nil, 0, errors.Wrap(err, "error during parsing conditions from query")
 	}
 
 	if q.Pagination.Size > maxPerPage {
 		q.Pagination.Size = maxPerPage
 	}
 
-	if condition.Op != query.OpEqual {
-		return nil, 0, fmt.Errorf("transaction indexer only supports op.Equal not %v", condition.Op)
+	s, err := newTxSearch(conditions)
+	if err != nil {
+		return nil, 0, err
+	}
+	if s.hash != nil {
+		return t.hashQuery(s)
+	}
+	return t.getByRange(s, q.Pagination)
+}
+
+// txSearch is the parsed form of the AND combined query conditions
+type txSearch struct {
+	hash      []byte
+	minHeight int64
+	maxHeight int64
+	equals    map[string][]string // index key -> values that must all be present
+}
+
+// the order in which equal conditions are preferred as the iterated index (most selective first)
+var searchKeyPriority = []string{TxSignerKey, TxRecipientKey, TxChainKey, TxMessageTypeKey, TxCodeKey}
+
+func newTxSearch(conditions []query.Condition) (s txSearch, err error) {
+	s = txSearch{maxHeight: math.MaxInt64, equals: make(map[string][]string)}
+	for _, c := range conditions {
+		if c.CompositeKey == TxHeightKey {
+			if err = s.addHeightCondition(c); err != nil {
+				return
+			}
+			continue
+		}
+		if c.Op != query.OpEqual {
+			return s, fmt.Errorf("transaction indexer only supports op.Equal for %s not %v", c.CompositeKey, c.Op)
+		}
+		var value string
+		switch c.CompositeKey {
+		case TxHashKey:
+			operand, ok := c.Operand.(string)
+			if !ok {
+				return s, errors.New("error during searching for a hash in the query, c.Operand not type string")
+			}
+			s.hash, err = hex.DecodeString(operand)
+			if err != nil {
+				return s, errors.Wrap(err, "error during searching for a hash in the query")
+			}
+			continue
+		case TxSignerKey, TxFeePayerKey, TxRecipientKey:
+			operand, ok := c.Operand.(string)
+			if !ok {
+				return s, fmt.Errorf("error during searching for a address in the query, %s operand not type string", c.CompositeKey)
+			}
+			addr, er := hex.DecodeString(operand)
+			if er != nil {
+				return s, errors.Wrap(er, "error during searching for a address in the query")
+			}
+			value = Address(addr).String()
+		case TxMessageTypeKey, TxChainKey:
+			operand, ok := c.Operand.(string)
+			if !ok {
+				return s, fmt.Errorf("error during searching for %s in the query, c.Operand not type string", c.CompositeKey)
+			}
+			value = operand
+		case TxCodeKey:
+			operand, ok := c.Operand.(int64)
+			if !ok {
+				return s, errors.New("error during searching for a code in the query, c.Operand not type int64")
+			}
+			value = strconv.FormatInt(operand, 10)
+		default:
+			return s, fmt.Errorf("Condition.CompositeKey: %v not supported on this indexer", c.CompositeKey)
+		}
+		key := c.CompositeKey
+		if key == TxFeePayerKey {
+			key = TxSignerKey
+		}
+		s.equals[key] = append(s.equals[key], value)
+	}
+	if s.minHeight > s.maxHeight {
+		return s, fmt.Errorf("empty height range: %d to %d", s.minHeight, s.maxHeight)
 	}
+	return
+}
 
-	switch condition.CompositeKey {
-	case TxHeightKey:
-		return t.heightQuery(condition, q.Pagination)
-	case TxSignerKey:
-		return t.signerQuery(condition, q.Pagination)
-	case TxRecipientKey:
-		return t.recipientQuery(condition, q.Pagination)
-	case TxHashKey:
-		return t.hashQuery(condition)
+func (s *txSearch) addHeightCondition(c query.Condition) error {
+	height, ok := c.Operand.(int64)
+	if !ok {
+		return errors.New("error during searching for a height in the query, c.Operand not type int64")
+	}
+	switch c.Op {
+	case query.OpEqual:
+		s.minHeight, s.maxHeight = max64(s.minHeight, height), min64(s.maxHeight, height)
+	case query.OpGreaterEqual:
+		s.minHeight = max64(s.minHeight, height)
+	case query.OpGreater:
+		s.minHeight = max64(s.minHeight, height+1)
+	case query.OpLessEqual:
+		s.maxHeight = min64(s.maxHeight, height)
+	case query.OpLess:
+		s.maxHeight = min64(s.maxHeight, height-1)
 	default:
-		return nil, 0, fmt.Errorf("Condition.CompositeKey: %v not supported on this indexer", condition.CompositeKey)
+		return fmt.Errorf("transaction indexer does not support %v for %s", c.Op, TxHeightKey)
 	}
+	return nil
+}
+
+// the index the search iterates over and the value within it ("" for the height index)
+func (s txSearch) iterated() (key, value string) {
+	for _, k := range searchKeyPriority {
+		if values, ok := s.equals[k]; ok {
+			return k, values[0]
+		}
+	}
+	return TxHeightKey, ""
+}
+
+// whether the tx result satisfies every condition of the search
+func (s txSearch) matches(result *types.TxResult) bool {
+	if result == nil || result.Height < s.minHeight || result.Height > s.maxHeight {
+		return false
+	}
+	values := TxIndexValues(result)
+	for key, want := range s.equals {
+		for _, w := range want {
+			if !ContainsString(values[key], w) {
+				return false
+			}
+		}
+	}
+	return true
 }
 
 func (t *TransactionIndexer) DeleteFromHeight(ctx context.Context, height int64) error {
@@ -175,64 +320,61 @@ func (t *TransactionIndexer) DeleteFromHeight(ctx context.Context, height int64)
 	b := t.store.NewBatch()
 	defer b.Close()
 	for ; it.Valid(); it.Next() {
+		// remove the secondary indexes of the tx before the tx itself
+		if result, err := t.Get(it.Value()); err == nil && result != nil {
+			for _, key := range indexKeys(result) {
+				b.Delete(key)
+			}
+		}
 		b.Delete(it.Value())
 	}
 	return b.WriteSync()
 }
 
-func (t *TransactionIndexer) hashQuery(condition query.Condition) (res []*types.TxResult, total int, err error) {
-	hash, err := hex.DecodeString(condition.Operand.(string))
+func (t *TransactionIndexer) hashQuery(s txSearch) (res []*types.TxResult, total int, err error) {
+	result, err := t.Get(s.hash)
 	if err != nil {
-		return nil, 0, errors.Wrap(err, "error during searching for a hash in the query")
-	}
-	result, err := t.Get(hash)
-	if err == nil {
-		total = 1
-	}
-	return []*types.TxResult{result}, total, err
-}
-
-func (t *TransactionIndexer) heightQuery(condition query.Condition, pagination *query.Page) (res []*types.TxResult, total int, err error) {
-	height, ok := condition.Operand.(int64)
-	if !ok {
-		return nil, 0, errors.New("error during searching for a height in the query, c.Operand not type int64")
+		return []*types.TxResult{result}, 0, err
 	}
-	return t.getByPrefix(prefixKeyForHeight(height), pagination)
-}
-
-func (t *TransactionIndexer) signerQuery(condition query.Condition, pagination *query.Page) (res []*types.TxResult, total int, err error) {
-	signer, err := hex.DecodeString(condition.Operand.(string))
-	if err != nil {
-		return nil, 0, errors.Wrap(err, "error during searching for a address in the query")
-	}
-	return t.getByPrefix(prefixKeyForSigner(signer), pagination)
-}
-
-func (t *TransactionIndexer) recipientQuery(condition query.Condition, pagination *query.Page) (res []*types.TxResult, total int, err error) {
-	recipient, err := hex.DecodeString(condition.Operand.(string))
-	if err != nil {
-		return nil, 0, errors.Wrap(err, "error during searching for a address in the query")
+	if !s.matches(result) {
+		return nil, 0, nil
 	}
-	return t.getByPrefix(prefixKeyForRecipient(recipient), pagination)
+	return []*types.TxResult{result}, 1, nil
 }
 
-func (t *TransactionIndexer) getByPrefix(prefix []byte, pagination *query.Page) (res []*types.TxResult, total int, err error) {
-	it, err := PrefixIterator(t.store, prefix, pagination.Sort)
+func (t *TransactionIndexer) getByRange(s txSearch, pagination *query.Page) (res []*types.TxResult, total int, err error) {
+	key, value := s.iterated()
+	start, end := rangeKeys(key, value, s.minHeight, s.maxHeight)
+	it, err := RangeIterator(t.store, start, end, pagination.Sort)
 	if err != nil {
-		return nil, 0, errors.Wrap(err, "error creating prefix iterator")
+		return nil, 0, errors.Wrap(err, "error creating range iterator")
 	}
 	defer it.Close()
+	// only the iterated condition is guaranteed by the index, anything more must be matched against the result
+	filter := len(s.equals) > 1 || (len(s.equals) == 1 && len(s.equals[key]) > 1)
 	for i, skipCount := 0, 0; it.Valid(); it.Next() {
+		var val *types.TxResult
+		if filter {
+			val, err = t.Get(it.Value())
+			if err != nil {
+				return nil, 0, errors.Wrap(err, "error during query iteration get()")
+			}
+			if !s.matches(val) {
+				continue
+			}
+		}
 		if skipCount < pagination.Skip {
 			skipCount++
 			total++
 			continue
 		}
-		val, err := t.Get(it.Value())
-		if err != nil {
-			return nil, 0, errors.Wrap(err, "error during query iteration get()")
-		}
 		if i < pagination.Size {
+			if val == nil {
+				val, err = t.Get(it.Value())
+				if err != nil {
+					return nil, 0, errors.Wrap(err, "error during query iteration get()")
+				}
+			}
 			res = append(res, val)
 		}
 		total++
@@ -241,62 +383,85 @@ func (t *TransactionIndexer) getByPrefix(prefix []byte, pagination *query.Page)
 	return
 }
 
-func keyForHeight(result *types.TxResult) []byte {
-	return []byte(fmt.Sprintf("%s/%s/%s",
-		TxHeightKey,
-		elenEncoder.EncodeInt(int(result.Height)),
-		elenEncoder.EncodeInt(int(result.Index)),
-	))
+// TxIndexValues returns the values a tx result is indexed under for every index key (except height and hash)
+func TxIndexValues(result *types.TxResult) map[string][]string {
+	values := map[string][]string{
+		TxCodeKey: {strconv.FormatUint(uint64(result.Result.Code), 10)},
+	}
+	if result.Result.Signer != nil {
+		values[TxSignerKey] = []string{Address(result.Result.Signer).String()}
+	}
+	if result.Result.Recipient != nil {
+		values[TxRecipientKey] = []string{Address(result.Result.Recipient).String()}
+	}
+	if result.Result.MessageType != "" {
+		values[TxMessageTypeKey] = []string{result.Result.MessageType}
+	}
+	// chains are emitted as event attributes by the stake, claim and proof handlers
+	for _, event := range result.Result.Events {
+		for _, attr := range event.Attributes {
+			if string(attr.Key) == AttributeKeyChain && !ContainsString(values[TxChainKey], string(attr.Value)) {
+				values[TxChainKey] = append(values[TxChainKey], string(attr.Value))
+			}
+		}
+	}
+	return values
 }
 
-func prefixKeyForHeight(height int64) []byte {
-	return []byte(fmt.Sprintf("%s/%s/",
-		TxHeightKey,
-		elenEncoder.EncodeInt(int(height)),
-	))
+// indexKeys returns every secondary index key of a tx result
+func indexKeys(result *types.TxResult) (keys [][]byte) {
+	keys = append(keys, keyForHeight(result))
+	for key, values := range TxIndexValues(result) {
+		for _, value := range values {
+			keys = append(keys, keyFor(key, value, result))
+		}
+	}
+	return
 }
 
-func keyForSigner(result *types.TxResult) []byte {
-	return []byte(fmt.Sprintf("%s/%s/%s/%s",
-		TxSignerKey,
-		Address(result.Result.Signer),
+func keyForHeight(result *types.TxResult) []byte {
+	return []byte(fmt.Sprintf("%s/%s/%s",
+		TxHeightKey,
 		elenEncoder.EncodeInt(int(result.Height)),
 		elenEncoder.EncodeInt(int(result.Index)),
 	))
 }
 
-func prefixKeyForSigner(signer Address) []byte {
-	return []byte(fmt.Sprintf("%s/%s/%s",
-		TxSignerKey,
-		signer,
-		elenEncoder.EncodeInt(0),
-	))
-}
-
-func keyForRecipient(result *types.TxResult) []byte {
+func keyFor(key, value string, result *types.TxResult) []byte {
 	return []byte(fmt.Sprintf("%s/%s/%s/%s",
-		TxRecipientKey,
-		Address(result.Result.Recipient),
+		key,
+		value,
 		elenEncoder.EncodeInt(int(result.Height)),
 		elenEncoder.EncodeInt(int(result.Index)),
 	))
 }
 
-func prefixKeyForRecipient(recipient Address) []byte {
-	return []byte(fmt.Sprintf("%s/%s/%s",
-		TxRecipientKey,
-		recipient,
-		elenEncoder.EncodeInt(0),
-	))
+// rangeKeys returns the [start, end) keys of an index (and value) between two heights, inclusive
+func rangeKeys(key, value string, minHeight, maxHeight int64) (start, end []byte) {
+	prefix := key
+	if key != TxHeightKey {
+		prefix = key + sep + value
+	}
+	if maxHeight < math.MaxInt64 {
+		maxHeight++
+	}
+	start = []byte(fmt.Sprintf("%s/%s", prefix, elenEncoder.EncodeInt(int(minHeight))))
+	end = []byte(fmt.Sprintf("%s/%s", prefix, elenEncoder.EncodeInt(int(maxHeight))))
+	return
 }
 
 // contract: caller must close iterator
 func PrefixIterator(db dbm.DB, prefix []byte, order string) (dbm.Iterator, error) {
+	return RangeIterator(db, prefix, endKey(prefix), order)
+}
+
+// contract: caller must close iterator
+func RangeIterator(db dbm.DB, start, end []byte, order string) (dbm.Iterator, error) {
 	switch order {
 	case SortAscending:
-		return db.ReverseIterator(prefix, endKey(prefix))
+		return db.ReverseIterator(start, end)
 	case SortDescending:
-		return db.Iterator(prefix, endKey(prefix))
+		return db.Iterator(start, end)
 	default:
 		return nil, fmt.Errorf("sorting order: %v not supported", order)
 	}
@@ -307,3 +472,17 @@ func endKey(prefix []byte) []byte {
 	bz = append(bz[:len(bz)-1], []byte(elenEncoder.EncodeInt(math.MaxInt64)))
 	return bytes.Join(bz[:], []byte(sep))
 }
+
+func min64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,168 @@
+package types
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/state/txindex"
+	"github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func newTestTxResult(height int64, index uint32, signer, recipient Address, msgType string, code uint32, chains ...string) *types.TxResult {
+	return &types.TxResult{
+		Height: height,
+		Index:  index,
+		Tx:     types.Tx(fmt.Sprintf("tx-%d-%d", height, index)),
+		Result: abci.ResponseDeliverTx{
+			Code:        code,
+			Events:      []abci.Event{NewEvent(EventTypeMessage, NewChainAttributes(chains...)...)},
+			Signer:      signer,
+			Recipient:   recipient,
+			MessageType: msgType,
+		},
+	}
+}
+
+func newTestIndexer(t *testing.T) (*TransactionIndexer, Address, Address) {
+	signer, recipient := Address(make([]byte, AddrLen)), Address(make([]byte, AddrLen))
+	signer[0], recipient[0] = 1, 2
+	indexer := NewTransactionIndexer(dbm.NewMemDB())
+	var ops []*types.TxResult
+	for height := int64(1); height <= 10; height++ {
+		ops = append(ops,
+			newTestTxResult(height, 0, signer, recipient, "send", 0),
+			newTestTxResult(height, 1, signer, nil, "claim", 0, "0001"),
+			newTestTxResult(height, 2, recipient, nil, "stake_validator", 5, "0001", "0002"),
+		)
+	}
+	assert.Nil(t, indexer.AddBatch(&txindex.Batch{Ops: ops}))
+	return indexer, signer, recipient
+}
+
+func search(t *testing.T, indexer *TransactionIndexer, q string, page, perPage int) ([]*types.TxResult, int, error) {
+	parsed, err := query.New(q)
+	assert.Nil(t, err)
+	parsed.AddPage(perPage, (page-1)*perPage, SortDescending)
+	return indexer.Search(context.Background(), parsed)
+}
+
+func TestTransactionIndexer_Search(t *testing.T) {
+	indexer, signer, recipient := newTestIndexer(t)
+	tests := []struct {
+		name  string
+		query string
+		total int
+	}{
+		{"height equal", "tx.height=3", 3},
+		{"height range", "tx.height>=3 AND tx.height<6", 9},
+		{"height exclusive range", "tx.height>3 AND tx.height<=6", 9},
+		{"signer", fmt.Sprintf("tx.signer='%s'", hex.EncodeToString(signer)), 20},
+		{"fee payer alias", fmt.Sprintf("tx.fee_payer='%s'", hex.EncodeToString(signer)), 20},
+		{"recipient", fmt.Sprintf("tx.recipient='%s'", hex.EncodeToString(recipient)), 10},
+		{"message type", "tx.message_type='claim'", 10},
+		{"chain", "tx.chain='0001'", 20},
+		{"second chain", "tx.chain='0002'", 10},
+		{"both chains", "tx.chain='0001' AND tx.chain='0002'", 10},
+		{"code", "tx.code=5", 10},
+		{"message type and chain", "tx.message_type='claim' AND tx.chain='0001'", 10},
+		{"message type and code", "tx.message_type='claim' AND tx.code=5", 0},
+		{"signer, chain and height range", fmt.Sprintf("tx.signer='%s' AND tx.chain='0001' AND tx.height>8", hex.EncodeToString(signer)), 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, total, err := search(t, indexer, tt.query, 1, 100)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.total, total)
+			assert.Len(t, res, tt.total)
+		})
+	}
+}
+
+func TestTransactionIndexer_SearchPagination(t *testing.T) {
+	indexer, _, _ := newTestIndexer(t)
+	res, total, err := search(t, indexer, "tx.chain='0001'", 2, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, 20, total)
+	assert.Len(t, res, 5)
+	// the second page of the filtered (multi condition) search
+	res, total, err = search(t, indexer, "tx.chain='0001' AND tx.code=0", 2, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, 10, total)
+	assert.Len(t, res, 4)
+	for _, r := range res {
+		assert.Equal(t, "claim", r.Result.MessageType)
+	}
+}
+
+func TestTransactionIndexer_SearchErrors(t *testing.T) {
+	indexer, _, _ := newTestIndexer(t)
+	for _, q := range []string{
+		"tx.code>1",
+		"tx.height>5 AND tx.height<3",
+		"tx.chain=1",
+		"tx.unknown='a'",
+	} {
+		_, _, err := search(t, indexer, q, 1, 10)
+		assert.NotNil(t, err, q)
+	}
+}
+
+func TestTransactionIndexer_DeleteFromHeight(t *testing.T) {
+	indexer, _, _ := newTestIndexer(t)
+	assert.Nil(t, indexer.DeleteFromHeight(context.Background(), 6))
+	for _, q := range []string{"tx.message_type='claim'", "tx.code=5", "tx.height>0"} {
+		res, _, err := search(t, indexer, q, 1, 100)
+		assert.Nil(t, err)
+		for _, r := range res {
+			assert.True(t, r.Height < 6, q)
+		}
+	}
+	_, total, err := search(t, indexer, "tx.chain='0002'", 1, 100)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, total)
+}
+
+func TestTransactionIndexer_Reindex(t *testing.T) {
+	db := dbm.NewMemDB()
+	indexer := NewTransactionIndexer(db)
+	// a claim indexed before the message type, chain and code indexes: only by height and hash, and without the chain
+	// event attributes
+	result := newTestTxResult(1, 0, nil, nil, "claim", 0)
+	result.Result.Events = []abci.Event{NewEvent("claim", NewAttribute("validator", "a0"))}
+	rawBytes, err := cdc.MarshalBinaryBare(result, 0)
+	assert.Nil(t, err)
+	assert.Nil(t, db.Set(keyForHeight(result), result.Tx.Hash()))
+	assert.Nil(t, db.Set(result.Tx.Hash(), rawBytes))
+	_, total, err := search(t, indexer, "tx.chain='0001'", 1, 100)
+	assert.Nil(t, err)
+	assert.Zero(t, total)
+	// without the chains of the msg, the chain isn't backfilled
+	count, err := indexer.Reindex(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+	_, total, err = search(t, indexer, "tx.chain='0001'", 1, 100)
+	assert.Nil(t, err)
+	assert.Zero(t, total)
+	// the chain is read from the msg of the tx
+	count, err = indexer.Reindex(func(txBytes []byte, height int64) []string {
+		assert.Equal(t, []byte(result.Tx), txBytes)
+		assert.Equal(t, result.Height, height)
+		return []string{"0001"}
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+	for _, q := range []string{"tx.chain='0001'", "tx.message_type='claim'", "tx.code=0", "tx.height=1", "tx.message_type='claim' AND tx.chain='0001'"} {
+		_, total, err = search(t, indexer, q, 1, 100)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, total, q)
+	}
+	got, err := indexer.Get(result.Tx.Hash())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"0001"}, TxIndexValues(got)[TxChainKey])
+}
@@ -72,16 +72,14 @@ func (s Subspace) WithKeyTable(table KeyTable) Subspace {
 
 // Returns a KVStore identical with ctx.KVStore(s.key).Prefix()
 func (s Subspace) kvStore(ctx Ctx) KVStore {
-	// append here is safe, appends within a function won't cause
-	// weird side effects when its singlethreaded
-	return prefix.NewStore(ctx.KVStore(s.key), append(s.name, '/'))
+	// the prefix is copied, the params are read concurrently (e.g. by the relays)
+	return prefix.NewStore(ctx.KVStore(s.key), concatKeys(s.name, nil))
 }
 
 // Returns a transient store for modification
 func (s Subspace) transientStore(ctx Ctx) KVStore {
-	// append here is safe, appends within a function won't cause
-	// weird side effects when its singlethreaded
-	return prefix.NewStore(ctx.TransientStore(s.tkey), append(s.name, '/'))
+	// the prefix is copied, the params are read concurrently (e.g. by the relays)
+	return prefix.NewStore(ctx.TransientStore(s.tkey), concatKeys(s.name, nil))
 }
 
 func concatKeys(key, subkey []byte) (res []byte) {
@@ -101,6 +101,22 @@ func NewLevelDB(name, dir string, o *opt.Options) (db dbm.DB, err error) {
 	return db, err
 }
 
+// NewDB instantiate a new database instance of the given tm-db backend.
+// goleveldb (or an empty backend) honors the leveldb options, every other backend
+// must be registered (compiled in with its build tag) in tm-db.
+func NewDB(name, dir string, backend dbm.BackendType, o *opt.Options) (db dbm.DB, err error) {
+	if backend == "" || backend == dbm.GoLevelDBBackend {
+		return NewLevelDB(name, dir, o)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("couldn't create %s db: %v", backend, r)
+		}
+	}()
+	db = dbm.NewDB(name, backend, dir)
+	return db, err
+}
+
 // Raw is a raw encoded JSON value.
 // It implements Marshaler and Unmarshaler and can
 // be used to delay JSON decoding or precompute a JSON encoding.
@@ -141,3 +157,13 @@ func TimeTrack(start time.Time) {
 		log.Println(fmt.Sprintf("%s took %s", name, elapsed))
 	}
 }
+
+// ContainsString returns whether the slice contains the string
+func ContainsString(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
 )
 
 func TestSortJSON(t *testing.T) {
@@ -64,3 +65,15 @@ func TestTimeFormatAndParse(t *testing.T) {
 		require.Equal(t, timeFromRFC.Format(SortableTimeFormat), tc.SDKSortableTimeStr)
 	}
 }
+
+func TestNewDB(t *testing.T) {
+	db, err := NewDB("test", t.TempDir(), dbm.MemDBBackend, nil)
+	require.Nil(t, err)
+	require.IsType(t, &dbm.MemDB{}, db)
+	db, err = NewDB("test", t.TempDir(), "", nil)
+	require.Nil(t, err)
+	require.IsType(t, &dbm.GoLevelDB{}, db)
+	require.Nil(t, db.Close())
+	_, err = NewDB("test", t.TempDir(), "unknowndb", nil)
+	require.NotNil(t, err)
+}
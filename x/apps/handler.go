@@ -56,9 +56,11 @@ func handleStake(ctx sdk.Ctx, msg types.MsgStake, k keeper.Keeper) sdk.Result {
 		),
 		sdk.NewEvent(
 			types.EventTypeStake,
-			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
-			sdk.NewAttribute(sdk.AttributeKeySender, sdk.Address(addr).String()),
-			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Value.String()),
+			append([]sdk.Attribute{
+				sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+				sdk.NewAttribute(sdk.AttributeKeySender, sdk.Address(addr).String()),
+				sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Value.String()),
+			}, sdk.NewChainAttributes(msg.Chains...)...)...,
 		),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
@@ -14,6 +14,7 @@ import (
 const (
 	ModuleName        = types.ModuleName
 	StoreKey          = types.StoreKey
+	TStoreKey         = types.TStoreKey
 	FeeCollectorName  = types.FeeCollectorName
 	QuerierRoute      = types.QuerierRoute
 	DefaultParamspace = types.DefaultCodespace
@@ -49,4 +50,5 @@ type (
 	StdSignDoc         = types.StdSignDoc
 	StdSignature       = types.ProtoStdSignature
 	TxBuilder          = types.TxBuilder
+	TxSigner           = types.TxSigner
 )
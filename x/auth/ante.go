@@ -191,7 +191,7 @@ func DeductFees(keeper keeper.Keeper, ctx sdk.Ctx, tx types.StdTx, signer posCry
 	if hasNeg {
 		return types.ErrInsufficientBalance(ModuleName, acc.GetAddress(), fees)
 	}
-	err = keeper.SendCoinsFromAccountToModule(ctx, acc.GetAddress(), types.FeeCollectorName, fees)
+	err = keeper.SendCoinsFromAccountToModule(sdk.WithBalanceChangeReason(ctx, sdk.BalanceChangeFee), acc.GetAddress(), types.FeeCollectorName, fees)
 	if err != nil {
 		return err
 	}
@@ -161,7 +161,9 @@ func (k Keeper) SubtractCoins(ctx sdk.Ctx, addr sdk.Address, amt sdk.Coins) (sdk
 
 	newCoins := oldCoins.Sub(amt) // should not panic as spendable coins was already checked
 	err := k.SetCoins(ctx, addr, newCoins)
-
+	if err == nil {
+		k.recordBalanceChange(ctx, addr, amt.AmountOf(sdk.DefaultStakeDenom).Neg())
+	}
 	return newCoins, err
 }
 
@@ -182,6 +184,9 @@ func (k Keeper) AddCoins(ctx sdk.Ctx, addr sdk.Address, amt sdk.Coins) (sdk.Coin
 	}
 
 	err := k.SetCoins(ctx, addr, newCoins)
+	if err == nil {
+		k.recordBalanceChange(ctx, addr, amt.AmountOf(sdk.DefaultStakeDenom))
+	}
 	return newCoins, err
 }
 
@@ -0,0 +1,74 @@
+package keeper
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/pokt-network/pocket-core/types"
+	"github.com/pokt-network/pocket-core/x/auth/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+// EnableHistory turns on the balance change log, the changes of a block are kept in the transient store tkey.
+// Must be called before the keeper is handed to the other keepers
+func (k *Keeper) EnableHistory(tkey sdk.StoreKey) {
+	k.historyKey = tkey
+}
+
+// HistoryEnabled returns whether the balance changes are logged
+func (k Keeper) HistoryEnabled() bool {
+	return k.historyKey != nil
+}
+
+// recordBalanceChange logs a change of the account balance with the reason (and account, see
+// sdk.WithBalanceChangeAccount) of the context. The log is written
+// next to the account in the same multistore, so it's discarded along with the account writes of a dropped cache
+// (e.g. the ante handler of a CheckTx)
+func (k Keeper) recordBalanceChange(ctx sdk.Ctx, addr sdk.Address, delta sdk.BigInt) {
+	if k.historyKey == nil || delta.IsZero() {
+		return
+	}
+	change := sdk.AccountChange{
+		Height:  ctx.BlockHeight(),
+		Address: addr,
+		Delta:   delta,
+		Reason:  sdk.BalanceChangeReason(ctx),
+	}
+	if account, ok := sdk.BalanceChangeAccount(ctx); ok {
+		change.Address = account
+	}
+	if txBytes := ctx.TxBytes(); len(txBytes) != 0 {
+		change.TxHash = fmt.Sprintf("%X", tmhash.Sum(txBytes))
+	}
+	bz, err := json.Marshal(change)
+	if err != nil {
+		k.Logger(ctx).Error(fmt.Sprintf("unable to marshal the balance change of %s: %s", addr.String(), err.Error()))
+		return
+	}
+	store := ctx.TransientStore(k.historyKey)
+	var seq uint64
+	if countBz, _ := store.Get(types.BalanceChangeCountKey); countBz != nil {
+		seq = binary.BigEndian.Uint64(countBz)
+	}
+	_ = store.Set(types.BalanceChangeKey(seq), bz)
+	_ = store.Set(types.BalanceChangeCountKey, sdk.Uint64ToBigEndian(seq+1))
+}
+
+// GetBalanceChanges returns the balance changes logged during the current block, in order
+func (k Keeper) GetBalanceChanges(ctx sdk.Ctx) (changes []sdk.AccountChange) {
+	if k.historyKey == nil {
+		return nil
+	}
+	it, _ := sdk.KVStorePrefixIterator(ctx.TransientStore(k.historyKey), types.BalanceChangeKeyPrefix)
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		var change sdk.AccountChange
+		if err := json.Unmarshal(it.Value(), &change); err != nil {
+			k.Logger(ctx).Error(fmt.Sprintf("unable to unmarshal a balance change: %s", err.Error()))
+			continue
+		}
+		changes = append(changes, change)
+	}
+	return
+}
@@ -0,0 +1,76 @@
+package keeper
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pokt-network/pocket-core/store"
+	sdk "github.com/pokt-network/pocket-core/types"
+	"github.com/pokt-network/pocket-core/x/auth/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func createHistoryTestInput(t *testing.T) (sdk.Context, Keeper) {
+	keyAcc := sdk.NewKVStoreKey(types.StoreKey)
+	tkeyAcc := sdk.NewTransientStoreKey(types.TStoreKey)
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db, false, 5000000)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyAcc, sdk.StoreTypeTransient, db)
+	ms.MountStoreWithDB(sdk.ParamsKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(sdk.ParamsTKey, sdk.StoreTypeTransient, db)
+	require.Nil(t, ms.LoadLatestVersion())
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "history-chain", Height: 5}, false, log.NewNopLogger()).WithAppVersion("0.0.0")
+	keeper := NewKeeper(makeTestCodec(), keyAcc, sdk.NewSubspace(types.StoreKey), map[string][]string{types.Minter: {types.Minter, types.Burner}})
+	keeper.EnableHistory(tkeyAcc)
+	keeper.SetSupply(ctx, types.NewSupply(sdk.NewCoins()))
+	return ctx, keeper
+}
+
+func TestKeeper_BalanceChanges(t *testing.T) {
+	ctx, keeper := createHistoryTestInput(t)
+	from, to := sdk.Address(types.NewModuleAddress("from")), sdk.Address(types.NewModuleAddress("to"))
+	coins := sdk.NewCoins(sdk.NewCoin(sdk.DefaultStakeDenom, sdk.NewInt(100)))
+	// block level change without a reason
+	require.Nil(t, keeper.MintCoins(ctx, types.Minter, coins))
+	require.Nil(t, keeper.SendCoinsFromModuleToAccount(ctx, types.Minter, from, coins))
+	// tx level changes
+	txBytes := []byte("tx")
+	txCtx := ctx.WithTxBytes(txBytes)
+	require.Nil(t, keeper.SendCoins(sdk.WithBalanceChangeReason(txCtx, sdk.BalanceChangeFee), from, to, sdk.NewCoins(sdk.NewCoin(sdk.DefaultStakeDenom, sdk.NewInt(10)))))
+	require.Nil(t, keeper.SendCoins(txCtx, from, to, sdk.NewCoins(sdk.NewCoin(sdk.DefaultStakeDenom, sdk.NewInt(20)))))
+	// the changes of a discarded (failed tx) cache are not logged
+	cacheCtx, _ := txCtx.CacheContext()
+	require.Nil(t, keeper.SendCoins(cacheCtx, from, to, coins.Sub(sdk.NewCoins(sdk.NewCoin(sdk.DefaultStakeDenom, sdk.NewInt(30))))))
+	// a change recorded against another account (e.g. a slash burning the pool on behalf of a node)
+	node := sdk.Address(types.NewModuleAddress("node"))
+	slashed := sdk.NewCoins(sdk.NewCoin(sdk.DefaultStakeDenom, sdk.NewInt(5)))
+	require.Nil(t, keeper.MintCoins(ctx, types.Minter, slashed))
+	require.Nil(t, keeper.BurnCoins(sdk.WithBalanceChangeAccount(sdk.WithBalanceChangeReason(ctx, sdk.BalanceChangeSlash), node), types.Minter, slashed))
+	hash := fmt.Sprintf("%X", tmhash.Sum(txBytes))
+	minter := keeper.GetModuleAddress(types.Minter)
+	expected := []sdk.AccountChange{
+		{Height: 5, Address: minter, Delta: sdk.NewInt(100), Reason: sdk.BalanceChangeBlock},
+		{Height: 5, Address: minter, Delta: sdk.NewInt(-100), Reason: sdk.BalanceChangeBlock},
+		{Height: 5, Address: from, Delta: sdk.NewInt(100), Reason: sdk.BalanceChangeBlock},
+		{Height: 5, Address: from, Delta: sdk.NewInt(-10), Reason: sdk.BalanceChangeFee, TxHash: hash},
+		{Height: 5, Address: to, Delta: sdk.NewInt(10), Reason: sdk.BalanceChangeFee, TxHash: hash},
+		{Height: 5, Address: from, Delta: sdk.NewInt(-20), Reason: sdk.BalanceChangeTx, TxHash: hash},
+		{Height: 5, Address: to, Delta: sdk.NewInt(20), Reason: sdk.BalanceChangeTx, TxHash: hash},
+		{Height: 5, Address: minter, Delta: sdk.NewInt(5), Reason: sdk.BalanceChangeBlock},
+		{Height: 5, Address: node, Delta: sdk.NewInt(-5), Reason: sdk.BalanceChangeSlash},
+	}
+	changes := keeper.GetBalanceChanges(ctx)
+	require.Len(t, changes, len(expected))
+	for i, change := range changes {
+		require.True(t, expected[i].Address.Equals(change.Address), i)
+		require.True(t, expected[i].Delta.Equal(change.Delta), i)
+		require.Equal(t, expected[i].Reason, change.Reason, i)
+		require.Equal(t, expected[i].TxHash, change.TxHash, i)
+		require.Equal(t, expected[i].Height, change.Height, i)
+	}
+}
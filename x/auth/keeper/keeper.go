@@ -14,6 +14,8 @@ type Keeper struct {
 	storeKey  sdk.StoreKey
 	subspace  sdk.Subspace
 	permAddrs map[string]types.PermissionsForAddress
+	// transient store of the balance change log (nil = the log is disabled)
+	historyKey sdk.StoreKey
 }
 
 // NewKeeper creates a new Keeper instance
@@ -9,6 +9,8 @@ const (
 	ModuleName = "auth"
 	// storeKey is string representation of the store key for auth
 	StoreKey = ModuleName
+	// TStoreKey is the transient store of the balance changes of the current block
+	TStoreKey = "transient_" + ModuleName
 	// FeeCollectorName the root string for the fee collector account address
 	FeeCollectorName = "fee_collector"
 	// QuerierRoute is the querier route for auth
@@ -21,9 +23,17 @@ var (
 	// AddressStoreKeyPrefix prefix for account-by-address store
 	SupplyKeyPrefix       = []byte{0x00}
 	AddressStoreKeyPrefix = []byte{0x01}
+	// transient store keys of the balance change log
+	BalanceChangeCountKey  = []byte{0x00}
+	BalanceChangeKeyPrefix = []byte{0x01}
 )
 
 // AddressStoreKey turn an address to key used to get it from the account store
 func AddressStoreKey(addr sdk.Address) []byte {
 	return append(AddressStoreKeyPrefix, addr.Bytes()...)
 }
+
+// BalanceChangeKey turns the sequence of a balance change within the block into its transient store key
+func BalanceChangeKey(seq uint64) []byte {
+	return append(BalanceChangeKeyPrefix, sdk.Uint64ToBigEndian(seq)...)
+}
@@ -88,9 +88,15 @@ func (bldr TxBuilder) WithMemo(memo string) TxBuilder {
 	return bldr
 }
 
+// TxSigner signs the sign bytes of a transaction e.g. a private key or a remote signer
+type TxSigner interface {
+	PublicKey() crypto.PublicKey
+	Sign(msg []byte) ([]byte, error)
+}
+
 // BuildAndSign builds a single message to be signed, and signs a transaction
-// with the built message given a address, private key, and a set of messages.
-func (bldr TxBuilder) BuildAndSign(address sdk.Address, privateKey crypto.PrivateKey, msg sdk.ProtoMsg, legacyCodec bool) ([]byte, error) {
+// with the built message given a address, signer (private key), and a set of messages.
+func (bldr TxBuilder) BuildAndSign(address sdk.Address, privateKey TxSigner, msg sdk.ProtoMsg, legacyCodec bool) ([]byte, error) {
 	if bldr.chainID == "" {
 		return nil, errors.New("cant build and sign transaciton: the chainID is empty")
 	}
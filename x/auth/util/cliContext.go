@@ -8,6 +8,7 @@ import (
 	"github.com/pokt-network/pocket-core/x/auth/exported"
 	"github.com/pokt-network/pocket-core/x/auth/types"
 	"github.com/tendermint/tendermint/libs/bytes"
+	"strings"
 
 	"github.com/pokt-network/pocket-core/codec"
 	sdk "github.com/pokt-network/pocket-core/types"
@@ -24,6 +25,7 @@ type CLIContext struct { // TODO consider module passing clicontext instead of n
 	Height        int64
 	BroadcastMode BroadcastType
 	PrivateKey    crypto.PrivateKey
+	Signer        types.TxSigner // takes precedence over the private key
 }
 
 // NewCLIContext returns a new initialized CLIContext with parameters from the
@@ -58,6 +60,23 @@ func (ctx CLIContext) WithFromAddress(addr sdk.Address) CLIContext {
 	return ctx
 }
 
+// WithSigner returns a copy of the context with an updated tx signer.
+func (ctx CLIContext) WithSigner(signer types.TxSigner) CLIContext {
+	ctx.Signer = signer
+	return ctx
+}
+
+// txSigner returns the signer of the txs of the context, the private key when no signer is set
+func (ctx CLIContext) txSigner() types.TxSigner {
+	if ctx.Signer != nil {
+		return ctx.Signer
+	}
+	if ctx.PrivateKey != nil {
+		return ctx.PrivateKey
+	}
+	return nil
+}
+
 // WithHeight returns a copy of the context with an updated height.
 func (ctx CLIContext) WithHeight(height int64) CLIContext {
 	ctx.Height = height
@@ -96,7 +115,7 @@ func (ctx CLIContext) BroadcastTx(txBytes []byte) (res sdk.TxResponse, err error
 		res, err = ctx.BroadcastTxCommit(txBytes)
 
 	default:
-		return sdk.TxResponse{}, fmt.Errorf("unsupported return type %v; supported types: sync, async, block", ctx.BroadcastMode)
+		return sdk.TxResponse{}, fmt.Errorf("unsupported return type %v; supported types: sync, async, commit", ctx.BroadcastMode)
 	}
 
 	return res, err
@@ -163,6 +182,22 @@ const (
 	BroadcastBlock
 )
 
+// ParseBroadcastMode returns the broadcast type of its name: sync (the default when empty), async, or commit (block).
+// Commit waits for the block at most TimeoutBroadcastTxCommit of the tendermint config (10s by default), far less than
+// the time of a mainnet block: there, broadcast with sync and poll the tx by its hash (what the --wait of the cli does)
+func ParseBroadcastMode(mode string) (BroadcastType, error) {
+	switch strings.ToLower(mode) {
+	case "", "sync":
+		return BroadcastSync, nil
+	case "async":
+		return BroadcastAsync, nil
+	case "commit", "block":
+		return BroadcastBlock, nil
+	default:
+		return 0, fmt.Errorf("unsupported broadcast mode %s; supported modes: async, sync, commit", mode)
+	}
+}
+
 // ---------------------------------------------------------------------------------------------------------------------
 // Query performs a query to a Tendermint node with the provided path.
 // It returns the result and height of the query upon success or an error if
@@ -18,8 +18,8 @@ func CompleteAndBroadcastTxCLI(txBldr auth.TxBuilder, cliCtx CLIContext, msgs sd
 
 	// build and sign the transaction
 
-	if cliCtx.PrivateKey != nil {
-		txBytes, err := txBldr.BuildAndSign(cliCtx.FromAddress, cliCtx.PrivateKey, msgs, legacyCodec)
+	if signer := cliCtx.txSigner(); signer != nil {
+		txBytes, err := txBldr.BuildAndSign(cliCtx.FromAddress, signer, msgs, legacyCodec)
 		if err != nil {
 			return nil, err
 		}
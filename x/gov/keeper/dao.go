@@ -12,7 +12,7 @@ func (k Keeper) DAOTransferFrom(ctx sdk.Ctx, owner, to sdk.Address, amount sdk.B
 		return sdk.ErrUnauthorized(fmt.Sprintf("non dao owner is trying to transfer from the dao %s", owner.String())).Result()
 	}
 	coins := sdk.NewCoins(sdk.NewCoin(sdk.DefaultStakeDenom, amount))
-	err := k.AuthKeeper.SendCoinsFromModuleToAccount(ctx, types.DAOAccountName, to, coins)
+	err := k.AuthKeeper.SendCoinsFromModuleToAccount(sdk.WithBalanceChangeReason(ctx, sdk.BalanceChangeDAOTransfer), types.DAOAccountName, to, coins)
 	if err != nil {
 		return err.Result()
 	}
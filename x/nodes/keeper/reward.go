@@ -19,6 +19,7 @@ func (k Keeper) RewardForRelays(ctx sdk.Ctx, relays sdk.BigInt, address sdk.Addr
 	}
 	coins := k.RelaysToTokensMultiplier(ctx).Mul(relays)
 	toNode, toFeeCollector := k.NodeReward(ctx, coins)
+	ctx = sdk.WithBalanceChangeReason(ctx, sdk.BalanceChangeRelayReward)
 	if toNode.IsPositive() {
 		k.mint(ctx, toNode, address)
 	}
@@ -48,6 +49,7 @@ func (k Keeper) blockReward(ctx sdk.Ctx, previousProposer sdk.Address) {
 	proposerCut := feesCollected.Sub(daoCut)
 	// send to the two parties
 	feeAddr := feesCollector.GetAddress()
+	ctx = sdk.WithBalanceChangeReason(ctx, sdk.BalanceChangeBlockReward)
 	err := k.AccountKeeper.SendCoinsFromAccountToModule(ctx, feeAddr, govTypes.DAOAccountName, sdk.NewCoins(sdk.NewCoin(sdk.DefaultStakeDenom, daoCut)))
 	if err != nil {
 		ctx.Logger().Error(fmt.Sprintf("unable to send %s cut of block reward to the dao: %s, at height %d", daoCut.String(), err.Error(), ctx.BlockHeight()))
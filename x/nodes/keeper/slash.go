@@ -17,6 +17,11 @@ func (k Keeper) BurnForChallenge(ctx sdk.Ctx, challenges sdk.BigInt, address sdk
 	k.simpleSlash(ctx, address, coins)
 }
 
+// slashContext - The burn of a slash is recorded in the account history against the slashed validator (not the pool)
+func slashContext(ctx sdk.Ctx, addr sdk.Address) sdk.Ctx {
+	return sdk.WithBalanceChangeAccount(sdk.WithBalanceChangeReason(ctx, sdk.BalanceChangeSlash), addr)
+}
+
 // simpleSlash - Slash validator for an infraction committed at a known height
 // Find the contributing stake at that height and burn the specified slashFactor
 func (k Keeper) simpleSlash(ctx sdk.Ctx, addr sdk.Address, amount sdk.BigInt) {
@@ -33,7 +38,7 @@ func (k Keeper) simpleSlash(ctx sdk.Ctx, addr sdk.Address, amount sdk.BigInt) {
 		k.Logger(ctx).Error("could not remove staked tokens in simpleSlash: " + err.Error() + "\nfor validator " + addr.String())
 		return
 	}
-	err = k.burnStakedTokens(ctx, tokensToBurn)
+	err = k.burnStakedTokens(slashContext(ctx, addr), tokensToBurn)
 	if err != nil {
 		k.Logger(ctx).Error("could not burn staked tokens in simpleSlash: " + err.Error() + "\nfor validator " + addr.String())
 		return
@@ -100,7 +105,7 @@ func (k Keeper) slash(ctx sdk.Ctx, addr sdk.Address, infractionHeight, power int
 		k.Logger(ctx).Error("could not remove staked tokens in slash: " + err.Error() + "\nfor validator " + addr.String())
 		return
 	}
-	err = k.burnStakedTokens(ctx, tokensToBurn)
+	err = k.burnStakedTokens(slashContext(ctx, addr), tokensToBurn)
 	if err != nil {
 		k.Logger(ctx).Error("could not burn staked tokens in slash: " + err.Error() + "\nfor validator " + addr.String())
 		return
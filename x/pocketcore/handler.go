@@ -51,6 +51,7 @@ func handleClaimMsg(ctx sdk.Ctx, k keeper.Keeper, msg types.MsgClaim) sdk.Result
 		sdk.NewEvent(
 			types.EventTypeClaim,
 			sdk.NewAttribute(types.AttributeKeyValidator, msg.FromAddress.String()),
+			sdk.NewAttribute(sdk.AttributeKeyChain, msg.SessionHeader.Chain),
 		),
 	})
 	return sdk.Result{Events: ctx.EventManager().Events()}
@@ -92,6 +93,7 @@ func handleProofMsg(ctx sdk.Ctx, k keeper.Keeper, proof types.MsgProof) sdk.Resu
 		sdk.NewEvent(
 			types.EventTypeProof,
 			sdk.NewAttribute(types.AttributeKeyValidator, addr.String()),
+			sdk.NewAttribute(sdk.AttributeKeyChain, claim.SessionHeader.Chain),
 		),
 	})
 	return sdk.Result{Events: ctx.EventManager().Events()}
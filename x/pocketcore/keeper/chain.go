@@ -10,8 +10,6 @@ func (k Keeper) GetHostedBlockchains() *pc.HostedBlockchains {
 }
 
 func (k Keeper) SetHostedBlockchains(m map[string]pc.HostedBlockchain) *pc.HostedBlockchains {
-	k.hostedBlockchains.L.Lock()
-	k.hostedBlockchains.M = m
-	k.hostedBlockchains.L.Unlock()
+	k.hostedBlockchains.Set(m)
 	return k.hostedBlockchains
 }
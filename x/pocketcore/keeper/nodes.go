@@ -1,7 +1,6 @@
 package keeper
 
 import (
-	"github.com/pokt-network/pocket-core/crypto"
 	sdk "github.com/pokt-network/pocket-core/types"
 	"github.com/pokt-network/pocket-core/x/nodes/exported"
 	pc "github.com/pokt-network/pocket-core/x/pocketcore/types"
@@ -17,21 +16,17 @@ func (k Keeper) GetNode(ctx sdk.Ctx, address sdk.Address) (n exported.ValidatorI
 }
 
 func (k Keeper) GetSelfAddress(ctx sdk.Ctx) sdk.Address {
-	kp, err := k.GetPKFromFile(ctx)
+	signer, err := k.GetSelfSigner(ctx)
 	if err != nil {
 		ctx.Logger().Error("Unable to retrieve selfAddress: " + err.Error())
 		return nil
 	}
-	return sdk.Address(kp.PublicKey().Address())
+	return sdk.Address(signer.PublicKey().Address())
 }
 
-func (k Keeper) GetSelfPrivKey(ctx sdk.Ctx) (crypto.PrivateKey, sdk.Error) {
-	// get the private key from the private validator file
-	pk, er := k.GetPKFromFile(ctx)
-	if er != nil {
-		return nil, pc.NewKeybaseError(pc.ModuleName, er)
-	}
-	return pk, nil
+// "GetSelfSigner" - Returns the signer of the node: the private validator key or a remote signer
+func (k Keeper) GetSelfSigner(ctx sdk.Ctx) (pc.Signer, sdk.Error) {
+	return pc.GetSigner()
 }
 
 // "GetSelfNode" - Gets self node (private val key) from the world state
@@ -15,11 +15,11 @@ func (k Keeper) HandleRelay(ctx sdk.Ctx, relay pc.Relay) (*pc.RelayResponse, sdk
 	// get the latest session block height because this relay will correspond with the latest session
 	sessionBlockHeight := k.GetLatestSessionBlockHeight(ctx)
 	// get self node (your validator) from the current state
-	pk, err := k.GetSelfPrivKey(ctx)
+	signer, err := k.GetSelfSigner(ctx)
 	if err != nil {
 		return nil, err
 	}
-	selfAddr := sdk.Address(pk.PublicKey().Address())
+	selfAddr := sdk.Address(signer.PublicKey().Address())
 	// retrieve the nonNative blockchains your node is hosting
 	hostedBlockchains := k.GetHostedBlockchains()
 	// ensure the validity of the relay
@@ -46,12 +46,33 @@ func (k Keeper) HandleRelay(ctx sdk.Ctx, relay pc.Relay) (*pc.RelayResponse, sdk
 		}
 		return nil, err
 	}
-	// store the proof before execution, because the proof corresponds to the previous relay
-	relay.Proof.Store(maxPossibleRelays)
+	// reject the relays the policy of the chain does not allow, before their proof is stored
+	if err := relay.CheckPolicy(hostedBlockchains); err != nil {
+		if pc.GlobalPocketConfig.RelayErrors {
+			ctx.Logger().Error(fmt.Sprintf("relay for chainID: %v rejected by policy with error: %s", relay.Proof.Blockchain, err.Error()))
+		}
+		return nil, err
+	}
+	// reserve the proof before execution, because the proof corresponds to the previous relay; the checks of the
+	// evidence are done under the session lock, so concurrent duplicates or relays over the max never reach the backend
+	if err := relay.Proof.Reserve(maxPossibleRelays); err != nil {
+		return nil, err
+	}
 	// attempt to execute
 	respPayload, err := relay.Execute(hostedBlockchains)
 	if err != nil {
 		ctx.Logger().Error(fmt.Sprintf("could not send relay with error: %s", err.Error()))
+		// a response failing the checks of the chain is returned unsigned, and its proof is released without storing it
+		if err.Code() == pc.CodeBackendResponseError {
+			relay.Proof.Release()
+			return &pc.RelayResponse{Response: respPayload}, err
+		}
+	}
+	// store the reserved proof, before the response is signed so no response is served without its proof
+	if er := relay.Proof.Admit(maxPossibleRelays); er != nil {
+		return nil, er
+	}
+	if err != nil {
 		return nil, err
 	}
 	// generate response object
@@ -60,7 +81,7 @@ func (k Keeper) HandleRelay(ctx sdk.Ctx, relay pc.Relay) (*pc.RelayResponse, sdk
 		Proof:    relay.Proof,
 	}
 	// sign the response
-	sig, er := pk.Sign(resp.Hash())
+	sig, er := signer.Sign(pc.SignRelayResponse, resp.HashSeed())
 	if er != nil {
 		ctx.Logger().Error(
 			fmt.Sprintf("could not sign response for address: %s with hash: %v, with error: %s",
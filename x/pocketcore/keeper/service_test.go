@@ -87,4 +87,61 @@ func TestKeeper_HandleRelay(t *testing.T) {
 	assert.NotNil(t, resp)
 	assert.NotEmpty(t, resp)
 	assert.Equal(t, resp.Response, "bar")
+	// a relay the policy of the chain rejects is not stored
+	chain := keeper.GetHostedBlockchains().M[ethereum]
+	chain.Policy = &types.RequestPolicy{DenyMethods: []string{"web3_clientVersion"}}
+	keeper.SetHostedBlockchains(map[string]types.HostedBlockchain{ethereum: chain})
+	header := types.SessionHeader{
+		ApplicationPubKey:  appPubKey,
+		Chain:              ethereum,
+		SessionBlockHeight: 976,
+	}
+	_, totalRelays := types.GetTotalProofs(header, types.RelayEvidence, app.MaxRelays)
+	rejectedRelay := validRelay
+	rejectedRelay.Proof.Entropy = 2
+	clientSig, er = clientPrivateKey.Sign(rejectedRelay.Proof.Hash())
+	if er != nil {
+		t.Fatalf(er.Error())
+	}
+	rejectedRelay.Proof.Signature = hex.EncodeToString(clientSig)
+	resp, err = keeper.HandleRelay(mockCtx, rejectedRelay)
+	assert.Nil(t, resp)
+	assert.NotNil(t, err)
+	assert.Equal(t, sdk.CodeType(types.CodePolicyRejectedError), err.Code())
+	_, total := types.GetTotalProofs(header, types.RelayEvidence, app.MaxRelays)
+	assert.Equal(t, totalRelays, total)
+	// a response failing the checks of the chain is returned unsigned and its proof is not stored
+	chain.Policy = nil
+	chain.ResponseChecks = &types.ResponseChecks{HTTPStatus: true}
+	keeper.SetHostedBlockchains(map[string]types.HostedBlockchain{ethereum: chain})
+	gock.New("https://www.google.com:443").
+		Post("/").
+		Reply(500).
+		BodyString("unavailable")
+	resp, err = keeper.HandleRelay(mockCtx, rejectedRelay)
+	assert.NotNil(t, err)
+	assert.Equal(t, sdk.CodeType(types.CodeBackendResponseError), err.Code())
+	assert.Equal(t, "unavailable", resp.Response)
+	assert.Empty(t, resp.Signature)
+	_, total = types.GetTotalProofs(header, types.RelayEvidence, app.MaxRelays)
+	assert.Equal(t, totalRelays, total)
+	// the slot of the released proof is free again
+	gock.New("https://www.google.com:443").
+		Post("/").
+		Reply(200).
+		BodyString("bar")
+	resp, err = keeper.HandleRelay(mockCtx, rejectedRelay)
+	assert.Nil(t, err, err)
+	assert.Equal(t, "bar", resp.Response)
+	_, total = types.GetTotalProofs(header, types.RelayEvidence, app.MaxRelays)
+	assert.Equal(t, totalRelays+1, total)
+	// a duplicate never reaches the backend
+	gock.New("https://www.google.com:443").
+		Post("/").
+		Reply(200).
+		BodyString("bar")
+	resp, err = keeper.HandleRelay(mockCtx, rejectedRelay)
+	assert.Nil(t, resp)
+	assert.Equal(t, sdk.CodeType(types.CodeDuplicateProofError), err.Code())
+	assert.True(t, gock.IsPending())
 }
@@ -2,52 +2,205 @@ package keeper
 
 import (
 	"encoding/hex"
+
 	sdk "github.com/pokt-network/pocket-core/types"
 	"github.com/pokt-network/pocket-core/x/nodes/exported"
 	"github.com/pokt-network/pocket-core/x/pocketcore/types"
 )
 
-// "HandleDispatch" - Handles a client request for their session information
+// "HandleDispatch" - Handles a client request for their session information, of the latest session when the header has
+// no session block height, else of the session of the height
 func (k Keeper) HandleDispatch(ctx sdk.Ctx, header types.SessionHeader) (*types.DispatchResponse, sdk.Error) {
-	// retrieve the latest session block height
-	latestSessionBlockHeight := k.GetLatestSessionBlockHeight(ctx)
+	// get the session contexts of the session block height
+	sessionCtx, sessionEndCtx, sessionBlockHeight, err := k.dispatchCtx(ctx, header.SessionBlockHeight)
+	if err != nil {
+		return nil, err
+	}
 	// set the session block height
-	header.SessionBlockHeight = latestSessionBlockHeight
-	// validate the header
-	err := header.ValidateHeader()
+	header.SessionBlockHeight = sessionBlockHeight
+	// only the latest session is cached, so the past heights can't grow the cache
+	latest := sessionBlockHeight == k.GetLatestSessionBlockHeight(ctx)
+	session, err := k.dispatchSession(sessionCtx, sessionEndCtx, header, latest)
 	if err != nil {
 		return nil, err
 	}
-	// get the session context
-	sessionCtx, er := ctx.PrevCtx(latestSessionBlockHeight)
+	return &types.DispatchResponse{Session: session, BlockHeight: ctx.BlockHeight()}, nil
+}
+
+// "HandleBatchDispatch" - Handles a client request for the session information of multiple chains of an app, of the latest
+// session when the session block height is zero, else of the session of the height. A batch has at most
+// MaxBatchDispatchChains chains
+func (k Keeper) HandleBatchDispatch(ctx sdk.Ctx, appPubKey string, chains []string, sessionBlockHeight int64) (*types.BatchDispatchResponse, sdk.Error) {
+	if len(chains) == 0 {
+		return nil, types.NewEmptyChainError(types.ModuleName)
+	}
+	if len(chains) > types.MaxBatchDispatchChains {
+		return nil, types.NewTooManyChainsError(types.ModuleName, len(chains))
+	}
+	// get the session contexts of the session block height
+	sessionCtx, sessionEndCtx, sessionBlockHeight, err := k.dispatchCtx(ctx, sessionBlockHeight)
+	if err != nil {
+		return nil, err
+	}
+	// validate every header before generating the sessions
+	headers := make([]types.SessionHeader, 0, len(chains))
+	seen := make(map[string]struct{}, len(chains))
+	for _, chain := range chains {
+		if _, found := seen[chain]; found {
+			continue
+		}
+		seen[chain] = struct{}{}
+		header := types.SessionHeader{
+			ApplicationPubKey:  appPubKey,
+			Chain:              chain,
+			SessionBlockHeight: sessionBlockHeight,
+		}
+		if err := header.ValidateHeader(); err != nil {
+			return nil, err
+		}
+		if !k.IsPocketSupportedBlockchain(sessionCtx, chain) {
+			return nil, types.NewChainNotSupportedErr(types.ModuleName)
+		}
+		headers = append(headers, header)
+	}
+	// only the latest session is cached, so the past heights can't grow the cache
+	latest := sessionBlockHeight == k.GetLatestSessionBlockHeight(ctx)
+	sessions := make([]types.DispatchSession, len(headers))
+	for i, header := range headers {
+		sessions[i], err = k.dispatchSession(sessionCtx, sessionEndCtx, header, latest)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &types.BatchDispatchResponse{Sessions: sessions, BlockHeight: ctx.BlockHeight()}, nil
+}
+
+// dispatchCtx returns the context of the start and of the end of the session of the session block height (the latest
+// session if zero) and the session block height. The past sessions are limited to the heights the node has the state of
+func (k Keeper) dispatchCtx(ctx sdk.Ctx, sessionBlockHeight int64) (sdk.Ctx, sdk.Ctx, int64, sdk.Error) {
+	// retrieve the latest session block height
+	latestSessionBlockHeight := k.GetLatestSessionBlockHeight(ctx)
+	if sessionBlockHeight == 0 || sessionBlockHeight == latestSessionBlockHeight {
+		// get the session context
+		sessionCtx, er := ctx.PrevCtx(latestSessionBlockHeight)
+		if er != nil {
+			return nil, nil, 0, sdk.ErrInternal(er.Error())
+		}
+		return sessionCtx, ctx, latestSessionBlockHeight, nil
+	}
+	if sessionBlockHeight < 0 {
+		return nil, nil, 0, types.NewInvalidBlockHeightError(types.ModuleName)
+	}
+	if sessionBlockHeight > latestSessionBlockHeight {
+		return nil, nil, 0, types.NewFutureSessionHeightError(types.ModuleName, sessionBlockHeight, latestSessionBlockHeight)
+	}
+	sessionCtx, er := ctx.PrevCtx(sessionBlockHeight)
+	if er != nil {
+		return nil, nil, 0, types.NewUnavailableSessionHeightError(types.ModuleName, sessionBlockHeight, er)
+	}
+	// the session block height must be the start of a session, with the blocks per session of the time of the session
+	blocksPerSession := k.BlocksPerSession(sessionCtx)
+	if (sessionBlockHeight-1)%blocksPerSession != 0 {
+		return nil, nil, 0, types.NewInvalidBlockHeightError(types.ModuleName)
+	}
+	// use the session end context like the claims, so the nodes jailed mid session are not in the session
+	sessionEndCtx, er := ctx.PrevCtx(sessionBlockHeight + blocksPerSession - 1)
 	if er != nil {
-		return nil, sdk.ErrInternal(er.Error())
+		return nil, nil, 0, types.NewUnavailableSessionHeightError(types.ModuleName, sessionBlockHeight, er)
+	}
+	return sessionCtx, sessionEndCtx, sessionBlockHeight, nil
+}
+
+// dispatchSession returns the session of the header, from the cache or generated. A generated session is added to the
+// cache only if cache is set
+func (k Keeper) dispatchSession(sessionCtx, sessionEndCtx sdk.Ctx, header types.SessionHeader, cache bool) (types.DispatchSession, sdk.Error) {
+	// validate the header
+	err := header.ValidateHeader()
+	if err != nil {
+		return types.DispatchSession{}, err
 	}
 	// check cache
 	session, found := types.GetSession(header)
 	// if not found generate the session
 	if !found {
-		var err sdk.Error
 		blockHashBz, er := sessionCtx.BlockHash(k.Cdc, sessionCtx.BlockHeight())
 		if er != nil {
-			return nil, sdk.ErrInternal(er.Error())
+			return types.DispatchSession{}, sdk.ErrInternal(er.Error())
 		}
-		session, err = types.NewSession(sessionCtx, ctx, k.posKeeper, header, hex.EncodeToString(blockHashBz), int(k.SessionNodeCount(sessionCtx)))
+		session, err = types.NewSession(sessionCtx, sessionEndCtx, k.posKeeper, header, hex.EncodeToString(blockHashBz), int(k.SessionNodeCount(sessionCtx)))
 		if err != nil {
-			return nil, err
+			return types.DispatchSession{}, err
 		}
 		// add to cache
-		types.SetSession(session)
+		if cache {
+			types.SetSession(session)
+		}
 	}
 	actualNodes := make([]exported.ValidatorI, len(session.SessionNodes))
 	for i, addr := range session.SessionNodes {
 		actualNodes[i], _ = k.GetNode(sessionCtx, addr)
 	}
-	return &types.DispatchResponse{Session: types.DispatchSession{
+	return types.DispatchSession{
 		SessionHeader: session.SessionHeader,
 		SessionKey:    session.SessionKey,
 		SessionNodes:  actualNodes,
-	}, BlockHeight: ctx.BlockHeight()}, nil
+	}, nil
+}
+
+// "PrefetchSessions" - Precomputes and caches the sessions of the latest session in which the node is a servicer: every
+// staked app for every chain the node hosts, so the first relays of the session don't generate them. Returns the
+// number of sessions of the node
+func (k Keeper) PrefetchSessions(ctx sdk.Ctx) (int, sdk.Error) {
+	sessionBlockHeight := k.GetLatestSessionBlockHeight(ctx)
+	// get the session context
+	sessionCtx, er := ctx.PrevCtx(sessionBlockHeight)
+	if er != nil {
+		return 0, sdk.ErrInternal(er.Error())
+	}
+	selfAddr := k.GetSelfAddress(ctx)
+	if selfAddr == nil {
+		return 0, types.NewSelfNotFoundError(types.ModuleName)
+	}
+	blockHashBz, er := sessionCtx.BlockHash(k.Cdc, sessionCtx.BlockHeight())
+	if er != nil {
+		return 0, sdk.ErrInternal(er.Error())
+	}
+	blockHash := hex.EncodeToString(blockHashBz)
+	sessionNodeCount := int(k.SessionNodeCount(sessionCtx))
+	hostedBlockchains := k.GetHostedBlockchains()
+	count := 0
+	for _, app := range k.appKeeper.AllApplications(sessionCtx) {
+		if !app.IsStaked() {
+			continue
+		}
+		for _, chain := range app.GetChains() {
+			if !hostedBlockchains.Contains(chain) {
+				continue
+			}
+			header := types.SessionHeader{
+				ApplicationPubKey:  app.GetPublicKey().RawString(),
+				Chain:              chain,
+				SessionBlockHeight: sessionBlockHeight,
+			}
+			session, found := types.GetSession(header)
+			if !found {
+				var err sdk.Error
+				session, err = types.NewSession(sessionCtx, ctx, k.posKeeper, header, blockHash, sessionNodeCount)
+				if err != nil {
+					continue
+				}
+			}
+			// only the sessions of the node are cached
+			if !session.SessionNodes.Contains(selfAddr) {
+				continue
+			}
+			if !found {
+				types.SetSession(session)
+			}
+			count++
+		}
+	}
+	return count, nil
 }
 
 // "IsSessionBlock" - Returns true if current block, is a session block (beginning of a session)
@@ -2,8 +2,12 @@ package keeper
 
 import (
 	"encoding/hex"
+	"fmt"
 	"testing"
 
+	sdk "github.com/pokt-network/pocket-core/types"
+	appsKeeper "github.com/pokt-network/pocket-core/x/apps/keeper"
+	appsTypes "github.com/pokt-network/pocket-core/x/apps/types"
 	"github.com/pokt-network/pocket-core/x/pocketcore/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -44,6 +48,128 @@ func TestKeeper_Dispatch(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestKeeper_DispatchSessionHeight(t *testing.T) {
+	ctx, _, _, _, keeper, keys, _ := createTestInput(t, false)
+	appPubKey := getRandomPrivateKey().PublicKey().RawString()
+	ethereum := hex.EncodeToString([]byte{01})
+	bitcoin := hex.EncodeToString([]byte{02})
+	blocksPerSession := keeper.BlocksPerSession(ctx)
+	latest, past, pruned := int64(976), 976-blocksPerSession, 976-2*blocksPerSession
+	mockCtx := new(Ctx)
+	mockCtx.On("KVStore", keeper.storeKey).Return(ctx.KVStore(keeper.storeKey))
+	mockCtx.On("KVStore", keys["pos"]).Return(ctx.KVStore(keys["pos"]))
+	mockCtx.On("KVStore", keys["params"]).Return(ctx.KVStore(keys["params"]))
+	mockCtx.On("PrevCtx", latest).Return(ctx, nil)
+	mockCtx.On("PrevCtx", past).Return(ctx, nil)
+	mockCtx.On("PrevCtx", past+1).Return(ctx, nil)
+	mockCtx.On("PrevCtx", latest-1).Return(ctx, nil)
+	mockCtx.On("PrevCtx", pruned).Return(ctx, fmt.Errorf("version does not exist"))
+	mockCtx.On("BlockHeight").Return(ctx.BlockHeight())
+	mockCtx.On("Logger").Return(ctx.Logger())
+	header := types.SessionHeader{
+		ApplicationPubKey: appPubKey,
+		Chain:             ethereum,
+	}
+	types.ClearSessionCache()
+	// no session height is the latest session
+	res, err := keeper.HandleDispatch(mockCtx, header)
+	assert.Nil(t, err)
+	assert.Equal(t, latest, res.Session.SessionHeader.SessionBlockHeight)
+	// the latest session is cached
+	_, found := types.GetSession(res.Session.SessionHeader)
+	assert.True(t, found)
+	// a past session
+	header.SessionBlockHeight = past
+	res, err = keeper.HandleDispatch(mockCtx, header)
+	assert.Nil(t, err)
+	assert.Equal(t, header, res.Session.SessionHeader)
+	assert.Len(t, res.Session.SessionNodes, 5)
+	// a past session is not cached
+	_, found = types.GetSession(header)
+	assert.False(t, found)
+	// not the start of a session
+	header.SessionBlockHeight = past + 1
+	_, err = keeper.HandleDispatch(mockCtx, header)
+	assert.NotNil(t, err)
+	assert.Equal(t, sdk.CodeType(types.CodeInvalidBlockHeightError), err.Code())
+	// a session the node has no state for
+	header.SessionBlockHeight = pruned
+	_, err = keeper.HandleDispatch(mockCtx, header)
+	assert.NotNil(t, err)
+	assert.Equal(t, sdk.CodeType(types.CodeUnavailableSessionHeightError), err.Code())
+	// a future session
+	header.SessionBlockHeight = latest + blocksPerSession
+	_, err = keeper.HandleDispatch(mockCtx, header)
+	assert.NotNil(t, err)
+	assert.Equal(t, sdk.CodeType(types.CodeFutureSessionHeightError), err.Code())
+	// the batch of the chains of the app, without the duplicates
+	batch, err := keeper.HandleBatchDispatch(mockCtx, appPubKey, []string{ethereum, ethereum}, past)
+	assert.Nil(t, err)
+	assert.Len(t, batch.Sessions, 1)
+	assert.Equal(t, past, batch.Sessions[0].SessionHeader.SessionBlockHeight)
+	_, found = types.GetSession(batch.Sessions[0].SessionHeader)
+	assert.False(t, found)
+	// a batch of more than the maximum number of chains
+	tooMany := make([]string, types.MaxBatchDispatchChains+1)
+	for i := range tooMany {
+		tooMany[i] = ethereum
+	}
+	_, err = keeper.HandleBatchDispatch(mockCtx, appPubKey, tooMany, 0)
+	assert.NotNil(t, err)
+	assert.Equal(t, sdk.CodeType(types.CodeTooManyChainsError), err.Code())
+	_, err = keeper.HandleBatchDispatch(mockCtx, appPubKey, []string{ethereum, bitcoin}, 0)
+	assert.NotNil(t, err)
+	assert.Equal(t, sdk.CodeType(types.CodeChainNotSupportedErr), err.Code())
+	_, err = keeper.HandleBatchDispatch(mockCtx, appPubKey, nil, 0)
+	assert.NotNil(t, err)
+}
+
+func TestKeeper_PrefetchSessions(t *testing.T) {
+	ctx, _, _, _, keeper, keys, kb := createTestInput(t, false)
+	ethereum := hex.EncodeToString([]byte{01})
+	ak := keeper.appKeeper.(appsKeeper.Keeper)
+	// an app of a hosted chain and an app of a chain the node does not host
+	appPubKeys := make([]string, 2)
+	for i, chain := range []string{ethereum, hex.EncodeToString([]byte{03})} {
+		apk := getRandomPrivateKey().PublicKey()
+		app := appsTypes.NewApplication(sdk.Address(apk.Address()), apk, []string{chain}, sdk.NewInt(10000000))
+		ak.SetApplication(ctx, app)
+		ak.SetStakedApplication(ctx, app)
+		appPubKeys[i] = apk.RawString()
+	}
+	mockCtx := new(Ctx)
+	mockCtx.On("KVStore", keeper.storeKey).Return(ctx.KVStore(keeper.storeKey))
+	mockCtx.On("KVStore", keys["pos"]).Return(ctx.KVStore(keys["pos"]))
+	mockCtx.On("KVStore", keys["params"]).Return(ctx.KVStore(keys["params"]))
+	mockCtx.On("KVStore", keys["application"]).Return(ctx.KVStore(keys["application"]))
+	mockCtx.On("BlockHeight").Return(ctx.BlockHeight())
+	mockCtx.On("PrevCtx", keeper.GetLatestSessionBlockHeight(mockCtx)).Return(ctx, nil)
+	mockCtx.On("Logger").Return(ctx.Logger())
+	types.ClearSessionCache()
+	count, err := keeper.PrefetchSessions(mockCtx)
+	assert.Nil(t, err)
+	kp, _ := kb.GetCoinbase()
+	header := types.SessionHeader{
+		ApplicationPubKey:  appPubKeys[0],
+		Chain:              ethereum,
+		SessionBlockHeight: keeper.GetLatestSessionBlockHeight(mockCtx),
+	}
+	session, found := types.GetSession(header)
+	// only the sessions of the node are cached
+	assert.Equal(t, session.SessionNodes.Contains(sdk.Address(kp.GetAddress())), found)
+	cached := 0
+	iter := types.SessionIterator()
+	for ; iter.Valid(); iter.Next() {
+		cached++
+	}
+	iter.Close()
+	assert.Equal(t, cached, count)
+	// the app of the chain the node does not host
+	header.ApplicationPubKey, header.Chain = appPubKeys[1], hex.EncodeToString([]byte{03})
+	_, found = types.GetSession(header)
+	assert.False(t, found)
+}
+
 func TestKeeper_IsSessionBlock(t *testing.T) {
 	notSessionContext, _, _, _, keeper, _, _ := createTestInput(t, false)
 	assert.False(t, keeper.IsSessionBlock(notSessionContext.WithBlockHeight(977)))
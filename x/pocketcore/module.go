@@ -118,8 +118,8 @@ func (am AppModule) EndBlock(ctx sdk.Ctx, _ abci.RequestEndBlock) []abci.Validat
 						am.keeper.SendClaimTx(ctx, am.keeper, am.keeper.TmNode, ClaimTx)
 						// auto claim the proofs
 						am.keeper.SendProofTx(ctx, am.keeper.TmNode, ProofTx)
-						// clear session cache and db
-						types.ClearSessionCache()
+						// clear the sessions of the previous sessions from the cache and db, the current ones stay warm
+						types.ClearSessionCacheBefore(am.keeper.GetLatestSessionBlockHeight(ctx))
 					}
 				}
 			}()
@@ -0,0 +1,304 @@
+package types
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+)
+
+// the backend types of the hosted blockchains
+const (
+	BackendHTTP = "http"
+	BackendGRPC = "grpc"
+	BackendIPC  = "ipc"
+)
+
+// "GRPCConfig" - The config of a hosted blockchain served over gRPC
+type GRPCConfig struct {
+	TLS        bool   `json:"tls"`                   // dial the backend with tls
+	ServerName string `json:"server_name,omitempty"` // the server name of the tls certificate, the host of the url by default
+}
+
+// "IPCConfig" - The config of a hosted blockchain served over a raw socket (JSON-RPC over IPC)
+type IPCConfig struct {
+	Network string `json:"network,omitempty"` // unix (default) for a socket path or tcp for a host:port
+}
+
+// "RelayBackend" - Executes the payload of the relays of the hosted blockchains of a backend type
+type RelayBackend interface {
+	// Execute does the request of the payload on the hosted blockchain and returns its response
+	Execute(chain HostedBlockchain, payload Payload) (string, error)
+	// ValidateChain checks the backend config of the hosted blockchain
+	ValidateChain(chain HostedBlockchain) error
+}
+
+// "backendReleaser" - A relay backend holding resources for its hosted blockchains, e.g. connections
+type backendReleaser interface {
+	// Release frees the resources no longer used by the hosted blockchains
+	Release(chains map[string]HostedBlockchain)
+}
+
+var relayBackends = map[string]RelayBackend{
+	BackendHTTP: httpBackend{},
+	BackendGRPC: newGRPCBackend(),
+	BackendIPC:  ipcBackend{},
+}
+
+// "BackendType" - Returns the backend type of the hosted blockchain, http when none is set
+func (c HostedBlockchain) BackendType() string {
+	if c.Type == "" {
+		return BackendHTTP
+	}
+	return strings.ToLower(c.Type)
+}
+
+// "Backend" - Returns the relay backend of the hosted blockchain
+func (c HostedBlockchain) Backend() (RelayBackend, error) {
+	backend, found := relayBackends[c.BackendType()]
+	if !found {
+		return nil, fmt.Errorf("unsupported backend type %s for chain %s", c.Type, c.ID)
+	}
+	return backend, nil
+}
+
+// "releaseBackends" - Frees the resources of the relay backends no longer used by the hosted blockchains
+func releaseBackends(chains map[string]HostedBlockchain) {
+	for _, backend := range relayBackends {
+		if r, ok := backend.(backendReleaser); ok {
+			r.Release(chains)
+		}
+	}
+}
+
+// "Execute" - Does the request of the payload on the hosted blockchain with its backend
+func (c HostedBlockchain) Execute(payload Payload) (string, error) {
+	backend, err := c.Backend()
+	if err != nil {
+		return "", err
+	}
+	return backend.Execute(c, payload)
+}
+
+// httpBackend sends the payload as an http request to the url of the chain joined with the payload path
+type httpBackend struct{}
+
+func (httpBackend) Execute(chain HostedBlockchain, payload Payload) (string, error) {
+	url := strings.Trim(chain.URL, `/`)
+	if len(payload.Path) > 0 {
+		url = url + "/" + strings.Trim(payload.Path, `/`)
+	}
+	res, status, err := executeHTTPRequest(payload.Data, url, GlobalPocketConfig.UserAgent, chain.BasicAuth, payload.Method, payload.Headers)
+	if err != nil {
+		return res, err
+	}
+	return res, chain.ResponseChecks.CheckStatus(status)
+}
+
+func (httpBackend) ValidateChain(_ HostedBlockchain) error {
+	return nil
+}
+
+// grpcBackend invokes the method of the payload path (/package.Service/Method) on the url of the chain. The payload
+// data is the base64 encoded protobuf request message and the response is the base64 encoded protobuf response message,
+// the headers are sent as metadata
+type grpcBackend struct {
+	conns map[string]*grpc.ClientConn
+	l     sync.Mutex
+}
+
+func newGRPCBackend() *grpcBackend {
+	return &grpcBackend{conns: make(map[string]*grpc.ClientConn)}
+}
+
+func (b *grpcBackend) Execute(chain HostedBlockchain, payload Payload) (string, error) {
+	if !strings.HasPrefix(payload.Path, "/") || strings.Count(payload.Path, "/") != 2 {
+		return "", fmt.Errorf("the payload path %q is not a grpc method (/package.Service/Method)", payload.Path)
+	}
+	req, err := base64.StdEncoding.DecodeString(payload.Data)
+	if err != nil {
+		return "", fmt.Errorf("the payload data is not base64 encoded: %v", err)
+	}
+	conn, err := b.conn(chain)
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), globalRPCTimeout*time.Millisecond)
+	defer cancel()
+	md := metadata.New(payload.Headers)
+	if chain.BasicAuth.Username != "" {
+		auth := base64.StdEncoding.EncodeToString([]byte(chain.BasicAuth.Username + ":" + chain.BasicAuth.Password))
+		md.Set("authorization", "Basic "+auth)
+	}
+	var res []byte
+	if err := conn.Invoke(metadata.NewOutgoingContext(ctx, md), payload.Path, &req, &res, grpc.ForceCodec(rawCodec{})); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(res), nil
+}
+
+func (b *grpcBackend) ValidateChain(chain HostedBlockchain) error {
+	if chain.GRPC != nil && chain.GRPC.ServerName != "" && !chain.GRPC.TLS {
+		return fmt.Errorf("the grpc server name of chain %s is set without tls", chain.ID)
+	}
+	return nil
+}
+
+// Release closes the connections that no grpc chain uses anymore
+func (b *grpcBackend) Release(chains map[string]HostedBlockchain) {
+	used := make(map[string]struct{})
+	for _, chain := range chains {
+		if chain.BackendType() == BackendGRPC {
+			used[grpcConnKey(chain)] = struct{}{}
+		}
+	}
+	b.l.Lock()
+	defer b.l.Unlock()
+	for key, conn := range b.conns {
+		if _, found := used[key]; !found {
+			_ = conn.Close()
+			delete(b.conns, key)
+		}
+	}
+}
+
+func grpcConnKey(chain HostedBlockchain) string {
+	config := GRPCConfig{}
+	if chain.GRPC != nil {
+		config = *chain.GRPC
+	}
+	return fmt.Sprintf("%s|%t|%s", chain.URL, config.TLS, config.ServerName)
+}
+
+// conn returns the connection to the url of the chain, the connections are reused across relays and chains reloads
+// until no chain uses them
+func (b *grpcBackend) conn(chain HostedBlockchain) (*grpc.ClientConn, error) {
+	config := GRPCConfig{}
+	if chain.GRPC != nil {
+		config = *chain.GRPC
+	}
+	key := grpcConnKey(chain)
+	b.l.Lock()
+	defer b.l.Unlock()
+	if conn, found := b.conns[key]; found {
+		return conn, nil
+	}
+	opts := []grpc.DialOption{grpc.WithInsecure()}
+	if config.TLS {
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{ServerName: config.ServerName}))}
+	}
+	if GlobalPocketConfig.UserAgent != "" {
+		opts = append(opts, grpc.WithUserAgent(GlobalPocketConfig.UserAgent))
+	}
+	conn, err := grpc.Dial(chain.URL, opts...)
+	if err != nil {
+		return nil, err
+	}
+	b.conns[key] = conn
+	return conn, nil
+}
+
+// rawCodec passes the already encoded protobuf messages of the relays through grpc
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	bz, ok := v.(*[]byte)
+	if !ok {
+		return nil, fmt.Errorf("rawCodec cannot marshal %T", v)
+	}
+	return *bz, nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	bz, ok := v.(*[]byte)
+	if !ok {
+		return fmt.Errorf("rawCodec cannot unmarshal into %T", v)
+	}
+	*bz = append((*bz)[:0], data...)
+	return nil
+}
+
+func (rawCodec) Name() string {
+	return "proto"
+}
+
+// ipcBackend writes the payload data (a JSON-RPC request) to the socket of the url of the chain and reads a single json
+// value back as the response, the path, method and headers of the payload are not used
+type ipcBackend struct{}
+
+// ipcPrivateNamespaces are the JSON-RPC namespaces a node serves over ipc but not over http by default: their methods
+// are only relayed when the allow_methods of the policy of the chain lists them
+var ipcPrivateNamespaces = []string{"admin", "personal", "debug", "miner"}
+
+func (ipcBackend) Execute(chain HostedBlockchain, payload Payload) (string, error) {
+	if err := checkIPCMethods(chain.Policy, payload.Data); err != nil {
+		return "", err
+	}
+	network := ipcNetwork(chain)
+	timeout := globalRPCTimeout * time.Millisecond
+	conn, err := net.DialTimeout(network, strings.TrimPrefix(chain.URL, network+"://"), timeout)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return "", err
+	}
+	if _, err := conn.Write([]byte(payload.Data)); err != nil {
+		return "", err
+	}
+	var res json.RawMessage
+	if err := json.NewDecoder(conn).Decode(&res); err != nil {
+		return "", err
+	}
+	if GlobalPocketConfig.JSONSortRelayResponses {
+		return sortJSONResponse(string(res)), nil
+	}
+	return string(res), nil
+}
+
+func (ipcBackend) ValidateChain(chain HostedBlockchain) error {
+	switch network := ipcNetwork(chain); network {
+	case "unix", "tcp":
+		return nil
+	default:
+		return fmt.Errorf("unsupported ipc network %s for chain %s", network, chain.ID)
+	}
+}
+
+// checkIPCMethods checks that the payload data is a JSON-RPC request (or batch) without a method of the private
+// namespaces that the policy does not allow
+func checkIPCMethods(policy *RequestPolicy, data string) error {
+	reqs, err := parseJSONRPCRequests(data)
+	if err != nil {
+		return err
+	}
+	if len(reqs) == 0 {
+		return fmt.Errorf("the payload data of an ipc chain is not a JSON-RPC request")
+	}
+	for _, req := range reqs {
+		namespace := strings.SplitN(req.Method, "_", 2)[0]
+		for _, private := range ipcPrivateNamespaces {
+			if namespace == private && !policy.listsMethod(req.Method) {
+				return fmt.Errorf("the method %s is not allowed over ipc without the allow_methods of the policy", req.Method)
+			}
+		}
+	}
+	return nil
+}
+
+func ipcNetwork(chain HostedBlockchain) string {
+	if chain.IPC == nil || chain.IPC.Network == "" {
+		return "unix"
+	}
+	return strings.ToLower(chain.IPC.Network)
+}
@@ -0,0 +1,140 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestHostedBlockchain_BackendType(t *testing.T) {
+	ethereum := hex.EncodeToString([]byte{01})
+	tests := []struct {
+		name     string
+		chain    HostedBlockchain
+		hasError bool
+	}{
+		{"default http", HostedBlockchain{ID: ethereum, URL: "https://www.google.com:443"}, false},
+		{"grpc", HostedBlockchain{ID: ethereum, URL: "localhost:9090", Type: "GRPC"}, false},
+		{"grpc tls", HostedBlockchain{ID: ethereum, URL: "localhost:9090", Type: BackendGRPC, GRPC: &GRPCConfig{TLS: true, ServerName: "foo"}}, false},
+		{"grpc server name without tls", HostedBlockchain{ID: ethereum, URL: "localhost:9090", Type: BackendGRPC, GRPC: &GRPCConfig{ServerName: "foo"}}, true},
+		{"ipc", HostedBlockchain{ID: ethereum, URL: "/tmp/geth.ipc", Type: BackendIPC}, false},
+		{"ipc tcp", HostedBlockchain{ID: ethereum, URL: "localhost:8546", Type: BackendIPC, IPC: &IPCConfig{Network: "tcp"}}, false},
+		{"ipc invalid network", HostedBlockchain{ID: ethereum, URL: "localhost:8546", Type: BackendIPC, IPC: &IPCConfig{Network: "udp"}}, true},
+		{"unsupported type", HostedBlockchain{ID: ethereum, URL: "localhost:8546", Type: "websocket"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hb := HostedBlockchains{M: map[string]HostedBlockchain{tt.chain.ID: tt.chain}, L: sync.Mutex{}}
+			assert.Equal(t, tt.hasError, hb.Validate() != nil)
+		})
+	}
+}
+
+func TestIPCBackend_Execute(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ipc")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	lis, err := net.Listen("unix", filepath.Join(dir, "node.ipc"))
+	assert.Nil(t, err)
+	defer lis.Close()
+	// answers the JSON-RPC requests with their id
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				var req map[string]interface{}
+				if err := json.NewDecoder(conn).Decode(&req); err != nil {
+					return
+				}
+				_ = json.NewEncoder(conn).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req["id"], "result": "0x1"})
+			}(conn)
+		}
+	}()
+	chain := HostedBlockchain{ID: "0001", URL: "unix://" + lis.Addr().String(), Type: BackendIPC}
+	res, err := chain.Execute(Payload{Data: `{"jsonrpc":"2.0","method":"eth_blockNumber","params":[],"id":64}`})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":64,"result":"0x1"}`, res)
+	// the private namespaces are denied, unless the policy allows the method
+	_, err = chain.Execute(Payload{Data: `{"jsonrpc":"2.0","method":"admin_addPeer","params":[],"id":64}`})
+	assert.NotNil(t, err)
+	_, err = chain.Execute(Payload{Data: `[{"jsonrpc":"2.0","method":"eth_blockNumber","id":1},{"jsonrpc":"2.0","method":"personal_unlockAccount","id":2}]`})
+	assert.NotNil(t, err)
+	_, err = chain.Execute(Payload{Data: `not json-rpc`})
+	assert.NotNil(t, err)
+	chain.Policy = &RequestPolicy{AllowMethods: []string{"debug_traceTransaction"}}
+	res, err = chain.Execute(Payload{Data: `{"jsonrpc":"2.0","method":"debug_traceTransaction","params":[],"id":65}`})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":65,"result":"0x1"}`, res)
+	_, err = chain.Execute(Payload{Data: `{"jsonrpc":"2.0","method":"miner_start","params":[],"id":66}`})
+	assert.NotNil(t, err)
+	// an unreachable socket
+	chain.URL = filepath.Join(dir, "missing.ipc")
+	_, err = chain.Execute(Payload{Data: `{"jsonrpc":"2.0","method":"eth_blockNumber","params":[],"id":64}`})
+	assert.NotNil(t, err)
+}
+
+func TestGRPCBackend_Execute(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	// echoes the raw request of any method with the method name and the metadata of the call
+	srv := grpc.NewServer(grpc.ForceServerCodec(rawCodec{}), grpc.UnknownServiceHandler(func(_ interface{}, stream grpc.ServerStream) error {
+		var req []byte
+		if err := stream.RecvMsg(&req); err != nil {
+			return err
+		}
+		method, _ := grpc.MethodFromServerStream(stream)
+		md, _ := metadata.FromIncomingContext(stream.Context())
+		res := append([]byte(method+"|"+strings.Join(md.Get("x-foo"), ",")+"|"+strings.Join(md.Get("authorization"), ",")+"|"), req...)
+		return stream.SendMsg(&res)
+	}))
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+	chain := HostedBlockchain{ID: "0001", URL: lis.Addr().String(), Type: BackendGRPC, BasicAuth: BasicAuth{Username: "foo", Password: "bar"}}
+	req := []byte{0x0a, 0x03, 'f', 'o', 'o'}
+	res, err := chain.Execute(Payload{
+		Data:    base64.StdEncoding.EncodeToString(req),
+		Path:    "/cosmos.bank.v1beta1.Query/Balance",
+		Headers: map[string]string{"X-Foo": "bar"},
+	})
+	assert.Nil(t, err)
+	bz, err := base64.StdEncoding.DecodeString(res)
+	assert.Nil(t, err)
+	auth := "Basic " + base64.StdEncoding.EncodeToString([]byte("foo:bar"))
+	assert.Equal(t, append([]byte("/cosmos.bank.v1beta1.Query/Balance|bar|"+auth+"|"), req...), bz)
+	// the path must be a grpc method
+	_, err = chain.Execute(Payload{Data: base64.StdEncoding.EncodeToString(req), Path: "cosmos.bank.v1beta1.Query/Balance"})
+	assert.NotNil(t, err)
+	// the data must be base64
+	_, err = chain.Execute(Payload{Data: "not base64!", Path: "/cosmos.bank.v1beta1.Query/Balance"})
+	assert.NotNil(t, err)
+	// the connection is closed once no chain uses it
+	backend := relayBackends[BackendGRPC].(*grpcBackend)
+	conn, err := backend.conn(chain)
+	assert.Nil(t, err)
+	hb := HostedBlockchains{M: map[string]HostedBlockchain{chain.ID: chain}}
+	hb.Set(map[string]HostedBlockchain{chain.ID: chain})
+	assert.NotEqual(t, connectivity.Shutdown, conn.GetState())
+	moved := chain
+	moved.URL = "127.0.0.1:1"
+	hb.Set(map[string]HostedBlockchain{chain.ID: moved})
+	assert.Equal(t, connectivity.Shutdown, conn.GetState())
+	backend.l.Lock()
+	assert.Len(t, backend.conns, 0)
+	backend.l.Unlock()
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -24,8 +25,17 @@ var (
 	cacheOnce sync.Once
 
 	globalEvidenceSealedMap sync.Map
+	// the length of the key of a GOBEvidence object: the header hash || the type
+	evidenceKeyLength = HashLength + 1
+	// the locks of the evidence read-modify-write, sessions are spread over the shards by their header hash
+	sessionLocks [sessionLockShards]sync.Mutex
+	// the proofs of the relays in flight, admitted but not stored yet
+	globalProofReservations = proofReservations{m: make(map[string]map[string]struct{})}
 )
 
+// the number of shards of the session locks
+const sessionLockShards = 256
+
 // "CacheStorage" - Contains an LRU cache and a database instance w/ mutex
 type CacheStorage struct {
 	Cache *sdk.Cache // lru cache
@@ -42,7 +52,7 @@ type CacheObject interface {
 }
 
 // "Init" - Initializes a cache storage object
-func (cs *CacheStorage) Init(dir, name string, options config.LevelDBOptions, maxEntries int, inMemoryDB bool) {
+func (cs *CacheStorage) Init(dir, name string, backend db.BackendType, options config.LevelDBOptions, maxEntries int, inMemoryDB bool) {
 	// init the lru cache with a max entries
 	cs.Cache = sdk.NewCache(maxEntries)
 	// intialize the db
@@ -51,7 +61,7 @@ func (cs *CacheStorage) Init(dir, name string, options config.LevelDBOptions, ma
 		cs.DB = db.NewGoLevelMemDBWithCapacity(maxEntries)
 		return
 	}
-	cs.DB, err = sdk.NewLevelDB(name, dir, options.ToGoLevelDBOpts())
+	cs.DB, err = sdk.NewDB(name, dir, backend, options.ToGoLevelDBOpts())
 	if err != nil {
 		if err == syscall.EWOULDBLOCK {
 			message := fmt.Sprintf("can't open files needed for execution. Another instance may be running. path: %s\n", filepath.Join(dir, name+".db"))
@@ -194,11 +204,15 @@ func (cs *CacheStorage) Clear() {
 	defer cs.l.Unlock()
 	// clear cache
 	cs.Cache.Purge()
-	// clear db
+	// clear db, the in memory db can't be written while iterated
 	iter, _ := cs.DB.Iterator(nil, nil)
-	defer iter.Close()
+	var keys [][]byte
 	for ; iter.Valid(); iter.Next() {
-		_ = cs.DB.Delete(iter.Key())
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+	for _, k := range keys {
+		_ = cs.DB.Delete(k)
 	}
 }
 
@@ -247,6 +261,35 @@ func ClearSessionCache() {
 	}
 }
 
+// "ClearSessionCacheBefore" - Deletes the sessions of the session block heights before the height from the session cache,
+// so the sessions of the current session stay warm
+func ClearSessionCacheBefore(sessionBlockHeight int64) {
+	if globalSessionCache == nil {
+		return
+	}
+	cs := globalSessionCache
+	cs.l.Lock()
+	defer cs.l.Unlock()
+	// the sessions of the lru cache are flushed, so all of the sessions are iterated in the db
+	if err := cs.FlushToDBWithoutLock(); err != nil {
+		fmt.Printf("unable to flush the session cache to the db: %s\n", err.Error())
+		return
+	}
+	// the in memory db can't be written while iterated
+	iter, _ := cs.DB.Iterator(nil, nil)
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		s, err := Session{}.UnmarshalObject(iter.Value())
+		if session, ok := s.(Session); err != nil || !ok || session.SessionHeader.SessionBlockHeight < sessionBlockHeight {
+			keys = append(keys, iter.Key())
+		}
+	}
+	iter.Close()
+	for _, k := range keys {
+		_ = cs.DB.Delete(k)
+	}
+}
+
 // "SessionIt" - An iterator value for the sessionCache structure
 type SessionIt struct {
 	db.Iterator
@@ -316,29 +359,225 @@ func GetEvidence(header SessionHeader, evidenceType EvidenceType, max sdk.BigInt
 	return
 }
 
-// "SetEvidence" - Sets an GOBEvidence object in the storage
+// "SetEvidence" - Sets an GOBEvidence object in the storage. The proofs held by the object are appended under their own
+// keys, only the header record (count and bloom filter) of the evidence is rewritten
 func SetEvidence(evidence Evidence) {
+	globalEvidenceCache.l.Lock()
+	defer globalEvidenceCache.l.Unlock()
+	setEvidenceWithoutLock(evidence)
+}
+
+// "setEvidenceWithoutLock" - CONTRACT: used in a function with the lock of the evidence storage
+func setEvidenceWithoutLock(evidence Evidence) {
 	// generate the key for the evidence
 	key, err := evidence.Key()
 	if err != nil {
 		return
 	}
-	globalEvidenceCache.Set(key, evidence)
+	// a sealed evidence is read only
+	if _, ok := globalEvidenceSealedMap.Load(evidence.HashString()); ok {
+		return
+	}
+	if err := evidence.flushProofs(); err != nil {
+		fmt.Printf("ERROR: could not store the proofs of the evidence: %s\n", err.Error())
+		return
+	}
+	globalEvidenceCache.SetWithoutLockAndSealCheck(hex.EncodeToString(key), evidence)
+}
+
+// "flushProofs" - CONTRACT: used in a function with the lock of the evidence storage
+// Writes the proofs held by the evidence under their own keys and removes them from the object. The held proofs are
+// always the last ones of the evidence: the first NumOfProofs - len(Proofs) are already stored
+func (e *Evidence) flushProofs() error {
+	first := e.NumOfProofs - int64(len(e.Proofs))
+	for i, p := range e.Proofs {
+		key, err := KeyForEvidenceProof(e.SessionHeader, e.EvidenceType, first+int64(i))
+		if err != nil {
+			return err
+		}
+		bz, err := marshalProof(p)
+		if err != nil {
+			return err
+		}
+		if err = globalEvidenceCache.DB.Set(key, bz); err != nil {
+			return err
+		}
+	}
+	e.Proofs = nil
+	return nil
 }
 
-// "DeleteEvidence" - Remove the GOBEvidence from the stores
+// "IterateProofs" - Calls f with every proof of the evidence in order, the stored ones are read one at a time
+func (e Evidence) IterateProofs(f func(index int64, p Proof)) error {
+	stored := e.NumOfProofs - int64(len(e.Proofs))
+	if stored > 0 {
+		start, err := KeyForEvidenceProof(e.SessionHeader, e.EvidenceType, 0)
+		if err != nil {
+			return err
+		}
+		end, err := KeyForEvidenceProof(e.SessionHeader, e.EvidenceType, stored)
+		if err != nil {
+			return err
+		}
+		it, err := globalEvidenceCache.DB.Iterator(start, end)
+		if err != nil {
+			return err
+		}
+		defer it.Close()
+		index := int64(0)
+		for ; it.Valid(); it.Next() {
+			p, err := unmarshalProof(it.Value())
+			if err != nil {
+				return err
+			}
+			f(index, p)
+			index++
+		}
+		if index != stored {
+			return fmt.Errorf("the evidence has %d stored proofs, %d were found", stored, index)
+		}
+	}
+	for i, p := range e.Proofs {
+		f(stored+int64(i), p)
+	}
+	return nil
+}
+
+// "getProof" - Returns the proof of the evidence at the index, from the object or the storage
+func (e Evidence) getProof(index int64) (Proof, error) {
+	if index < 0 || index >= e.NumOfProofs {
+		return nil, fmt.Errorf("proof index %d out of bounds, the evidence has %d proofs", index, e.NumOfProofs)
+	}
+	stored := e.NumOfProofs - int64(len(e.Proofs))
+	if index >= stored {
+		return e.Proofs[index-stored], nil
+	}
+	key, err := KeyForEvidenceProof(e.SessionHeader, e.EvidenceType, index)
+	if err != nil {
+		return nil, err
+	}
+	bz, err := globalEvidenceCache.DB.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(bz) == 0 {
+		return nil, fmt.Errorf("proof %d of the evidence not found", index)
+	}
+	return unmarshalProof(bz)
+}
+
+// "marshalProof" - Encodes a proof of the evidence for its own record
+func marshalProof(p Proof) ([]byte, error) {
+	pi := p.ToProto()
+	return ModuleCdc.ProtoMarshalBinaryBare(&pi)
+}
+
+// "unmarshalProof" - Decodes the record of a proof of the evidence
+func unmarshalProof(bz []byte) (Proof, error) {
+	pi := ProofI{}
+	if err := ModuleCdc.ProtoUnmarshalBinaryBare(bz, &pi); err != nil {
+		return nil, fmt.Errorf("could not unmarshal the proof of the evidence: %s", err.Error())
+	}
+	// the proofs are added by value
+	switch p := pi.FromProto().(type) {
+	case *RelayProof:
+		return *p, nil
+	case *ChallengeProofInvalidData:
+		return *p, nil
+	default:
+		return p, nil
+	}
+}
+
+// "setEvidenceTree" - Persists the merkle sum tree of the evidence: number of proofs || number of leafs || the index of the
+// proof of every leaf || the tree
+func setEvidenceTree(e Evidence, t *MerkleSumTree, indices []int64) {
+	key, err := KeyForEvidenceTree(e.SessionHeader, e.EvidenceType)
+	if err != nil {
+		return
+	}
+	bz := make([]byte, 16+8*len(indices))
+	binary.BigEndian.PutUint64(bz, uint64(e.NumOfProofs))
+	binary.BigEndian.PutUint64(bz[8:], uint64(len(indices)))
+	for i, index := range indices {
+		binary.BigEndian.PutUint64(bz[16+8*i:], uint64(index))
+	}
+	_ = globalEvidenceCache.DB.Set(key, append(bz, t.Bytes()...))
+}
+
+// "getEvidenceTree" - Returns the persisted merkle sum tree of the evidence, if it is the one of the evidence at the height
+func getEvidenceTree(e Evidence, height int64) (t *MerkleSumTree, indices []int64, ok bool) {
+	key, err := KeyForEvidenceTree(e.SessionHeader, e.EvidenceType)
+	if err != nil {
+		return
+	}
+	bz, err := globalEvidenceCache.DB.Get(key)
+	if err != nil || len(bz) < 16 || int64(binary.BigEndian.Uint64(bz)) != e.NumOfProofs {
+		return
+	}
+	n := binary.BigEndian.Uint64(bz[8:])
+	if uint64(len(bz)) < 16+8*n {
+		return
+	}
+	indices = make([]int64, n)
+	for i := range indices {
+		indices[i] = int64(binary.BigEndian.Uint64(bz[16+8*i:]))
+	}
+	t, err = MerkleSumTreeFromBytes(bz[16+8*n:])
+	if err != nil || t.Height != height {
+		return nil, nil, false
+	}
+	return t, indices, true
+}
+
+// "DeleteEvidence" - Remove the GOBEvidence and its proofs from the stores
 func DeleteEvidence(header SessionHeader, evidenceType EvidenceType) error {
 	// generate key for GOBEvidence
 	key, err := KeyForEvidence(header, evidenceType)
 	if err != nil {
 		return err
 	}
+	unlock := lockSession(header)
+	defer unlock()
 	// delete from cache
 	globalEvidenceCache.Delete(key)
 	globalEvidenceSealedMap.Delete(header.HashString())
+	// delete the proofs, the seal and the tree: every key of the evidence || suffix
+	start, err := KeyForEvidenceProof(header, evidenceType, 0)
+	if err != nil {
+		return err
+	}
+	end := append(append([]byte{}, key...), 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff)
+	globalEvidenceCache.l.Lock()
+	defer globalEvidenceCache.l.Unlock()
+	it, err := globalEvidenceCache.DB.Iterator(start, end)
+	if err != nil {
+		return err
+	}
+	var keys [][]byte
+	for ; it.Valid(); it.Next() {
+		keys = append(keys, it.Key())
+	}
+	it.Close()
+	for _, k := range keys {
+		_ = globalEvidenceCache.DB.Delete(k)
+	}
 	return nil
 }
 
+// "IsEvidenceSealed" - Returns whether the GOBEvidence is sealed, in memory or by a seal persisted before a restart
+func IsEvidenceSealed(header SessionHeader, evidenceType EvidenceType) bool {
+	if _, ok := globalEvidenceSealedMap.Load(header.HashString()); ok {
+		return true
+	}
+	key, err := KeyForEvidenceSeal(header, evidenceType)
+	if err != nil {
+		return false
+	}
+	ok, err := globalEvidenceCache.DB.Has(key)
+	return err == nil && ok
+}
+
 // "SealEvidence" - Locks/sets the evidence from the stores
 func SealEvidence(evidence Evidence) (Evidence, bool) {
 	// delete from cache
@@ -347,7 +586,18 @@ func SealEvidence(evidence Evidence) (Evidence, bool) {
 		return Evidence{}, ok
 	}
 	e, ok := co.(Evidence)
-	return e, ok
+	if !ok {
+		return e, ok
+	}
+	// persist the seal, so the evidence store can be inspected offline
+	key, err := KeyForEvidenceSeal(e.SessionHeader, e.EvidenceType)
+	if err != nil {
+		return e, false
+	}
+	if err := globalEvidenceCache.DB.Set(key, []byte{1}); err != nil {
+		fmt.Printf("ERROR: could not persist the seal of the evidence: %s\n", err.Error())
+	}
+	return e, true
 }
 
 // "ClearEvidence" - Clear stores of all evidence
@@ -355,6 +605,7 @@ func ClearEvidence() {
 	if globalEvidenceCache != nil {
 		globalEvidenceCache.Clear()
 		globalEvidenceSealedMap = sync.Map{}
+		globalProofReservations.clear()
 	}
 }
 
@@ -377,13 +628,27 @@ func (ei *EvidenceIt) Value() (evidence Evidence) {
 	return
 }
 
+// "Next" - Moves the iterator to the next GOBEvidence object, skipping the records of the proofs
+func (ei *EvidenceIt) Next() {
+	ei.Iterator.Next()
+	ei.skipProofs()
+}
+
+// "skipProofs" - the proofs, seals and trees are keyed by the key of their evidence || suffix, the evidence keys are shorter
+func (ei *EvidenceIt) skipProofs() {
+	for ei.Iterator.Valid() && len(ei.Iterator.Key()) != evidenceKeyLength {
+		ei.Iterator.Next()
+	}
+}
+
 // "EvidenceIterator" - Returns a globalEvidenceCache iterator instance
 func EvidenceIterator() EvidenceIt {
 	it, _ := globalEvidenceCache.Iterator()
-
-	return EvidenceIt{
+	ei := EvidenceIt{
 		Iterator: it,
 	}
+	ei.skipProofs()
+	return ei
 }
 
 // "GetProof" - Returns the Proof object from a specific piece of GOBEvidence at a certain index
@@ -393,26 +658,171 @@ func GetProof(header SessionHeader, evidenceType EvidenceType, index int64) Proo
 	if err != nil {
 		return nil
 	}
-	// check for out of bounds
-	if evidence.NumOfProofs-1 < index || index < 0 {
+	// return the propoer proof, nil if out of bounds
+	p, err := evidence.getProof(index)
+	if err != nil {
 		return nil
 	}
-	// return the propoer proof
-	return evidence.Proofs[index]
+	return p
 }
 
-// "SetProof" - Sets a proof object in the GOBEvidence, using the header and GOBEvidence type
+// "SetProof" - Sets a proof object in the GOBEvidence, using the header and GOBEvidence type. The proof is appended
+// under its own key (header || type || index), so the cost of a relay doesn't grow with the size of the evidence
 func SetProof(header SessionHeader, evidenceType EvidenceType, p Proof, max sdk.BigInt) {
+	unlock := lockSession(header)
+	defer unlock()
 	// retireve the GOBEvidence
 	evidence, err := GetEvidence(header, evidenceType, max)
 	// if not found generate the GOBEvidence object
 	if err != nil {
 		log.Fatalf("could not set proof object: %s", err.Error())
 	}
-	// add proof
+	// add proof and set GOBEvidence back
+	addProof(evidence, p)
+}
+
+// "AdmitProof" - Checks that the proof may be added to the GOBEvidence and adds it, as one step for the session: the
+// evidence must not be sealed, the proof must be unique and the number of proofs must be under the max. The slot reserved
+// for the proof with ReserveProof, if any, is consumed
+func AdmitProof(header SessionHeader, evidenceType EvidenceType, p Proof, max sdk.BigInt) sdk.Error {
+	unlock := lockSession(header)
+	defer unlock()
+	// retrieve the GOBEvidence
+	evidence, err := GetEvidence(header, evidenceType, max)
+	if err != nil {
+		return sdk.ErrInternal(err.Error())
+	}
+	globalProofReservations.release(evidence, p)
+	if err := admissible(evidence, p, max); err != nil {
+		return err
+	}
+	// add proof and set GOBEvidence back
+	addProof(evidence, p)
+	return nil
+}
+
+// "ReserveProof" - Checks that the proof may be added to the GOBEvidence and reserves its slot, as one step for the
+// session, without adding it: the reserved proof counts as a duplicate and towards the max until it is admitted with
+// AdmitProof or released with ReleaseProof
+func ReserveProof(header SessionHeader, evidenceType EvidenceType, p Proof, max sdk.BigInt) sdk.Error {
+	unlock := lockSession(header)
+	defer unlock()
+	// retrieve the GOBEvidence
+	evidence, err := GetEvidence(header, evidenceType, max)
+	if err != nil {
+		return sdk.ErrInternal(err.Error())
+	}
+	if err := admissible(evidence, p, max); err != nil {
+		return err
+	}
+	globalProofReservations.reserve(evidence, p)
+	return nil
+}
+
+// "ReleaseProof" - Releases the slot reserved for the proof with ReserveProof, without adding it to the GOBEvidence
+func ReleaseProof(header SessionHeader, evidenceType EvidenceType, p Proof) {
+	unlock := lockSession(header)
+	defer unlock()
+	// only the header and the type of the GOBEvidence make the key of the reservations
+	globalProofReservations.release(Evidence{SessionHeader: header, EvidenceType: evidenceType}, p)
+}
+
+// "CheckProof" - Checks that the proof may be added to the GOBEvidence, under the lock of the session, without adding it
+func CheckProof(header SessionHeader, evidenceType EvidenceType, p Proof, max sdk.BigInt) sdk.Error {
+	unlock := lockSession(header)
+	defer unlock()
+	// retrieve the GOBEvidence
+	evidence, err := GetEvidence(header, evidenceType, max)
+	if err != nil {
+		return sdk.ErrInternal(err.Error())
+	}
+	return admissible(evidence, p, max)
+}
+
+// "admissible" - CONTRACT: used in a function with the lock of the session, the bloom filter is read in place
+func admissible(evidence Evidence, p Proof, max sdk.BigInt) sdk.Error {
+	if evidence.IsSealed() {
+		return NewSealedEvidenceError(ModuleName)
+	}
+	if !IsUniqueProof(p, evidence) || globalProofReservations.reserved(evidence, p) {
+		return NewDuplicateProofError(ModuleName)
+	}
+	if sdk.NewInt(evidence.NumOfProofs + globalProofReservations.count(evidence)).GTE(max) {
+		return NewOverServiceError(ModuleName)
+	}
+	return nil
+}
+
+// "proofReservations" - The hashes of the reserved proofs by the key of their GOBEvidence, in memory only
+// CONTRACT: read and modified in a function with the lock of the session
+type proofReservations struct {
+	m map[string]map[string]struct{}
+	l sync.Mutex
+}
+
+func (pr *proofReservations) reserve(evidence Evidence, p Proof) {
+	pr.l.Lock()
+	defer pr.l.Unlock()
+	key := reservationKey(evidence)
+	if pr.m[key] == nil {
+		pr.m[key] = make(map[string]struct{})
+	}
+	pr.m[key][p.HashString()] = struct{}{}
+}
+
+func (pr *proofReservations) release(evidence Evidence, p Proof) {
+	pr.l.Lock()
+	defer pr.l.Unlock()
+	key := reservationKey(evidence)
+	delete(pr.m[key], p.HashString())
+	if len(pr.m[key]) == 0 {
+		delete(pr.m, key)
+	}
+}
+
+func (pr *proofReservations) reserved(evidence Evidence, p Proof) bool {
+	pr.l.Lock()
+	defer pr.l.Unlock()
+	_, ok := pr.m[reservationKey(evidence)][p.HashString()]
+	return ok
+}
+
+func (pr *proofReservations) count(evidence Evidence) int64 {
+	pr.l.Lock()
+	defer pr.l.Unlock()
+	return int64(len(pr.m[reservationKey(evidence)]))
+}
+
+func (pr *proofReservations) clear() {
+	pr.l.Lock()
+	defer pr.l.Unlock()
+	pr.m = make(map[string]map[string]struct{})
+}
+
+// "reservationKey" - The key of the reservations of the GOBEvidence: the hex of the header hash || the type
+func reservationKey(evidence Evidence) string {
+	return fmt.Sprintf("%s%d", evidence.SessionHeader.HashString(), evidence.EvidenceType)
+}
+
+// "addProof" - CONTRACT: used in a function with the lock of the session
+// Adds the proof to the evidence and sets it back, with the lock of the evidence storage as the bits of the bloom filter
+// are set in place: the storage marshals the cached evidence when it flushes
+func addProof(evidence Evidence, p Proof) {
+	globalEvidenceCache.l.Lock()
+	defer globalEvidenceCache.l.Unlock()
+	// a sealed evidence is read only
+	if _, ok := globalEvidenceSealedMap.Load(evidence.HashString()); ok {
+		return
+	}
 	evidence.AddProof(p)
-	// set GOBEvidence back
-	SetEvidence(evidence)
+	setEvidenceWithoutLock(evidence)
+}
+
+// "lockSession" - Locks the shard of the session locks for the header and returns the unlock function
+func lockSession(header SessionHeader) (unlock func()) {
+	l := &sessionLocks[int(header.Hash()[0])%sessionLockShards]
+	l.Lock()
+	return l.Unlock
 }
 
 func IsUniqueProof(p Proof, evidence Evidence) bool {
@@ -5,6 +5,9 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 	"os"
 	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	sdk "github.com/pokt-network/pocket-core/types"
@@ -161,6 +164,241 @@ func TestAllEvidence_GetTotalProofs(t *testing.T) {
 	assert.Equal(t, totalRelays, int64(2))
 }
 
+func newTestEvidenceProof(header SessionHeader, entropy int64) RelayProof {
+	return RelayProof{
+		Entropy:            entropy,
+		SessionBlockHeight: header.SessionBlockHeight,
+		ServicerPubKey:     header.ApplicationPubKey, // fake
+		RequestHash:        header.HashString(),      // fake
+		Blockchain:         header.Chain,
+		Token: AAT{
+			Version:              "0.0.1",
+			ApplicationPublicKey: header.ApplicationPubKey,
+			ClientPublicKey:      header.ApplicationPubKey,
+			ApplicationSignature: "",
+		},
+		Signature: "",
+	}
+}
+
+func TestAllEvidence_AppendOnlyProofs(t *testing.T) {
+	header := SessionHeader{
+		ApplicationPubKey:  getRandomPubKey().RawString(),
+		Chain:              hex.EncodeToString([]byte{0001}),
+		SessionBlockHeight: 1,
+	}
+	proofs := make([]Proof, 0)
+	for i := int64(0); i < 10; i++ {
+		p := newTestEvidenceProof(header, i)
+		proofs = append(proofs, p)
+		SetProof(header, RelayEvidence, p, sdk.NewInt(100000))
+	}
+	// every proof has its own record
+	for i := int64(0); i < 10; i++ {
+		key, err := KeyForEvidenceProof(header, RelayEvidence, i)
+		assert.Nil(t, err)
+		bz, err := globalEvidenceCache.DB.Get(key)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, bz)
+		assert.Equal(t, proofs[i], GetProof(header, RelayEvidence, i))
+	}
+	assert.Nil(t, GetProof(header, RelayEvidence, 10))
+	// the evidence record only holds the header, count and bloom
+	evidence, err := GetEvidence(header, RelayEvidence, sdk.ZeroInt())
+	assert.Nil(t, err)
+	assert.Empty(t, evidence.Proofs)
+	assert.Equal(t, int64(10), evidence.NumOfProofs)
+	assert.False(t, IsUniqueProof(proofs[3], evidence))
+	// the iterator only returns the evidence
+	count := 0
+	iter := EvidenceIterator()
+	for ; iter.Valid(); iter.Next() {
+		if iter.Value().SessionHeader.HashString() == header.HashString() {
+			count++
+		}
+	}
+	iter.Close()
+	assert.Equal(t, 1, count)
+	// the streamed tree is the tree of the proofs
+	expectedRoot, _ := GenerateRoot(0, append([]Proof{}, proofs...))
+	root := evidence.GenerateMerkleRoot(0)
+	assert.Equal(t, expectedRoot, root)
+	for _, index := range []int{0, 7} {
+		mProof, leaf := evidence.GenerateMerkleProof(0, index)
+		expectedProof, expectedLeaf := GenerateProofs(0, append([]Proof{}, proofs...), index)
+		assert.Equal(t, expectedProof, mProof)
+		assert.Equal(t, expectedLeaf, leaf)
+		isValid, _ := mProof.Validate(0, root, leaf, len(mProof.HashRanges))
+		assert.True(t, isValid)
+	}
+	// sealed, no proof is appended anymore
+	SetProof(header, RelayEvidence, newTestEvidenceProof(header, 10), sdk.NewInt(100000))
+	_, total := GetTotalProofs(header, RelayEvidence, sdk.NewInt(100000))
+	assert.Equal(t, int64(10), total)
+	// deleting the evidence deletes its proofs
+	assert.Nil(t, DeleteEvidence(header, RelayEvidence))
+	for i := int64(0); i < 10; i++ {
+		key, _ := KeyForEvidenceProof(header, RelayEvidence, i)
+		bz, _ := globalEvidenceCache.DB.Get(key)
+		assert.Empty(t, bz)
+	}
+}
+
+func TestAllEvidence_InlineProofs(t *testing.T) {
+	header := SessionHeader{
+		ApplicationPubKey:  getRandomPubKey().RawString(),
+		Chain:              hex.EncodeToString([]byte{0001}),
+		SessionBlockHeight: 1,
+	}
+	// an evidence stored with its proofs, like before the proofs had their own records
+	evidence, err := GetEvidence(header, RelayEvidence, sdk.NewInt(100000))
+	assert.Nil(t, err)
+	proofs := make([]Proof, 0)
+	for i := int64(0); i < 3; i++ {
+		p := newTestEvidenceProof(header, i)
+		proofs = append(proofs, p)
+		evidence.AddProof(p)
+	}
+	key, err := evidence.Key()
+	assert.Nil(t, err)
+	globalEvidenceCache.Set(key, evidence)
+	assert.Nil(t, globalEvidenceCache.FlushToDB())
+	assert.Equal(t, proofs[1], GetProof(header, RelayEvidence, 1))
+	// appending moves them to their own records
+	p := newTestEvidenceProof(header, 3)
+	proofs = append(proofs, p)
+	SetProof(header, RelayEvidence, p, sdk.NewInt(100000))
+	evidence, err = GetEvidence(header, RelayEvidence, sdk.ZeroInt())
+	assert.Nil(t, err)
+	assert.Empty(t, evidence.Proofs)
+	for i := int64(0); i < 4; i++ {
+		assert.Equal(t, proofs[i], GetProof(header, RelayEvidence, i))
+	}
+	_ = DeleteEvidence(header, RelayEvidence)
+}
+
+// the cost of a relay doesn't depend on the number of proofs already in the evidence
+func TestAdmitProof_Concurrent(t *testing.T) {
+	header := SessionHeader{
+		ApplicationPubKey:  getRandomPubKey().RawString(),
+		Chain:              hex.EncodeToString([]byte{0001}),
+		SessionBlockHeight: 1,
+	}
+	const workers, proofs = 8, 300
+	// every worker tries every proof: each one must be admitted exactly once
+	var admitted int64
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := int64(0); i < proofs; i++ {
+				err := AdmitProof(header, RelayEvidence, newTestEvidenceProof(header, i), sdk.NewInt(proofs*2))
+				if err == nil {
+					atomic.AddInt64(&admitted, 1)
+				} else {
+					assert.Equal(t, sdk.CodeType(CodeDuplicateProofError), err.Code())
+				}
+			}
+		}()
+	}
+	// the bloom filter is set in place while the storage flushes and the relays are checked
+	done := make(chan struct{})
+	checked := make(chan struct{})
+	go func() {
+		defer close(checked)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				assert.Nil(t, globalEvidenceCache.FlushToDB())
+				_ = CheckProof(header, RelayEvidence, newTestEvidenceProof(header, proofs), sdk.NewInt(proofs*2))
+			}
+		}
+	}()
+	wg.Wait()
+	close(done)
+	<-checked
+	assert.Nil(t, CheckProof(header, RelayEvidence, newTestEvidenceProof(header, proofs), sdk.NewInt(proofs*2)))
+	assert.Equal(t, sdk.CodeType(CodeDuplicateProofError), CheckProof(header, RelayEvidence, newTestEvidenceProof(header, 0), sdk.NewInt(proofs*2)).Code())
+	assert.Equal(t, int64(proofs), admitted)
+	evidence, err := GetEvidence(header, RelayEvidence, sdk.ZeroInt())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(proofs), evidence.NumOfProofs)
+	for i := int64(0); i < evidence.NumOfProofs; i++ {
+		assert.NotNil(t, GetProof(header, RelayEvidence, i))
+	}
+	// distinct proofs at the edge of the max: no more than the max are admitted
+	header.SessionBlockHeight = 2
+	max := sdk.NewInt(10)
+	admitted = 0
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int64) {
+			defer wg.Done()
+			for i := int64(0); i < proofs; i++ {
+				if AdmitProof(header, RelayEvidence, newTestEvidenceProof(header, w*proofs+i), max) == nil {
+					atomic.AddInt64(&admitted, 1)
+				}
+			}
+		}(int64(w))
+	}
+	wg.Wait()
+	assert.Equal(t, max.Int64(), admitted)
+	evidence, err = GetEvidence(header, RelayEvidence, sdk.ZeroInt())
+	assert.Nil(t, err)
+	assert.Equal(t, max.Int64(), evidence.NumOfProofs)
+	ClearEvidence()
+}
+
+func TestReserveProof(t *testing.T) {
+	header := SessionHeader{
+		ApplicationPubKey:  getRandomPubKey().RawString(),
+		Chain:              hex.EncodeToString([]byte{0001}),
+		SessionBlockHeight: 1,
+	}
+	max := sdk.NewInt(2)
+	// a reserved proof is a duplicate and counts towards the max, without being stored
+	assert.Nil(t, ReserveProof(header, RelayEvidence, newTestEvidenceProof(header, 0), max))
+	assert.Equal(t, sdk.CodeType(CodeDuplicateProofError), ReserveProof(header, RelayEvidence, newTestEvidenceProof(header, 0), max).Code())
+	assert.Equal(t, sdk.CodeType(CodeDuplicateProofError), CheckProof(header, RelayEvidence, newTestEvidenceProof(header, 0), max).Code())
+	assert.Nil(t, ReserveProof(header, RelayEvidence, newTestEvidenceProof(header, 1), max))
+	assert.Equal(t, sdk.CodeType(CodeOverServiceError), ReserveProof(header, RelayEvidence, newTestEvidenceProof(header, 2), max).Code())
+	_, total := GetTotalProofs(header, RelayEvidence, max)
+	assert.Equal(t, int64(0), total)
+	// the admitted proof consumes its reservation, the released one frees its slot
+	assert.Nil(t, AdmitProof(header, RelayEvidence, newTestEvidenceProof(header, 0), max))
+	ReleaseProof(header, RelayEvidence, newTestEvidenceProof(header, 1))
+	assert.Nil(t, ReserveProof(header, RelayEvidence, newTestEvidenceProof(header, 2), max))
+	assert.Nil(t, AdmitProof(header, RelayEvidence, newTestEvidenceProof(header, 2), max))
+	assert.Equal(t, sdk.CodeType(CodeDuplicateProofError), AdmitProof(header, RelayEvidence, newTestEvidenceProof(header, 0), max).Code())
+	_, total = GetTotalProofs(header, RelayEvidence, max)
+	assert.Equal(t, int64(2), total)
+	ClearEvidence()
+}
+
+func BenchmarkSetProof(b *testing.B) {
+	for _, existing := range []int{100, 10000, 50000} {
+		b.Run(strconv.Itoa(existing), func(b *testing.B) {
+			ClearEvidence()
+			header := SessionHeader{
+				ApplicationPubKey:  getRandomPubKey().RawString(),
+				Chain:              hex.EncodeToString([]byte{0001}),
+				SessionBlockHeight: 1,
+			}
+			max := sdk.NewInt(int64(existing + b.N + 1))
+			for i := 0; i < existing; i++ {
+				SetProof(header, RelayEvidence, newTestEvidenceProof(header, int64(i)), max)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				SetProof(header, RelayEvidence, newTestEvidenceProof(header, int64(existing+i)), max)
+			}
+		})
+	}
+}
+
 func TestSetGetSession(t *testing.T) {
 	session := NewTestSession(t, hex.EncodeToString(Hash([]byte("foo"))))
 	session2 := NewTestSession(t, hex.EncodeToString(Hash([]byte("bar"))))
@@ -197,6 +435,21 @@ func TestClearCache(t *testing.T) {
 	assert.Zero(t, count)
 }
 
+func TestClearSessionCacheBefore(t *testing.T) {
+	ClearSessionCache()
+	previous := NewTestSession(t, hex.EncodeToString(Hash([]byte("foo"))))
+	current := NewTestSession(t, hex.EncodeToString(Hash([]byte("bar"))))
+	current.SessionHeader.SessionBlockHeight = 5
+	SetSession(previous)
+	SetSession(current)
+	ClearSessionCacheBefore(5)
+	_, found := GetSession(previous.SessionHeader)
+	assert.False(t, found)
+	s, found := GetSession(current.SessionHeader)
+	assert.True(t, found)
+	assert.Equal(t, current.SessionHeader, s.SessionHeader)
+}
+
 func NewTestSession(t *testing.T, chain string) Session {
 	appPubKey := getRandomPubKey()
 	var vals []sdk.Address
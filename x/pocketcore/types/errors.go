@@ -94,6 +94,12 @@ const (
 	CodeInvalidExpirationHeightErr       = 88
 	CodeInvalidMerkleRangeError          = 89
 	CodeEvidenceSealed                   = 90
+	CodeBackendExecutionError            = 91
+	CodePolicyRejectedError              = 92
+	CodeBackendResponseError             = 93
+	CodeFutureSessionHeightError         = 94
+	CodeUnavailableSessionHeightError    = 95
+	CodeTooManyChainsError               = 96
 )
 
 var (
@@ -132,6 +138,12 @@ var (
 	PubKeyDecodeError                = errors.New("error decoding the string into hex bytes")
 	InvalidHashError                 = errors.New("the hash is invalid: ")
 	HTTPExecutionError               = errors.New("error executing the http request: ")
+	BackendExecutionError            = errors.New("error executing the request on the backend: ")
+	PolicyRejectedError              = errors.New("the relay is rejected by the policy of the chain: ")
+	BackendResponseError             = errors.New("the backend response failed the checks of the chain: ")
+	FutureSessionHeightError         = errors.New("the session height is in the future: ")
+	UnavailableSessionHeightError    = errors.New("the state of the session height is not available: ")
+	TooManyChainsError               = errors.New("too many chains in the batch: ")
 	TicketsNotFoundError             = errors.New("the tickets requested could not be found")
 	DuplicateTicketError             = errors.New("the ticket is a duplicate")
 	InvalidSignatureSizeError        = errors.New("the signature Length is invalid")
@@ -401,6 +413,30 @@ func NewInvalidEntropyError(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidEntropyError, InvalidEntropyError.Error())
 }
 
+func NewBackendExecutionError(codespace sdk.CodespaceType, backendType string, err error) sdk.Error {
+	return sdk.NewError(codespace, CodeBackendExecutionError, BackendExecutionError.Error()+backendType+": "+err.Error())
+}
+
+func NewPolicyRejectedError(codespace sdk.CodespaceType, reason string, err error) sdk.Error {
+	return sdk.NewError(codespace, CodePolicyRejectedError, PolicyRejectedError.Error()+reason+": "+err.Error())
+}
+
+func NewBackendResponseError(codespace sdk.CodespaceType, err error) sdk.Error {
+	return sdk.NewError(codespace, CodeBackendResponseError, BackendResponseError.Error()+err.Error())
+}
+
+func NewFutureSessionHeightError(codespace sdk.CodespaceType, sessionBlockHeight, latestSessionBlockHeight int64) sdk.Error {
+	return sdk.NewError(codespace, CodeFutureSessionHeightError, FutureSessionHeightError.Error()+fmt.Sprintf("%d, the latest session height is %d", sessionBlockHeight, latestSessionBlockHeight))
+}
+
+func NewUnavailableSessionHeightError(codespace sdk.CodespaceType, sessionBlockHeight int64, err error) sdk.Error {
+	return sdk.NewError(codespace, CodeUnavailableSessionHeightError, UnavailableSessionHeightError.Error()+fmt.Sprintf("%d: %s", sessionBlockHeight, err.Error()))
+}
+
+func NewTooManyChainsError(codespace sdk.CodespaceType, chains int) sdk.Error {
+	return sdk.NewError(codespace, CodeTooManyChainsError, TooManyChainsError.Error()+fmt.Sprintf("%d, the maximum is %d", chains, MaxBatchDispatchChains))
+}
+
 func NewHTTPExecutionError(codespace sdk.CodespaceType, err error) sdk.Error {
 	return sdk.NewError(codespace, CodeHTTPExecutionError, HTTPExecutionError.Error()+err.Error())
 }
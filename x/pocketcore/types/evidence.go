@@ -38,25 +38,86 @@ func (e *Evidence) GenerateMerkleRoot(height int64) (root HashRange) {
 		return HashRange{}
 	}
 	// generate the root object
-	root, _ = GenerateRoot(height, ev.Proofs)
-	return
+	root, err := ev.MerkleRoot(height)
+	if err != nil {
+		return HashRange{}
+	}
+	return root
+}
+
+// "MerkleRoot" - Computes the merkle root of the evidence without sealing it
+func (e Evidence) MerkleRoot(height int64) (HashRange, error) {
+	t, _, err := e.MerkleSumTree(height)
+	if err != nil {
+		return HashRange{}, err
+	}
+	return t.Root(), nil
+}
+
+// "MerkleSumTree" - Returns the merkle sum tree of the evidence, with the index of the proof of each leaf. The tree of a
+// sealed evidence can't change: it is built once and persisted next to the evidence
+func (e Evidence) MerkleSumTree(height int64) (*MerkleSumTree, []int64, error) {
+	if t, indices, ok := getEvidenceTree(e, height); ok {
+		return t, indices, nil
+	}
+	data, indices, err := e.leafs()
+	if err != nil {
+		return nil, nil, err
+	}
+	t, err := NewMerkleSumTree(height, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if e.IsSealed() {
+		setEvidenceTree(e, t, indices)
+	}
+	return t, indices, nil
 }
 
 // "AddProof" - Adds a proof obj to the GOBEvidence field
+// CONTRACT: used with the lock of the session and of the evidence storage, the bits of the bloom filter are shared
+// with the cached evidence
 func (e *Evidence) AddProof(p Proof) {
 	// add proof to GOBEvidence
 	e.Proofs = append(e.Proofs, p)
 	// increment total proof count
 	e.NumOfProofs = e.NumOfProofs + 1
-	// add proof to bloom filter
+	// add proof to the bloom filter
 	e.Bloom.Add(p.Hash())
 }
 
 // "GenerateMerkleProof" - Generates the merkle Proof for an GOBEvidence
 func (e *Evidence) GenerateMerkleProof(height int64, index int) (proof MerkleProof, leaf Proof) {
+	t, indices, err := e.MerkleSumTree(height)
+	if err != nil || index < 0 || index >= len(indices) {
+		return
+	}
 	// generate the merkle proof
-	proof, leaf = GenerateProofs(height, e.Proofs, index)
-	// set the evidence in memory
+	proof, err = t.Proof(index)
+	if err != nil {
+		return MerkleProof{}, nil
+	}
+	// get the leaf from the storage
+	leaf, err = e.getProof(indices[index])
+	if err != nil {
+		return MerkleProof{}, nil
+	}
+	return
+}
+
+// "leafs" - Returns the sorted and structured leafs of the merkle tree of the evidence, with the index of the proof of
+// each leaf. The proofs are streamed, only their hash ranges are held in memory
+func (e Evidence) leafs() (data []HashRange, indices []int64, err error) {
+	data = make([]HashRange, 0, e.NumOfProofs)
+	indices = make([]int64, 0, e.NumOfProofs)
+	err = e.IterateProofs(func(index int64, p Proof) {
+		data = append(data, newLeaf(p))
+		indices = append(indices, index)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	data, indices = sortAndStructureLeafs(data, indices)
 	return
 }
 
@@ -7,9 +7,15 @@ import (
 
 // HostedBlockchain" - An object that represents a local hosted non-native blockchain
 type HostedBlockchain struct {
-	ID        string    `json:"id"`         // network identifier of the hosted blockchain
-	URL       string    `json:"url"`        // url of the hosted blockchain
-	BasicAuth BasicAuth `json:"basic_auth"` // basic http auth optinal
+	ID             string          `json:"id"`                        // network identifier of the hosted blockchain
+	URL            string          `json:"url"`                       // url of the hosted blockchain
+	BasicAuth      BasicAuth       `json:"basic_auth"`                // basic http auth optinal
+	MaxBodySize    int64           `json:"max_body_size,omitempty"`   // the size limit of the relay request bodies (optional)
+	Type           string          `json:"type,omitempty"`            // the backend type: http (default), grpc or ipc
+	GRPC           *GRPCConfig     `json:"grpc,omitempty"`            // the config of the grpc backend (optional)
+	IPC            *IPCConfig      `json:"ipc,omitempty"`             // the config of the ipc backend (optional)
+	Policy         *RequestPolicy  `json:"policy,omitempty"`          // the policy of the relay requests (optional)
+	ResponseChecks *ResponseChecks `json:"response_checks,omitempty"` // the checks of the backend responses (optional)
 }
 
 type BasicAuth struct {
@@ -23,6 +29,14 @@ type HostedBlockchains struct {
 	L sync.Mutex
 }
 
+// "Set" - Replaces the hosted chains, and frees the resources of the backends (e.g. connections) they no longer use
+func (c *HostedBlockchains) Set(m map[string]HostedBlockchain) {
+	c.L.Lock()
+	defer c.L.Unlock()
+	c.M = m
+	releaseBackends(m)
+}
+
 // "Contains" - Checks to see if the hosted chain is within the HostedBlockchains object
 func (c *HostedBlockchains) Contains(id string) bool {
 	c.L.Lock()
@@ -53,6 +67,31 @@ func (c *HostedBlockchains) GetChainURL(id string) (url string, err sdk.Error) {
 	return chain.URL, nil
 }
 
+// "MaxBodySize" - Returns the size limit of the relay request bodies of the hosted blockchain: its own limit, or the
+// default one of the config
+func (c *HostedBlockchains) MaxBodySize(id string) int64 {
+	c.L.Lock()
+	defer c.L.Unlock()
+	if chain, found := c.M[id]; found && chain.MaxBodySize > 0 {
+		return chain.MaxBodySize
+	}
+	return GlobalPocketConfig.MaxRelayBodySize
+}
+
+// "LargestMaxBodySize" - Returns the largest size limit of the relay request bodies of all the hosted blockchains, the
+// most a relay request body is read before its chain is known
+func (c *HostedBlockchains) LargestMaxBodySize() int64 {
+	c.L.Lock()
+	defer c.L.Unlock()
+	res := GlobalPocketConfig.MaxRelayBodySize
+	for _, chain := range c.M {
+		if chain.MaxBodySize > res {
+			res = chain.MaxBodySize
+		}
+	}
+	return res
+}
+
 // "Validate" - Validates the hosted blockchain object
 func (c *HostedBlockchains) Validate() error {
 	c.L.Lock()
@@ -67,6 +106,23 @@ func (c *HostedBlockchains) Validate() error {
 		if err := NetworkIdentifierVerification(chain.ID); err != nil {
 			return err
 		}
+		if chain.MaxBodySize < 0 {
+			return NewInvalidHostedChainError(ModuleName)
+		}
+		// validate the backend of the chain
+		backend, err := chain.Backend()
+		if err != nil {
+			return NewInvalidHostedChainError(ModuleName)
+		}
+		if err := backend.ValidateChain(chain); err != nil {
+			return NewInvalidHostedChainError(ModuleName)
+		}
+		if err := chain.Policy.Validate(); err != nil {
+			return NewInvalidHostedChainError(ModuleName)
+		}
+		if err := chain.ResponseChecks.Validate(); err != nil {
+			return NewInvalidHostedChainError(ModuleName)
+		}
 	}
 	return nil
 }
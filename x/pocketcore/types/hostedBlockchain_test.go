@@ -91,3 +91,22 @@ func TestHostedBlockchains_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestHostedBlockchains_MaxBodySize(t *testing.T) {
+	ethereum := hex.EncodeToString([]byte{01})
+	bitcoin := hex.EncodeToString([]byte{02})
+	hb := HostedBlockchains{
+		M: map[string]HostedBlockchain{
+			ethereum: {ID: ethereum, URL: "https://www.google.com:443", MaxBodySize: 10 * GlobalPocketConfig.MaxRelayBodySize},
+			bitcoin:  {ID: bitcoin, URL: "https://www.google.com:443"},
+		},
+		L: sync.Mutex{},
+	}
+	assert.Equal(t, 10*GlobalPocketConfig.MaxRelayBodySize, hb.MaxBodySize(ethereum))
+	assert.Equal(t, GlobalPocketConfig.MaxRelayBodySize, hb.MaxBodySize(bitcoin))
+	assert.Equal(t, GlobalPocketConfig.MaxRelayBodySize, hb.MaxBodySize(hex.EncodeToString([]byte{03})))
+	assert.Equal(t, 10*GlobalPocketConfig.MaxRelayBodySize, hb.LargestMaxBodySize())
+	// a negative limit is invalid
+	hb.M[bitcoin] = HostedBlockchain{ID: bitcoin, URL: "https://www.google.com:443", MaxBodySize: -1}
+	assert.NotNil(t, hb.Validate())
+}
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"encoding/binary"
+
 	sdk "github.com/pokt-network/pocket-core/types"
 )
 
@@ -59,3 +61,37 @@ func KeyForEvidence(header SessionHeader, evidenceType EvidenceType) ([]byte, er
 	}
 	return append(header.Hash(), et), nil
 }
+
+// "KeyForEvidenceProof" - Generates the key for a proof of the GOBEvidence: the key of the evidence || the index
+func KeyForEvidenceProof(header SessionHeader, evidenceType EvidenceType, index int64) ([]byte, error) {
+	key, err := KeyForEvidence(header, evidenceType)
+	if err != nil {
+		return nil, err
+	}
+	return append(key, proofIndexBytes(index)...), nil
+}
+
+// "KeyForEvidenceSeal" - Generates the key of the persisted seal of the GOBEvidence: the key of the evidence || 0xff
+func KeyForEvidenceSeal(header SessionHeader, evidenceType EvidenceType) ([]byte, error) {
+	key, err := KeyForEvidence(header, evidenceType)
+	if err != nil {
+		return nil, err
+	}
+	return append(key, 0xff), nil
+}
+
+// "KeyForEvidenceTree" - Generates the key of the persisted merkle sum tree of the GOBEvidence: the key of the evidence || 0xfe
+func KeyForEvidenceTree(header SessionHeader, evidenceType EvidenceType) ([]byte, error) {
+	key, err := KeyForEvidence(header, evidenceType)
+	if err != nil {
+		return nil, err
+	}
+	return append(key, 0xfe), nil
+}
+
+// "proofIndexBytes" - the big endian bytes of a proof index, so the proofs are iterated in order
+func proofIndexBytes(index int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(index))
+	return bz
+}
@@ -31,6 +31,19 @@ func (a SortByProof) Swap(i, j int) {
 }
 func (a SortByProof) Less(i, j int) bool { return a.hr[i].Range.Upper < a.hr[j].Range.Upper }
 
+type leafsAndIndices struct {
+	hr []HashRange
+	i  []int64
+}
+type SortByLeaf leafsAndIndices
+
+func (a SortByLeaf) Len() int { return len(a.hr) }
+func (a SortByLeaf) Swap(i, j int) {
+	a.hr[i], a.hr[j] = a.hr[j], a.hr[i]
+	a.i[i], a.i[j] = a.i[j], a.i[i]
+}
+func (a SortByLeaf) Less(i, j int) bool { return a.hr[i].Range.Upper < a.hr[j].Range.Upper }
+
 func uint64ToBytes(a uint64, x uint64) []byte {
 	b := make([]byte, 16)
 	binary.LittleEndian.PutUint64(b, a)
@@ -245,21 +258,7 @@ func sortAndStructure(proofs []Proof) (d []HashRange, sortedProofs []Proof) { //
 		// update the lower
 		lower = hashRanges[i].Range.Upper
 	}
-	// calculate the proper length of the merkle tree
-	properLength := nextPowerOfTwo(uint(numberOfProofs))
-	// generate padding to make it a proper merkle tree
-	padding := make([]HashRange, int(properLength)-numberOfProofs)
-	// add it to the merkleHash rangeds
-	hashRanges = append(hashRanges, padding...)
-	// add padding to the end of the hashRange
-	for i := numberOfProofs; i < int(properLength); i++ {
-		hashRanges[i] = HashRange{
-			Hash:  merkleHash([]byte(strconv.Itoa(i))),
-			Range: Range{Lower: lower, Upper: lower + 1},
-		}
-		lower = hashRanges[i].Range.Upper
-	}
-	return hashRanges, proofs
+	return padLeafs(hashRanges, lower), proofs
 }
 
 // "structureProofs" - structure hash ranges when proofs are already sorted
@@ -284,21 +283,47 @@ func structureProofs(proofs []Proof) (d []HashRange, sortedProofs []Proof) {
 			lower = hashRanges[i].Range.Upper
 		}
 	}
+	return padLeafs(hashRanges, lower), proofs
+}
+
+// "newLeaf" - the hash range of a proof, before the lower of its range is known
+func newLeaf(p Proof) HashRange {
+	hash := merkleHash(p.Bytes())
+	return HashRange{Hash: hash, Range: Range{Upper: sumFromHash(hash)}}
+}
 
-	properLength := nextPowerOfTwo(uint(numberOfProofs))
+// "sortAndStructureLeafs" - sortAndStructure for leafs already hashed, keeping track of the index of their proof
+func sortAndStructureLeafs(hashRanges []HashRange, indices []int64) ([]HashRange, []int64) {
+	// sort the slice based on the numerical value of the upper value (just the decimal representation of the merkleHash)
+	sort.Sort(SortByLeaf(leafsAndIndices{hashRanges, indices}))
+	// keep track of previous upper (next values lower)
+	lower := uint64(0)
+	// set the lower values of each
+	for i := range hashRanges {
+		hashRanges[i].Range.Lower = lower
+		lower = hashRanges[i].Range.Upper
+	}
+	return padLeafs(hashRanges, lower), indices
+}
+
+// "padLeafs" - pads the leafs up to the next power of two, to make it a proper merkle tree
+func padLeafs(hashRanges []HashRange, lower uint64) []HashRange {
+	numberOfLeafs := len(hashRanges)
+	// calculate the proper length of the merkle tree
+	properLength := nextPowerOfTwo(uint(numberOfLeafs))
 	// generate padding to make it a proper merkle tree
-	padding := make([]HashRange, int(properLength)-numberOfProofs)
+	padding := make([]HashRange, int(properLength)-numberOfLeafs)
 	// add it to the merkleHash rangeds
 	hashRanges = append(hashRanges, padding...)
 	// add padding to the end of the hashRange
-	for i := numberOfProofs; i < int(properLength); i++ {
+	for i := numberOfLeafs; i < int(properLength); i++ {
 		hashRanges[i] = HashRange{
 			Hash:  merkleHash([]byte(strconv.Itoa(i))),
 			Range: Range{Lower: lower, Upper: lower + 1},
 		}
 		lower = hashRanges[i].Range.Upper
 	}
-	return hashRanges, proofs
+	return hashRanges
 }
 
 func MultiAppend(dest []byte, s ...[]byte) []byte {
@@ -0,0 +1,55 @@
+//go:build go1.18
+// +build go1.18
+
+package types
+
+import (
+	"testing"
+)
+
+// FuzzMerkleSumTree checks the proofs of the tree against MerkleProof.Validate: every proof is valid for its leaf and
+// the root, and a tampered proof never is
+func FuzzMerkleSumTree(f *testing.F) {
+	f.Add(uint16(2), int64(1), uint16(0), uint16(0), false)
+	f.Add(uint16(5), int64(2), uint16(4), uint16(1), true)
+	f.Add(uint16(100), int64(3), uint16(57), uint16(300), false)
+	f.Fuzz(func(t *testing.T, n uint16, seed int64, index uint16, tamper uint16, afterUpgrade bool) {
+		numOfProofs := int(n%512) + 2
+		height := int64(0)
+		if afterUpgrade {
+			height = -1
+		}
+		tree, sorted, err := NewMerkleSumTreeFromProofs(height, randomProofs(numOfProofs, seed))
+		if err != nil {
+			t.Fatal(err)
+		}
+		i := int(index) % numOfProofs
+		mp, err := tree.Proof(i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if isValid, _ := mp.Validate(height, tree.Root(), sorted[i], len(mp.HashRanges)); !isValid {
+			t.Fatalf("the proof of leaf %d of %d is not valid", i, numOfProofs)
+		}
+		// the proof of another leaf
+		if other := sorted[(i+1)%numOfProofs]; other.HashString() != sorted[i].HashString() {
+			if isValid, _ := mp.Validate(height, tree.Root(), other, len(mp.HashRanges)); isValid {
+				t.Fatalf("the proof of leaf %d is valid for another leaf", i)
+			}
+		}
+		// a flipped bit of a sibling
+		level := int(tamper) % len(mp.HashRanges)
+		tampered := MerkleProof{TargetIndex: mp.TargetIndex, Target: mp.Target, HashRanges: append([]HashRange{}, mp.HashRanges...)}
+		tampered.HashRanges[level].Hash = append([]byte{}, tampered.HashRanges[level].Hash...)
+		tampered.HashRanges[level].Hash[int(tamper)%MerkleHashLength] ^= 1 << (tamper % 8)
+		if isValid, _ := tampered.Validate(height, tree.Root(), sorted[i], len(mp.HashRanges)); isValid {
+			t.Fatalf("the tampered proof of leaf %d is valid", i)
+		}
+		// the proof at the wrong index
+		tampered = mp
+		tampered.TargetIndex = int64((i + 1) % tree.NumOfLeafs())
+		if isValid, _ := tampered.Validate(height, tree.Root(), sorted[i], len(mp.HashRanges)); isValid && height == -1 {
+			t.Fatalf("the proof of leaf %d is valid at index %d", i, tampered.TargetIndex)
+		}
+	})
+}
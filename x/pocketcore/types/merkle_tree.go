@@ -0,0 +1,155 @@
+package types
+
+import (
+	"encoding/binary"
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// the number of parents of a level from which the level is built in parallel
+var parallelLevelThreshold = 1 << 12
+
+// the length of an encoded node of the tree: hash || lower || upper
+const merkleNodeLength = MerkleHashLength + 16
+
+// "MerkleSumTree" - A merkle sum tree built once from its leafs, with every level cached to answer the proofs. The
+// roots and the proofs are the ones of GenerateRoot and GenerateProofs
+type MerkleSumTree struct {
+	Height int64         // the height of the tree, which selects the parent hash function
+	Levels [][]HashRange // the levels from the leafs to the root
+}
+
+// "NewMerkleSumTree" - Builds the tree from the sorted and structured leafs (see sortAndStructure), the leafs are not
+// modified. CONTRACT: the number of leafs is a power of two greater than one
+func NewMerkleSumTree(height int64, leafs []HashRange) (*MerkleSumTree, error) {
+	if len(leafs) < 2 || len(leafs)&(len(leafs)-1) != 0 {
+		return nil, fmt.Errorf("the number of leafs of a merkle sum tree must be a power of two greater than one, got %d", len(leafs))
+	}
+	level := make([]HashRange, len(leafs))
+	copy(level, leafs)
+	t := &MerkleSumTree{Height: height, Levels: [][]HashRange{level}}
+	for len(level) > 1 {
+		level = t.nextLevel(level)
+		t.Levels = append(t.Levels, level)
+	}
+	return t, nil
+}
+
+// "NewMerkleSumTreeFromProofs" - Builds the tree of the proofs, returning the proofs in the order of the leafs
+func NewMerkleSumTreeFromProofs(height int64, proofs []Proof) (*MerkleSumTree, []Proof, error) {
+	if len(proofs) == 0 {
+		return nil, nil, fmt.Errorf("a merkle sum tree needs at least one proof")
+	}
+	data, sorted := sortAndStructure(proofs)
+	t, err := NewMerkleSumTree(height, data)
+	return t, sorted, err
+}
+
+// "nextLevel" - Computes the parents of the level, in parallel for big levels
+func (t *MerkleSumTree) nextLevel(level []HashRange) []HashRange {
+	parents := make([]HashRange, len(level)/2)
+	workers := runtime.GOMAXPROCS(0)
+	if len(parents) < parallelLevelThreshold || workers == 1 {
+		t.parents(level, parents, 0, len(parents))
+		return parents
+	}
+	chunk := (len(parents) + workers - 1) / workers
+	var wg sync.WaitGroup
+	for start := 0; start < len(parents); start += chunk {
+		end := start + chunk
+		if end > len(parents) {
+			end = len(parents)
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			t.parents(level, parents, start, end)
+		}(start, end)
+	}
+	wg.Wait()
+	return parents
+}
+
+// "parents" - Computes the parents [start, end) of the level, as levelUp does
+func (t *MerkleSumTree) parents(level, parents []HashRange, start, end int) {
+	for i := start; i < end; i++ {
+		left, right := level[2*i], level[2*i+1]
+		// the left child lower is new lower, the right child upper is new upper
+		parents[i].Range = Range{Lower: left.Range.Lower, Upper: right.Range.Upper}
+		parents[i].Hash = parentHash(t.Height, left.Hash, right.Hash, parents[i].Range, uint64(2*i), uint64(2*i+1))
+	}
+}
+
+// "Root" - The root of the tree
+func (t *MerkleSumTree) Root() HashRange {
+	return t.Levels[len(t.Levels)-1][0]
+}
+
+// "NumOfLeafs" - The number of leafs of the tree, padding included
+func (t *MerkleSumTree) NumOfLeafs() int {
+	return len(t.Levels[0])
+}
+
+// "Proof" - The merkle proof of the leaf at the index, without rebuilding the tree
+func (t *MerkleSumTree) Proof(index int) (MerkleProof, error) {
+	if index < 0 || index >= t.NumOfLeafs() {
+		return MerkleProof{}, fmt.Errorf("leaf index %d out of bounds, the tree has %d leafs", index, t.NumOfLeafs())
+	}
+	p := MerkleProof{
+		TargetIndex: int64(index),
+		Target:      t.Levels[0][index],
+		HashRanges:  make([]HashRange, 0, len(t.Levels)-1),
+	}
+	// the sibling at every level below the root
+	for _, level := range t.Levels[:len(t.Levels)-1] {
+		p.HashRanges = append(p.HashRanges, level[index^1])
+		index /= 2
+	}
+	return p, nil
+}
+
+// "Bytes" - Encodes the tree: height || number of leafs || every node of every level (hash || lower || upper)
+func (t *MerkleSumTree) Bytes() []byte {
+	bz := make([]byte, 16, 16+(2*t.NumOfLeafs()-1)*merkleNodeLength)
+	binary.BigEndian.PutUint64(bz, uint64(t.Height))
+	binary.BigEndian.PutUint64(bz[8:], uint64(t.NumOfLeafs()))
+	node := make([]byte, merkleNodeLength)
+	for _, level := range t.Levels {
+		for _, hr := range level {
+			copy(node, hr.Hash)
+			binary.BigEndian.PutUint64(node[MerkleHashLength:], hr.Range.Lower)
+			binary.BigEndian.PutUint64(node[MerkleHashLength+8:], hr.Range.Upper)
+			bz = append(bz, node...)
+		}
+	}
+	return bz
+}
+
+// "MerkleSumTreeFromBytes" - Decodes a tree encoded with Bytes
+func MerkleSumTreeFromBytes(bz []byte) (*MerkleSumTree, error) {
+	if len(bz) < 16 {
+		return nil, fmt.Errorf("invalid merkle sum tree encoding: too short")
+	}
+	numOfLeafs := binary.BigEndian.Uint64(bz[8:])
+	if numOfLeafs < 2 || numOfLeafs&(numOfLeafs-1) != 0 || uint64(len(bz)-16) != (2*numOfLeafs-1)*merkleNodeLength {
+		return nil, fmt.Errorf("invalid merkle sum tree encoding: %d bytes for %d leafs", len(bz), numOfLeafs)
+	}
+	t := &MerkleSumTree{Height: int64(binary.BigEndian.Uint64(bz))}
+	bz = bz[16:]
+	for n := numOfLeafs; n >= 1; n /= 2 {
+		level := make([]HashRange, n)
+		for i := range level {
+			level[i] = HashRange{
+				Hash: append([]byte{}, bz[:MerkleHashLength]...),
+				Range: Range{
+					Lower: binary.BigEndian.Uint64(bz[MerkleHashLength:]),
+					Upper: binary.BigEndian.Uint64(bz[MerkleHashLength+8:]),
+				},
+			}
+			bz = bz[merkleNodeLength:]
+		}
+		t.Levels = append(t.Levels, level)
+	}
+	return t, nil
+}
@@ -0,0 +1,171 @@
+package types
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	sdk "github.com/pokt-network/pocket-core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// randomProofs returns n relay proofs, deterministic for the seed
+func randomProofs(n int, seed int64) []Proof {
+	r := rand.New(rand.NewSource(seed))
+	proofs := make([]Proof, n)
+	for i := range proofs {
+		proofs[i] = RelayProof{
+			RequestHash:        fmt.Sprintf("%x", r.Int63()),
+			Entropy:            r.Int63(),
+			SessionBlockHeight: 1,
+			ServicerPubKey:     fmt.Sprintf("%x", r.Int63()),
+			Blockchain:         "0001",
+			Token:              AAT{},
+			Signature:          fmt.Sprintf("%x", r.Int63()),
+		}
+	}
+	return proofs
+}
+
+func copyProofs(proofs []Proof) []Proof {
+	return append([]Proof{}, proofs...)
+}
+
+func TestMerkleSumTree_IdenticalToGenerate(t *testing.T) {
+	// build the big trees in parallel
+	defer func(threshold int) { parallelLevelThreshold = threshold }(parallelLevelThreshold)
+	parallelLevelThreshold = 4
+	for _, height := range []int64{0, -1} { // before and after the codec upgrade
+		for _, n := range []int{2, 3, 5, 8, 31, 100, 257} {
+			proofs := randomProofs(n, int64(n))
+			tree, sorted, err := NewMerkleSumTreeFromProofs(height, copyProofs(proofs))
+			assert.Nil(t, err)
+			root, legacySorted := GenerateRoot(height, copyProofs(proofs))
+			assert.Equal(t, root, tree.Root(), "root of %d proofs at %d", n, height)
+			assert.Equal(t, legacySorted, sorted)
+			for i := 0; i < n; i++ {
+				mp, err := tree.Proof(i)
+				assert.Nil(t, err)
+				legacy, leaf := GenerateProofs(height, copyProofs(proofs), i)
+				assert.Equal(t, legacy, mp, "proof %d of %d proofs at %d", i, n, height)
+				isValid, _ := mp.Validate(height, tree.Root(), leaf, len(mp.HashRanges))
+				assert.True(t, isValid)
+			}
+		}
+	}
+	_, _, err := NewMerkleSumTreeFromProofs(0, randomProofs(1, 1))
+	assert.NotNil(t, err)
+	_, err = NewMerkleSumTree(0, make([]HashRange, 6))
+	assert.NotNil(t, err)
+}
+
+func TestMerkleSumTree_Bytes(t *testing.T) {
+	tree, _, err := NewMerkleSumTreeFromProofs(-1, randomProofs(100, 1))
+	assert.Nil(t, err)
+	decoded, err := MerkleSumTreeFromBytes(tree.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, tree, decoded)
+	_, err = MerkleSumTreeFromBytes(tree.Bytes()[:100])
+	assert.NotNil(t, err)
+	_, err = tree.Proof(tree.NumOfLeafs())
+	assert.NotNil(t, err)
+}
+
+func TestEvidence_PersistedMerkleSumTree(t *testing.T) {
+	header := SessionHeader{
+		ApplicationPubKey:  getRandomPubKey().RawString(),
+		Chain:              "0001",
+		SessionBlockHeight: 1,
+	}
+	for i := int64(0); i < 20; i++ {
+		SetProof(header, RelayEvidence, newTestEvidenceProof(header, i), sdk.NewInt(100))
+	}
+	evidence, err := GetEvidence(header, RelayEvidence, sdk.ZeroInt())
+	assert.Nil(t, err)
+	// the tree is persisted when the root of the claim seals the evidence
+	root := evidence.GenerateMerkleRoot(1)
+	evidence, err = GetEvidence(header, RelayEvidence, sdk.ZeroInt())
+	assert.Nil(t, err)
+	persisted, indices, ok := getEvidenceTree(evidence, 1)
+	assert.True(t, ok)
+	assert.Len(t, indices, 20)
+	assert.Equal(t, root, persisted.Root())
+	_, _, ok = getEvidenceTree(evidence, -1)
+	assert.False(t, ok)
+	// the proofs are answered from it
+	for i := 0; i < 20; i++ {
+		mp, leaf := evidence.GenerateMerkleProof(1, i)
+		isValid, _ := mp.Validate(1, root, leaf, len(mp.HashRanges))
+		assert.True(t, isValid)
+	}
+	// and deleted with the evidence
+	assert.Nil(t, DeleteEvidence(header, RelayEvidence))
+	_, _, ok = getEvidenceTree(evidence, 1)
+	assert.False(t, ok)
+	ClearEvidence()
+}
+
+func benchmarkProofCounts() []int {
+	return []int{1000, 10000, 100000}
+}
+
+func BenchmarkGenerateRoot(b *testing.B) {
+	for _, n := range benchmarkProofCounts() {
+		proofs := randomProofs(n, 1)
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				GenerateRoot(-1, copyProofs(proofs))
+			}
+		})
+	}
+}
+
+func BenchmarkMerkleSumTree(b *testing.B) {
+	for _, n := range benchmarkProofCounts() {
+		data, _ := sortAndStructure(randomProofs(n, 1))
+		b.Run(fmt.Sprintf("serial/%d", n), func(b *testing.B) {
+			defer func(threshold int) { parallelLevelThreshold = threshold }(parallelLevelThreshold)
+			parallelLevelThreshold = len(data)
+			for i := 0; i < b.N; i++ {
+				_, _ = NewMerkleSumTree(-1, data)
+			}
+		})
+		b.Run(fmt.Sprintf("parallel/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = NewMerkleSumTree(-1, data)
+			}
+		})
+	}
+}
+
+func BenchmarkGenerateProofs(b *testing.B) {
+	for _, n := range benchmarkProofCounts() {
+		proofs := randomProofs(n, 1)
+		tree, _, _ := NewMerkleSumTreeFromProofs(-1, copyProofs(proofs))
+		b.Run(fmt.Sprintf("rebuild/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				GenerateProofs(-1, copyProofs(proofs), i%n)
+			}
+		})
+		b.Run(fmt.Sprintf("cached/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = tree.Proof(i % n)
+			}
+		})
+	}
+}
+
+func BenchmarkMerkleSumTree_Bytes(b *testing.B) {
+	tree, _, _ := NewMerkleSumTreeFromProofs(-1, randomProofs(10000, 1))
+	bz := tree.Bytes()
+	b.Run("encode", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			tree.Bytes()
+		}
+	})
+	b.Run("decode", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = MerkleSumTreeFromBytes(bz)
+		}
+	})
+}
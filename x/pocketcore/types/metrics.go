@@ -30,6 +30,12 @@ const (
 	SessionsCountHelp       = "the number of unique sessions generated for: "
 	UPOKTCountName          = "tokens_earned_for_"
 	UPOKTCountHelp          = "the number of tokens earned in uPOKT for : "
+	PolicyRejectCountName   = "policy_reject_count_for_"
+	PolicyRejectCountHelp   = "the number of relays rejected by the request policy, by reason, of: "
+	PrefetchTimeName        = "session_prefetch_time"
+	PrefetchTimeHelp        = "the time in ms of the last precomputation of the sessions of the node at a session block"
+	PrefetchCountName       = "session_prefetch_count"
+	PrefetchCountHelp       = "the number of sessions of the node precomputed at the last session block"
 )
 
 type ServiceMetrics struct {
@@ -37,6 +43,8 @@ type ServiceMetrics struct {
 	tmLogger        log.Logger
 	ServiceMetric   `json:"accumulated_service_metrics"` // total metrics
 	NonNativeChains map[string]ServiceMetric             `json:"individual_service_metrics"` // metrics per chain
+	PrefetchTime    metrics.Gauge                        `json:"session_prefetch_time"`      // the time of the last session warm-up
+	PrefetchCount   metrics.Gauge                        `json:"session_prefetch_count"`     // the sessions of the last session warm-up
 	prometheusSrv   *http.Server
 }
 
@@ -194,6 +202,31 @@ func (sm *ServiceMetrics) AddUPOKTEarnedFor(networkID string, upoktEarned float6
 	sm.NonNativeChains[networkID] = nnc
 }
 
+func (sm *ServiceMetrics) AddPolicyRejectFor(networkID string, reason string) {
+	sm.l.Lock()
+	defer sm.l.Unlock()
+	// attempt to locate nn chain
+	nnc, ok := sm.NonNativeChains[networkID]
+	if !ok {
+		sm.tmLogger.Error("unable to find corresponding networkID in service metrics: ", networkID)
+		sm.NonNativeChains[networkID] = NewServiceMetricsFor(networkID)
+		return
+	}
+	// add to accumulated count
+	sm.PolicyRejectCount.With("reason", reason).Add(1)
+	// add to individual count
+	nnc.PolicyRejectCount.With("reason", reason).Add(1)
+	// update nnc
+	sm.NonNativeChains[networkID] = nnc
+}
+
+func (sm *ServiceMetrics) SetSessionPrefetch(sessions int, prefetchTime float64) {
+	sm.l.Lock()
+	defer sm.l.Unlock()
+	sm.PrefetchCount.Set(float64(sessions))
+	sm.PrefetchTime.Set(prefetchTime)
+}
+
 func KeyForServiceMetrics() []byte {
 	return []byte(ServiceMetricsKey)
 }
@@ -202,6 +235,18 @@ func NewServiceMetrics(hostedBlockchains *HostedBlockchains, logger log.Logger)
 	serviceMetrics := ServiceMetrics{
 		ServiceMetric:   NewServiceMetricsFor("all"),
 		NonNativeChains: make(map[string]ServiceMetric),
+		PrefetchTime: prometheus.NewGaugeFrom(stdPrometheus.GaugeOpts{
+			Namespace: ModuleName,
+			Subsystem: ServiceMetricsNamespace,
+			Name:      PrefetchTimeName,
+			Help:      PrefetchTimeHelp,
+		}, nil),
+		PrefetchCount: prometheus.NewGaugeFrom(stdPrometheus.GaugeOpts{
+			Namespace: ModuleName,
+			Subsystem: ServiceMetricsNamespace,
+			Name:      PrefetchCountName,
+			Help:      PrefetchCountHelp,
+		}, nil),
 	}
 	if hostedBlockchains != nil {
 		for _, hb := range hostedBlockchains.M {
@@ -215,12 +260,13 @@ func NewServiceMetrics(hostedBlockchains *HostedBlockchains, logger log.Logger)
 }
 
 type ServiceMetric struct {
-	RelayCount       metrics.Counter   `json:"relay_count"`
-	ChallengeCount   metrics.Counter   `json:"challenge_count"`
-	ErrCount         metrics.Counter   `json:"err_count"`
-	AverageRelayTime metrics.Histogram `json:"avg_relay_time"`
-	TotalSessions    metrics.Counter   `json:"total_sessions"`
-	UPOKTEarned      metrics.Counter   `json:"upokt_earned"`
+	RelayCount        metrics.Counter   `json:"relay_count"`
+	ChallengeCount    metrics.Counter   `json:"challenge_count"`
+	ErrCount          metrics.Counter   `json:"err_count"`
+	AverageRelayTime  metrics.Histogram `json:"avg_relay_time"`
+	TotalSessions     metrics.Counter   `json:"total_sessions"`
+	UPOKTEarned       metrics.Counter   `json:"upokt_earned"`
+	PolicyRejectCount metrics.Counter   `json:"policy_reject_count"`
 }
 
 func NewServiceMetricsFor(networkID string) ServiceMetric {
@@ -269,12 +315,20 @@ func NewServiceMetricsFor(networkID string) ServiceMetric {
 		Name:      UPOKTCountName + networkID,
 		Help:      UPOKTCountHelp + networkID,
 	}, nil)
+	// policy rejections metric
+	policyRejectCounter := prometheus.NewCounterFrom(stdPrometheus.CounterOpts{
+		Namespace: ModuleName,
+		Subsystem: ServiceMetricsNamespace,
+		Name:      PolicyRejectCountName + networkID,
+		Help:      PolicyRejectCountHelp + networkID,
+	}, []string{"reason"})
 	return ServiceMetric{
-		RelayCount:       relayCounter,
-		ChallengeCount:   challengeCounter,
-		ErrCount:         errCounter,
-		AverageRelayTime: avgRelayTime,
-		TotalSessions:    totalSessions,
-		UPOKTEarned:      uPOKTEarned,
+		RelayCount:        relayCounter,
+		ChallengeCount:    challengeCounter,
+		ErrCount:          errCounter,
+		AverageRelayTime:  avgRelayTime,
+		TotalSessions:     totalSessions,
+		UPOKTEarned:       uPOKTEarned,
+		PolicyRejectCount: policyRejectCounter,
 	}
 }
@@ -609,6 +609,123 @@ func (m *HashRange) GetRange() Range {
 	return Range{}
 }
 
+// ProtoRelay is the protobuf encoding of a relay on the client relay endpoint
+type ProtoRelay struct {
+	Payload ProtoPayload   `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload"`
+	Meta    ProtoRelayMeta `protobuf:"bytes,2,opt,name=meta,proto3" json:"meta"`
+	Proof   RelayProof     `protobuf:"bytes,3,opt,name=proof,proto3" json:"proof"`
+}
+
+func (m *ProtoRelay) Reset()         { *m = ProtoRelay{} }
+func (m *ProtoRelay) String() string { return proto.CompactTextString(m) }
+func (*ProtoRelay) ProtoMessage()    {}
+func (*ProtoRelay) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd7cbfa14fd73888, []int{13}
+}
+func (m *ProtoRelay) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ProtoRelay) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ProtoRelay.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ProtoRelay) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProtoRelay.Merge(m, src)
+}
+func (m *ProtoRelay) XXX_Size() int {
+	return m.Size()
+}
+func (m *ProtoRelay) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProtoRelay.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProtoRelay proto.InternalMessageInfo
+
+type ProtoPayload struct {
+	Data    string            `protobuf:"bytes,1,opt,name=data,proto3" json:"data"`
+	Method  string            `protobuf:"bytes,2,opt,name=method,proto3" json:"method"`
+	Path    string            `protobuf:"bytes,3,opt,name=path,proto3" json:"path"`
+	Headers map[string]string `protobuf:"bytes,4,rep,name=headers,proto3" json:"headers" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *ProtoPayload) Reset()         { *m = ProtoPayload{} }
+func (m *ProtoPayload) String() string { return proto.CompactTextString(m) }
+func (*ProtoPayload) ProtoMessage()    {}
+func (*ProtoPayload) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd7cbfa14fd73888, []int{14}
+}
+func (m *ProtoPayload) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ProtoPayload) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ProtoPayload.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ProtoPayload) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProtoPayload.Merge(m, src)
+}
+func (m *ProtoPayload) XXX_Size() int {
+	return m.Size()
+}
+func (m *ProtoPayload) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProtoPayload.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProtoPayload proto.InternalMessageInfo
+
+type ProtoRelayMeta struct {
+	BlockHeight int64 `protobuf:"varint,1,opt,name=blockHeight,proto3" json:"block_height"`
+}
+
+func (m *ProtoRelayMeta) Reset()         { *m = ProtoRelayMeta{} }
+func (m *ProtoRelayMeta) String() string { return proto.CompactTextString(m) }
+func (*ProtoRelayMeta) ProtoMessage()    {}
+func (*ProtoRelayMeta) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fd7cbfa14fd73888, []int{15}
+}
+func (m *ProtoRelayMeta) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ProtoRelayMeta) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ProtoRelayMeta.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ProtoRelayMeta) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProtoRelayMeta.Merge(m, src)
+}
+func (m *ProtoRelayMeta) XXX_Size() int {
+	return m.Size()
+}
+func (m *ProtoRelayMeta) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProtoRelayMeta.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProtoRelayMeta proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*SessionHeader)(nil), "x.pocketcore.SessionHeader")
 	proto.RegisterType((*Session)(nil), "x.pocketcore.Session")
@@ -623,95 +740,110 @@ func init() {
 	proto.RegisterType((*MerkleProof)(nil), "x.pocketcore.MerkleProof")
 	proto.RegisterType((*Range)(nil), "x.pocketcore.Range")
 	proto.RegisterType((*HashRange)(nil), "x.pocketcore.HashRange")
+	proto.RegisterType((*ProtoRelay)(nil), "x.pocketcore.ProtoRelay")
+	proto.RegisterType((*ProtoPayload)(nil), "x.pocketcore.ProtoPayload")
+	proto.RegisterMapType((map[string]string)(nil), "x.pocketcore.ProtoPayload.HeadersEntry")
+	proto.RegisterType((*ProtoRelayMeta)(nil), "x.pocketcore.ProtoRelayMeta")
 }
 
 func init() { proto.RegisterFile("x/pocketcore/pocket.proto", fileDescriptor_fd7cbfa14fd73888) }
 
 var fileDescriptor_fd7cbfa14fd73888 = []byte{
-	// 1322 bytes of a gzipped FileDescriptorProto
+	// 1502 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0xcd, 0x6e, 0xdb, 0xc6,
-	0x13, 0x17, 0x4d, 0xc9, 0x8e, 0x47, 0x94, 0x3f, 0x36, 0x0e, 0xfe, 0x72, 0xfe, 0x80, 0xe9, 0x1a,
-	0x28, 0x62, 0x20, 0x88, 0x8c, 0x3a, 0x6d, 0x50, 0x04, 0x09, 0x50, 0x31, 0x35, 0xea, 0xb4, 0x4d,
-	0xe3, 0xac, 0x8d, 0x1e, 0x7a, 0x21, 0x28, 0x6a, 0x2d, 0xb1, 0xa2, 0xb8, 0xec, 0x72, 0xe5, 0x58,
-	0x6f, 0x90, 0x63, 0x1f, 0xa1, 0xe8, 0xa1, 0x87, 0x3c, 0x43, 0x1f, 0x20, 0xc7, 0x5c, 0x0a, 0xe4,
-	0x50, 0x30, 0x85, 0x7d, 0x23, 0xfa, 0x00, 0x45, 0x4e, 0xc5, 0x7e, 0x50, 0x22, 0x6d, 0xc7, 0x29,
-	0xfa, 0x71, 0x31, 0x57, 0x33, 0xbf, 0x99, 0x9d, 0xef, 0x59, 0xc3, 0xea, 0xf1, 0x56, 0x4c, 0xfd,
-	0x01, 0xe1, 0x3e, 0x65, 0x44, 0x1f, 0x5b, 0x31, 0xa3, 0x9c, 0x22, 0xeb, 0xb8, 0x35, 0x65, 0x5d,
-	0x5f, 0xe9, 0xd1, 0x1e, 0x95, 0x8c, 0x2d, 0x71, 0x52, 0x98, 0x8d, 0x9f, 0x0d, 0x68, 0xec, 0x93,
-	0x24, 0x09, 0x68, 0xb4, 0x4b, 0xbc, 0x2e, 0x61, 0xe8, 0x13, 0x58, 0xf6, 0xe2, 0x38, 0x0c, 0x7c,
-	0x8f, 0x07, 0x34, 0xda, 0x1b, 0x75, 0xbe, 0x20, 0xe3, 0xa6, 0xb1, 0x6e, 0x6c, 0xce, 0x3b, 0x28,
-	0x4b, 0xed, 0x05, 0x2f, 0x8e, 0xdd, 0x78, 0xd4, 0x09, 0x03, 0xdf, 0x1d, 0x90, 0x31, 0x3e, 0x0f,
-	0x46, 0x36, 0xd4, 0xfc, 0xbe, 0x17, 0x44, 0xcd, 0x19, 0x29, 0x35, 0x9f, 0xa5, 0xb6, 0x22, 0x60,
-	0xf5, 0x41, 0x0e, 0xa0, 0x44, 0xdd, 0xe9, 0x84, 0xd4, 0x1f, 0xec, 0x92, 0xa0, 0xd7, 0xe7, 0x4d,
-	0x73, 0xdd, 0xd8, 0x34, 0xd5, 0x1d, 0x9a, 0xeb, 0xf6, 0x25, 0x07, 0x5f, 0x80, 0xbe, 0x5b, 0x7d,
-	0xf6, 0x83, 0x5d, 0xd9, 0x78, 0x65, 0xc0, 0x9c, 0x36, 0x1f, 0x3d, 0x81, 0x46, 0x52, 0xf4, 0x44,
-	0x1a, 0x5d, 0xdf, 0xfe, 0x7f, 0xab, 0x18, 0x86, 0x56, 0xc9, 0x59, 0x67, 0xe1, 0x45, 0x6a, 0x57,
-	0xb2, 0xd4, 0x9e, 0xed, 0xcb, 0xdf, 0xb8, 0xac, 0x01, 0x7d, 0x04, 0xa0, 0x09, 0x22, 0x08, 0xc2,
-	0x1d, 0xcb, 0xb9, 0x96, 0xa5, 0xb6, 0x39, 0x20, 0xe3, 0x37, 0xa9, 0x0d, 0xfb, 0x13, 0x26, 0x2e,
-	0x00, 0xd1, 0x7d, 0xb0, 0xf4, 0xaf, 0xaf, 0x68, 0x97, 0x24, 0x4d, 0x73, 0xdd, 0xdc, 0xb4, 0x9c,
-	0x55, 0x11, 0x87, 0x48, 0x10, 0x9e, 0xbf, 0xb6, 0xad, 0xfd, 0x02, 0x00, 0x97, 0xe0, 0xda, 0xb5,
-	0x5f, 0x4d, 0xb8, 0xf2, 0x28, 0xe9, 0x3d, 0x08, 0xbd, 0x60, 0xf8, 0x5f, 0xf8, 0xf6, 0x25, 0xc0,
-	0x90, 0xb0, 0x41, 0x48, 0x30, 0xa5, 0x5c, 0xfa, 0x56, 0xdf, 0xfe, 0x5f, 0x59, 0xdf, 0xae, 0x97,
-	0xf4, 0xb1, 0x17, 0xf5, 0x88, 0x73, 0x55, 0xeb, 0xaa, 0x2b, 0x11, 0x97, 0x51, 0xca, 0x71, 0x41,
-	0x1e, 0x6d, 0x43, 0x9d, 0x53, 0xee, 0x85, 0x7b, 0x8c, 0xd2, 0xc3, 0x44, 0xe7, 0x72, 0x29, 0x4b,
-	0x6d, 0x4b, 0x92, 0xdd, 0x58, 0xd2, 0x71, 0x11, 0x84, 0x7a, 0x50, 0x3f, 0x64, 0x74, 0xd8, 0xee,
-	0x76, 0x19, 0x49, 0x92, 0x66, 0x55, 0x86, 0x77, 0x47, 0xc8, 0x08, 0xb2, 0xeb, 0x29, 0xfa, 0x9b,
-	0xd4, 0xfe, 0xa0, 0x17, 0xf0, 0xfe, 0xa8, 0xd3, 0xf2, 0xe9, 0x70, 0x2b, 0xa6, 0x03, 0x7e, 0x2b,
-	0x22, 0xfc, 0x29, 0x65, 0x03, 0x5d, 0xee, 0xb7, 0x64, 0xe9, 0xf3, 0x71, 0x4c, 0x92, 0x96, 0x56,
-	0x86, 0x8b, 0x9a, 0xd1, 0x0e, 0x58, 0xe4, 0x28, 0xe8, 0x92, 0xc8, 0x27, 0x07, 0xe3, 0x98, 0x34,
-	0x6b, 0xeb, 0xc6, 0x66, 0xcd, 0x79, 0x2f, 0x4b, 0xed, 0x46, 0x4e, 0x77, 0x85, 0xf8, 0x9b, 0xd4,
-	0xb6, 0x76, 0x0a, 0x40, 0x5c, 0x12, 0x43, 0x6d, 0x58, 0x22, 0xc7, 0x71, 0xc0, 0x64, 0xad, 0xeb,
-	0xa2, 0x9d, 0x95, 0x8e, 0x8a, 0x9a, 0x58, 0x9e, 0xf2, 0xf2, 0xba, 0x3d, 0x07, 0xbf, 0x7b, 0x45,
-	0xa4, 0xf6, 0xd9, 0x8f, 0xb6, 0xb1, 0xf1, 0xbb, 0x01, 0x8d, 0x47, 0x49, 0x6f, 0x4f, 0x74, 0xa1,
-	0x8c, 0x07, 0xc2, 0xa0, 0xa3, 0x2b, 0x7f, 0xea, 0x0c, 0xaf, 0x96, 0x33, 0xf2, 0x68, 0x0a, 0x70,
-	0xae, 0xe9, 0x9c, 0x34, 0x74, 0x4e, 0xf2, 0x10, 0x17, 0x94, 0xa0, 0x3b, 0x50, 0x0d, 0x89, 0x77,
-	0xa8, 0xd3, 0xbb, 0x52, 0x56, 0x26, 0x21, 0x0f, 0x1d, 0x4b, 0xeb, 0x91, 0x48, 0x2c, 0xff, 0x9e,
-	0x8b, 0x98, 0xf9, 0xb7, 0x22, 0x56, 0x70, 0xf7, 0x27, 0x03, 0x66, 0xd5, 0x7d, 0xe8, 0x2e, 0x00,
-	0x23, 0xa1, 0x37, 0x2e, 0xba, 0xd9, 0x2c, 0x5b, 0x86, 0x27, 0xfc, 0xdd, 0x0a, 0x2e, 0xa0, 0xd1,
-	0x13, 0x58, 0xf0, 0xfb, 0x5e, 0x18, 0x92, 0xa8, 0xa7, 0xc3, 0xa4, 0x3c, 0xbb, 0x51, 0x96, 0x7f,
-	0x50, 0xc2, 0x3c, 0x8c, 0x8e, 0xbc, 0x30, 0xe8, 0x7e, 0xea, 0x71, 0x6f, 0xb7, 0x82, 0xcf, 0x28,
-	0x50, 0xdd, 0xe6, 0xcc, 0x41, 0x4d, 0xc6, 0x6f, 0xe3, 0x74, 0x06, 0x1a, 0x32, 0x29, 0xb9, 0x5b,
-	0x68, 0x0b, 0xa0, 0x13, 0x52, 0x3a, 0x74, 0xc6, 0x9c, 0x24, 0xd2, 0x5e, 0xcb, 0x59, 0x14, 0xbd,
-	0x20, 0xa9, 0x6e, 0x47, 0x90, 0x71, 0x01, 0x82, 0xbe, 0x3e, 0xdb, 0xac, 0x33, 0xef, 0x6e, 0xd6,
-	0xab, 0x59, 0x6a, 0x2f, 0x4e, 0x42, 0x7b, 0x71, 0xc7, 0xde, 0x86, 0x7a, 0x34, 0x1a, 0x3e, 0x3e,
-	0x2c, 0xf5, 0xd8, 0xb2, 0xc8, 0x49, 0x34, 0x1a, 0xba, 0xf4, 0x70, 0x52, 0x01, 0x05, 0x14, 0xfa,
-	0x0c, 0x66, 0x15, 0xb9, 0x59, 0x5d, 0x37, 0xdf, 0x5a, 0x03, 0xab, 0xf9, 0xac, 0x50, 0xd8, 0xe7,
-	0xaf, 0xed, 0x39, 0xc5, 0x49, 0xb0, 0x26, 0xfd, 0x4b, 0x4d, 0xa4, 0x87, 0xdb, 0x33, 0x13, 0x60,
-	0x9a, 0x64, 0x31, 0x3d, 0x18, 0xf9, 0x6e, 0x44, 0x12, 0x2e, 0x46, 0x8e, 0xde, 0x36, 0x72, 0x7a,
-	0x68, 0xb2, 0xdb, 0x17, 0xa3, 0xa8, 0x08, 0x42, 0xef, 0xc3, 0x1c, 0x89, 0x38, 0xa3, 0xb1, 0x1a,
-	0xcc, 0xa6, 0x53, 0xcf, 0x52, 0x3b, 0x27, 0xe1, 0xfc, 0x80, 0x76, 0x2f, 0xd9, 0x35, 0xcd, 0x2c,
-	0xb5, 0x57, 0xf2, 0x5d, 0xd3, 0x11, 0xec, 0x4b, 0x36, 0x0e, 0xba, 0x07, 0x0b, 0x09, 0x61, 0x47,
-	0x81, 0x4f, 0x98, 0xde, 0x8a, 0x55, 0x69, 0xe7, 0x4a, 0x96, 0xda, 0x4b, 0x39, 0x47, 0xac, 0x46,
-	0xb9, 0x17, 0xcf, 0x60, 0x51, 0x4b, 0x56, 0x91, 0x3f, 0x50, 0x9b, 0xb1, 0x26, 0x25, 0x17, 0xb2,
-	0xd4, 0x2e, 0x50, 0x71, 0xe1, 0x8c, 0x3e, 0x84, 0x1a, 0xa7, 0x03, 0x12, 0xc9, 0x09, 0x53, 0xdf,
-	0x5e, 0x2e, 0xa7, 0xad, 0xdd, 0x3e, 0x70, 0xea, 0x3a, 0x67, 0xa6, 0xe7, 0x71, 0xac, 0xc0, 0xe8,
-	0x26, 0xcc, 0x27, 0x41, 0x2f, 0xf2, 0xf8, 0x88, 0x91, 0xe6, 0x9c, 0xbc, 0xa4, 0x91, 0xa5, 0xf6,
-	0x94, 0x88, 0xa7, 0x47, 0x9d, 0x8a, 0x93, 0x19, 0x58, 0x7d, 0x6b, 0xbf, 0x20, 0x02, 0xcb, 0x43,
-	0xef, 0x5b, 0xca, 0x02, 0x3e, 0xc6, 0x24, 0x89, 0x69, 0x94, 0xc8, 0x1e, 0x30, 0xcf, 0xd7, 0xb3,
-	0x4c, 0x67, 0x8e, 0x71, 0xae, 0x6b, 0xe3, 0x50, 0x2e, 0xed, 0xb2, 0x5c, 0x1c, 0x9f, 0xd7, 0x88,
-	0x3a, 0xb0, 0x34, 0x0c, 0xa2, 0x12, 0xf1, 0xe2, 0xae, 0x29, 0xdf, 0x92, 0x97, 0xed, 0x72, 0x2e,
-	0x3c, 0xb9, 0x05, 0x9f, 0xd3, 0x87, 0x38, 0x2c, 0x32, 0x12, 0x53, 0xc6, 0x09, 0xcb, 0x57, 0x8e,
-	0x29, 0x9b, 0xf9, 0x73, 0xa1, 0x21, 0x67, 0x25, 0xff, 0x6c, 0xef, 0x9c, 0xbd, 0x42, 0x07, 0xf9,
-	0xb9, 0x01, 0x8d, 0x92, 0xe9, 0xe5, 0x4c, 0x19, 0x97, 0x67, 0x0a, 0xdd, 0x80, 0x2b, 0xac, 0x18,
-	0x96, 0x79, 0x55, 0xec, 0xb1, 0x37, 0x0e, 0xa9, 0xd7, 0xc5, 0x13, 0x26, 0xba, 0xaf, 0xc7, 0x98,
-	0xf4, 0xec, 0x92, 0xb1, 0xea, 0x34, 0x74, 0xe4, 0x14, 0x1c, 0xab, 0x8f, 0x36, 0xf6, 0x0f, 0x03,
-	0xcc, 0x76, 0xfb, 0x40, 0x74, 0xd8, 0x11, 0x61, 0xa2, 0x0d, 0xb4, 0x81, 0xf2, 0x52, 0x4d, 0xc2,
-	0xf9, 0x01, 0x3d, 0x80, 0x95, 0xf2, 0x1b, 0x30, 0x0c, 0xfc, 0xfc, 0xb9, 0x34, 0xaf, 0x26, 0xa5,
-	0x7e, 0x33, 0xca, 0xc6, 0xb8, 0x10, 0x8c, 0xee, 0xc1, 0xa2, 0x1f, 0x06, 0x24, 0xe2, 0x53, 0x79,
-	0x73, 0xfa, 0xe6, 0x54, 0xac, 0x89, 0x8a, 0xb3, 0x50, 0xd4, 0x2e, 0x99, 0xb0, 0x3f, 0x89, 0x6b,
-	0xf5, 0xa2, 0xb8, 0x5e, 0x08, 0xd5, 0xae, 0xff, 0x62, 0x40, 0xbd, 0xb0, 0x63, 0xd1, 0x4d, 0xa8,
-	0x1f, 0x78, 0xac, 0x47, 0xf8, 0xc3, 0xa8, 0x4b, 0x8e, 0x65, 0x18, 0x4c, 0xf5, 0xa0, 0x0d, 0x04,
-	0x01, 0x17, 0xb9, 0xe2, 0x45, 0xd5, 0xcf, 0x5f, 0x4c, 0x49, 0x73, 0x46, 0x36, 0xc9, 0xbb, 0x5f,
-	0x54, 0x42, 0xc4, 0x65, 0x52, 0x06, 0x17, 0xe4, 0xd1, 0x0e, 0xcc, 0x72, 0xa9, 0x5c, 0xe7, 0xf2,
-	0xad, 0x9a, 0x56, 0xb4, 0x26, 0x4b, 0xc1, 0x95, 0x2e, 0xac, 0x85, 0xb5, 0x5f, 0x8f, 0xa1, 0x26,
-	0xc1, 0xe2, 0x6d, 0x1e, 0xd2, 0xa7, 0xfa, 0x01, 0x59, 0x55, 0xae, 0x48, 0x02, 0x56, 0x1f, 0x01,
-	0x18, 0xc5, 0xb1, 0x5e, 0x5a, 0x1a, 0x20, 0x09, 0x58, 0x7d, 0xb4, 0xc2, 0x00, 0xe6, 0x27, 0x16,
-	0xa0, 0x0d, 0xa8, 0xf6, 0xf3, 0xb9, 0x6d, 0xa9, 0xa9, 0xa6, 0x1e, 0x21, 0x12, 0x22, 0x79, 0xe8,
-	0x63, 0xa8, 0x49, 0xc3, 0x74, 0x5b, 0x5f, 0x3d, 0x53, 0x99, 0xd2, 0x93, 0x49, 0x51, 0x2a, 0x17,
-	0xd4, 0xc7, 0xd9, 0x7b, 0x71, 0xb2, 0x66, 0xbc, 0x3c, 0x59, 0x33, 0x7e, 0x3b, 0x59, 0x33, 0xbe,
-	0x3f, 0x5d, 0xab, 0xbc, 0x3c, 0x5d, 0xab, 0xbc, 0x3a, 0x5d, 0xab, 0x7c, 0x73, 0xe7, 0xaf, 0xf4,
-	0x67, 0xe9, 0xff, 0x23, 0xd9, 0xac, 0x9d, 0x59, 0xf9, 0xbf, 0xcf, 0xed, 0x3f, 0x03, 0x00, 0x00,
-	0xff, 0xff, 0xa5, 0xfb, 0x71, 0x3c, 0x3c, 0x0d, 0x00, 0x00,
+	0x16, 0x36, 0x4d, 0xc9, 0x3f, 0x47, 0x94, 0x7f, 0x26, 0x0e, 0x2e, 0xed, 0x1b, 0x98, 0xbe, 0x06,
+	0x2e, 0x62, 0x20, 0x88, 0x8c, 0xeb, 0xdc, 0x06, 0x81, 0x91, 0x14, 0x35, 0x53, 0xa3, 0x4e, 0x5a,
+	0x27, 0xce, 0xd8, 0xe8, 0xa2, 0x1b, 0x61, 0x24, 0x8d, 0x25, 0x56, 0x14, 0x87, 0x25, 0x47, 0x8e,
+	0xb5, 0xeb, 0x32, 0xcb, 0x3e, 0x42, 0xd1, 0x45, 0x17, 0x79, 0x86, 0x3e, 0x40, 0x96, 0xd9, 0x14,
+	0xc8, 0xa2, 0x60, 0x0a, 0x7b, 0x27, 0xf4, 0x01, 0x8a, 0xac, 0x8a, 0xf9, 0xa1, 0x44, 0x5a, 0xb2,
+	0x1b, 0xb4, 0xe9, 0x86, 0x1c, 0x9e, 0xf9, 0xce, 0x99, 0x39, 0xff, 0x87, 0xb0, 0x7c, 0xba, 0x19,
+	0xb2, 0x7a, 0x9b, 0xf2, 0x3a, 0x8b, 0xa8, 0x5e, 0x56, 0xc2, 0x88, 0x71, 0x86, 0xac, 0xd3, 0xca,
+	0x70, 0x6b, 0x65, 0xa9, 0xc9, 0x9a, 0x4c, 0x6e, 0x6c, 0x8a, 0x95, 0xc2, 0xac, 0xff, 0x64, 0x40,
+	0xf9, 0x90, 0xc6, 0xb1, 0xc7, 0x82, 0x3d, 0x4a, 0x1a, 0x34, 0x42, 0x9f, 0xc0, 0x22, 0x09, 0x43,
+	0xdf, 0xab, 0x13, 0xee, 0xb1, 0xe0, 0xa0, 0x5b, 0xfb, 0x9c, 0xf6, 0x6c, 0x63, 0xcd, 0xd8, 0x98,
+	0x75, 0x51, 0x3f, 0x71, 0xe6, 0x48, 0x18, 0x56, 0xc3, 0x6e, 0xcd, 0xf7, 0xea, 0xd5, 0x36, 0xed,
+	0xe1, 0x51, 0x30, 0x72, 0xa0, 0x58, 0x6f, 0x11, 0x2f, 0xb0, 0x27, 0x25, 0xd7, 0x6c, 0x3f, 0x71,
+	0x14, 0x01, 0xab, 0x17, 0x72, 0x01, 0xc5, 0xea, 0x4c, 0xd7, 0x67, 0xf5, 0xf6, 0x1e, 0xf5, 0x9a,
+	0x2d, 0x6e, 0x9b, 0x6b, 0xc6, 0x86, 0xa9, 0xce, 0xd0, 0xbb, 0xd5, 0x96, 0xdc, 0xc1, 0x63, 0xd0,
+	0xdb, 0x85, 0x17, 0xdf, 0x3b, 0x13, 0xeb, 0x6f, 0x0c, 0x98, 0xd6, 0xd7, 0x47, 0xcf, 0xa0, 0x1c,
+	0x67, 0x35, 0x91, 0x97, 0x2e, 0x6d, 0xfd, 0xbb, 0x92, 0x35, 0x43, 0x25, 0xa7, 0xac, 0x3b, 0xf7,
+	0x2a, 0x71, 0x26, 0xfa, 0x89, 0x33, 0xd5, 0x92, 0xdf, 0x38, 0x2f, 0x01, 0x7d, 0x04, 0xa0, 0x09,
+	0xc2, 0x08, 0x42, 0x1d, 0xcb, 0xbd, 0xde, 0x4f, 0x1c, 0xb3, 0x4d, 0x7b, 0xef, 0x12, 0x07, 0x0e,
+	0x07, 0x9b, 0x38, 0x03, 0x44, 0x0f, 0xc0, 0xd2, 0x5f, 0x4f, 0x58, 0x83, 0xc6, 0xb6, 0xb9, 0x66,
+	0x6e, 0x58, 0xee, 0xb2, 0xb0, 0x43, 0x20, 0x08, 0x2f, 0xdf, 0x3a, 0xd6, 0x61, 0x06, 0x80, 0x73,
+	0x70, 0xad, 0xda, 0x2f, 0x26, 0xcc, 0xec, 0xc7, 0xcd, 0x87, 0x3e, 0xf1, 0x3a, 0xff, 0x84, 0x6e,
+	0x5f, 0x00, 0x74, 0x68, 0xd4, 0xf6, 0x29, 0x66, 0x8c, 0x4b, 0xdd, 0x4a, 0x5b, 0xff, 0xca, 0xcb,
+	0xdb, 0x23, 0x71, 0x0b, 0x93, 0xa0, 0x49, 0xdd, 0x6b, 0x5a, 0x56, 0x49, 0xb1, 0x54, 0x23, 0xc6,
+	0x38, 0xce, 0xf0, 0xa3, 0x2d, 0x28, 0x71, 0xc6, 0x89, 0x7f, 0x10, 0x31, 0x76, 0x1c, 0x6b, 0x5f,
+	0x2e, 0xf4, 0x13, 0xc7, 0x92, 0xe4, 0x6a, 0x28, 0xe9, 0x38, 0x0b, 0x42, 0x4d, 0x28, 0x1d, 0x47,
+	0xac, 0xb3, 0xd3, 0x68, 0x44, 0x34, 0x8e, 0xed, 0x82, 0x34, 0xef, 0xae, 0xe0, 0x11, 0xe4, 0x2a,
+	0x51, 0xf4, 0x77, 0x89, 0xf3, 0xbf, 0xa6, 0xc7, 0x5b, 0xdd, 0x5a, 0xa5, 0xce, 0x3a, 0x9b, 0x21,
+	0x6b, 0xf3, 0xdb, 0x01, 0xe5, 0xcf, 0x59, 0xd4, 0xd6, 0xe1, 0x7e, 0x5b, 0x86, 0x3e, 0xef, 0x85,
+	0x34, 0xae, 0x68, 0x61, 0x38, 0x2b, 0x19, 0xed, 0x82, 0x45, 0x4f, 0xbc, 0x06, 0x0d, 0xea, 0xf4,
+	0xa8, 0x17, 0x52, 0xbb, 0xb8, 0x66, 0x6c, 0x14, 0xdd, 0xff, 0xf4, 0x13, 0xa7, 0x9c, 0xd2, 0xab,
+	0x82, 0xfd, 0x5d, 0xe2, 0x58, 0xbb, 0x19, 0x20, 0xce, 0xb1, 0xa1, 0x1d, 0x58, 0xa0, 0xa7, 0xa1,
+	0x17, 0xc9, 0x58, 0xd7, 0x41, 0x3b, 0x25, 0x15, 0x15, 0x31, 0xb1, 0x38, 0xdc, 0x4b, 0xe3, 0x76,
+	0x04, 0xbe, 0x3d, 0x23, 0x5c, 0xfb, 0xe2, 0x07, 0xc7, 0x58, 0xff, 0xcd, 0x80, 0xf2, 0x7e, 0xdc,
+	0x3c, 0x10, 0x59, 0x28, 0xed, 0x81, 0x30, 0x68, 0xeb, 0xca, 0x4f, 0xed, 0xe1, 0xe5, 0xbc, 0x47,
+	0xf6, 0x87, 0x00, 0xf7, 0xba, 0xf6, 0x49, 0x59, 0xfb, 0x24, 0x35, 0x71, 0x46, 0x08, 0xba, 0x0b,
+	0x05, 0x9f, 0x92, 0x63, 0xed, 0xde, 0xa5, 0xbc, 0x30, 0x09, 0x79, 0xe4, 0x5a, 0x5a, 0x8e, 0x44,
+	0x62, 0xf9, 0x1c, 0xb1, 0x98, 0xf9, 0x97, 0x2c, 0x96, 0x51, 0xf7, 0x47, 0x03, 0xa6, 0xd4, 0x79,
+	0x68, 0x1b, 0x20, 0xa2, 0x3e, 0xe9, 0x65, 0xd5, 0xb4, 0xf3, 0x37, 0xc3, 0x83, 0xfd, 0xbd, 0x09,
+	0x9c, 0x41, 0xa3, 0x67, 0x30, 0x57, 0x6f, 0x11, 0xdf, 0xa7, 0x41, 0x53, 0x9b, 0x49, 0x69, 0x76,
+	0x33, 0xcf, 0xff, 0x30, 0x87, 0x79, 0x14, 0x9c, 0x10, 0xdf, 0x6b, 0x7c, 0x4a, 0x38, 0xd9, 0x9b,
+	0xc0, 0x17, 0x04, 0xa8, 0x6c, 0x73, 0xa7, 0xa1, 0x28, 0xed, 0xb7, 0x7e, 0x3e, 0x09, 0x65, 0xe9,
+	0x94, 0x54, 0x2d, 0xb4, 0x09, 0x50, 0xf3, 0x19, 0xeb, 0xb8, 0x3d, 0x4e, 0x63, 0x79, 0x5f, 0xcb,
+	0x9d, 0x17, 0xb9, 0x20, 0xa9, 0xd5, 0x9a, 0x20, 0xe3, 0x0c, 0x04, 0x7d, 0x79, 0x31, 0x59, 0x27,
+	0xff, 0x3c, 0x59, 0xaf, 0xf5, 0x13, 0x67, 0x7e, 0x60, 0xda, 0xf1, 0x19, 0x7b, 0x07, 0x4a, 0x41,
+	0xb7, 0xf3, 0xf4, 0x38, 0x97, 0x63, 0x8b, 0xc2, 0x27, 0x41, 0xb7, 0x53, 0x65, 0xc7, 0x83, 0x08,
+	0xc8, 0xa0, 0xd0, 0x67, 0x30, 0xa5, 0xc8, 0x76, 0x61, 0xcd, 0xbc, 0x34, 0x06, 0x96, 0xd3, 0x5a,
+	0xa1, 0xb0, 0x2f, 0xdf, 0x3a, 0xd3, 0x6a, 0x27, 0xc6, 0x9a, 0xf4, 0x81, 0x92, 0x48, 0x17, 0xb7,
+	0x17, 0x26, 0xc0, 0xd0, 0xc9, 0xa2, 0x7a, 0x44, 0xf4, 0x9b, 0x2e, 0x8d, 0xb9, 0x28, 0x39, 0xba,
+	0xdb, 0xc8, 0xea, 0xa1, 0xc9, 0xd5, 0x96, 0x28, 0x45, 0x59, 0x10, 0xfa, 0x2f, 0x4c, 0xd3, 0x80,
+	0x47, 0x2c, 0x54, 0x85, 0xd9, 0x74, 0x4b, 0xfd, 0xc4, 0x49, 0x49, 0x38, 0x5d, 0xa0, 0xbd, 0x2b,
+	0x7a, 0x8d, 0xdd, 0x4f, 0x9c, 0xa5, 0xb4, 0xd7, 0xd4, 0xc4, 0xf6, 0x15, 0x1d, 0x07, 0xdd, 0x87,
+	0xb9, 0x98, 0x46, 0x27, 0x5e, 0x9d, 0x46, 0xba, 0x2b, 0x16, 0xe4, 0x3d, 0x97, 0xfa, 0x89, 0xb3,
+	0x90, 0xee, 0x88, 0xd6, 0x28, 0xfb, 0xe2, 0x05, 0x2c, 0xaa, 0xc8, 0x28, 0xaa, 0xb7, 0x55, 0x67,
+	0x2c, 0x4a, 0xce, 0xb9, 0x7e, 0xe2, 0x64, 0xa8, 0x38, 0xb3, 0x46, 0xff, 0x87, 0x22, 0x67, 0x6d,
+	0x1a, 0xc8, 0x0a, 0x53, 0xda, 0x5a, 0xcc, 0xbb, 0x6d, 0x67, 0xe7, 0xc8, 0x2d, 0x69, 0x9f, 0x99,
+	0x84, 0x70, 0xac, 0xc0, 0xe8, 0x16, 0xcc, 0xc6, 0x5e, 0x33, 0x20, 0xbc, 0x1b, 0x51, 0x7b, 0x5a,
+	0x1e, 0x52, 0xee, 0x27, 0xce, 0x90, 0x88, 0x87, 0x4b, 0xed, 0x8a, 0xb3, 0x49, 0x58, 0xbe, 0x34,
+	0x5f, 0x10, 0x85, 0xc5, 0x0e, 0xf9, 0x9a, 0x45, 0x1e, 0xef, 0x61, 0x1a, 0x87, 0x2c, 0x88, 0x65,
+	0x0e, 0x98, 0xa3, 0xf1, 0x2c, 0xdd, 0x99, 0x62, 0xdc, 0x15, 0x7d, 0x39, 0x94, 0x72, 0x57, 0xa3,
+	0x94, 0x1d, 0x8f, 0x4a, 0x44, 0x35, 0x58, 0xe8, 0x78, 0x41, 0x8e, 0x38, 0x3e, 0x6b, 0xf2, 0xa7,
+	0xa4, 0x61, 0xbb, 0x98, 0x32, 0x0f, 0x4e, 0xc1, 0x23, 0xf2, 0x10, 0x87, 0xf9, 0x88, 0x86, 0x2c,
+	0xe2, 0x34, 0x4a, 0x5b, 0x8e, 0x29, 0x93, 0xf9, 0xb1, 0x90, 0x90, 0x6e, 0xc5, 0x7f, 0xaf, 0xef,
+	0x5c, 0x3c, 0x42, 0x1b, 0xf9, 0xa5, 0x01, 0xe5, 0xdc, 0xd5, 0xf3, 0x9e, 0x32, 0xae, 0xf6, 0x14,
+	0xba, 0x09, 0x33, 0x51, 0xd6, 0x2c, 0xb3, 0x2a, 0xd8, 0x43, 0xd2, 0xf3, 0x19, 0x69, 0xe0, 0xc1,
+	0x26, 0x7a, 0xa0, 0xcb, 0x98, 0x6d, 0x5e, 0x5d, 0x56, 0xdd, 0xb2, 0xb6, 0x9c, 0x82, 0x63, 0xf5,
+	0xd2, 0x97, 0xfd, 0xdd, 0x00, 0x73, 0x67, 0xe7, 0x48, 0x64, 0xd8, 0x09, 0x8d, 0x44, 0x1a, 0xd8,
+	0xc6, 0xf0, 0x50, 0x4d, 0xc2, 0xe9, 0x02, 0x3d, 0x84, 0xa5, 0xfc, 0x0c, 0xe8, 0x7b, 0xf5, 0x74,
+	0x5c, 0x9a, 0x55, 0x95, 0x52, 0xcf, 0x8c, 0x32, 0x31, 0xc6, 0x82, 0xd1, 0x7d, 0x98, 0xaf, 0xfb,
+	0x1e, 0x0d, 0xf8, 0x90, 0xdf, 0x1c, 0xce, 0x9c, 0x6a, 0x6b, 0x20, 0xe2, 0x22, 0x14, 0xed, 0xe4,
+	0xae, 0x70, 0x38, 0xb0, 0x6b, 0x61, 0x9c, 0x5d, 0xc7, 0x42, 0xb5, 0xea, 0x3f, 0x1b, 0x50, 0xca,
+	0xf4, 0x58, 0x74, 0x0b, 0x4a, 0x47, 0x24, 0x6a, 0x52, 0xfe, 0x28, 0x68, 0xd0, 0x53, 0x69, 0x06,
+	0x53, 0x0d, 0xb4, 0x9e, 0x20, 0xe0, 0xec, 0xae, 0x98, 0xa8, 0x5a, 0xe9, 0xc4, 0x14, 0xdb, 0x93,
+	0x6b, 0xe6, 0x7b, 0x4d, 0x54, 0x82, 0xa5, 0x1a, 0x49, 0x1e, 0x9c, 0xe1, 0x47, 0xbb, 0x30, 0xc5,
+	0xa5, 0x70, 0xed, 0xcb, 0x4b, 0x25, 0x2d, 0x69, 0x49, 0x96, 0x82, 0x2b, 0x59, 0x58, 0x33, 0x6b,
+	0xbd, 0x9e, 0x42, 0x51, 0x82, 0xc5, 0x6c, 0xee, 0xb3, 0xe7, 0x7a, 0x80, 0x2c, 0x28, 0x55, 0x24,
+	0x01, 0xab, 0x97, 0x00, 0x74, 0xc3, 0x50, 0x37, 0x2d, 0x0d, 0x90, 0x04, 0xac, 0x5e, 0x5a, 0xa0,
+	0x07, 0xb3, 0x83, 0x1b, 0xa0, 0x75, 0x28, 0xb4, 0xd2, 0xba, 0x6d, 0xa9, 0xaa, 0xa6, 0x86, 0x10,
+	0x09, 0x91, 0x7b, 0xe8, 0x1e, 0x14, 0xe5, 0xc5, 0x74, 0x5a, 0x5f, 0xbb, 0x10, 0x99, 0x52, 0x93,
+	0x41, 0x50, 0x2a, 0x15, 0xd4, 0x4b, 0xcc, 0xf8, 0x20, 0x3b, 0xb2, 0x0c, 0x5f, 0xb4, 0x0b, 0x69,
+	0xdc, 0xeb, 0xd9, 0x61, 0x65, 0xa4, 0xa3, 0x71, 0x76, 0xa0, 0x10, 0xee, 0xbc, 0x96, 0x38, 0x48,
+	0x95, 0x74, 0x81, 0x3e, 0x86, 0x42, 0x87, 0x72, 0xa2, 0xaf, 0x73, 0x63, 0x8c, 0x0c, 0x79, 0xdc,
+	0x3e, 0xe5, 0x64, 0x38, 0x21, 0x09, 0x0e, 0x2c, 0x9f, 0x1f, 0x26, 0xd3, 0xbe, 0x9d, 0x04, 0x2b,
+	0x7b, 0x5f, 0x74, 0x03, 0x0a, 0x0d, 0xc2, 0x89, 0xce, 0xb7, 0x19, 0x71, 0xa6, 0xf8, 0xc6, 0xf2,
+	0x89, 0xd6, 0x61, 0xaa, 0x43, 0x79, 0x8b, 0x35, 0x74, 0x6e, 0x81, 0xe8, 0xd8, 0x8a, 0x82, 0xf5,
+	0x5b, 0x48, 0x08, 0x09, 0x6f, 0xd9, 0xe6, 0x50, 0x82, 0xf8, 0xc6, 0xf2, 0x89, 0x9e, 0xc0, 0xb4,
+	0x9a, 0x2d, 0xd2, 0x71, 0xe0, 0xe6, 0xe5, 0xc6, 0xab, 0xa8, 0xb1, 0x23, 0xde, 0x0d, 0x78, 0xd4,
+	0x53, 0xb9, 0xaf, 0x79, 0x71, 0xba, 0x58, 0xd9, 0x06, 0x2b, 0x8b, 0x42, 0x0b, 0x20, 0x7e, 0x8d,
+	0xd4, 0xf5, 0xb1, 0x58, 0xa2, 0x25, 0x28, 0x9e, 0x10, 0xbf, 0xab, 0xeb, 0x16, 0x56, 0x1f, 0xdb,
+	0x93, 0xf7, 0x0c, 0x6d, 0x82, 0xc7, 0x30, 0x97, 0xb7, 0xb6, 0x18, 0x06, 0x6a, 0x99, 0x56, 0x6d,
+	0x0c, 0x7f, 0x25, 0x72, 0x2d, 0x3a, 0x0b, 0xd2, 0x43, 0xdc, 0xc1, 0xab, 0xb3, 0x55, 0xe3, 0xf5,
+	0xd9, 0xaa, 0xf1, 0xeb, 0xd9, 0xaa, 0xf1, 0xdd, 0xf9, 0xea, 0xc4, 0xeb, 0xf3, 0xd5, 0x89, 0x37,
+	0xe7, 0xab, 0x13, 0x5f, 0xdd, 0x7d, 0x9f, 0x4a, 0x9e, 0xfb, 0x93, 0x96, 0x65, 0xbd, 0x36, 0x25,
+	0xff, 0x92, 0xef, 0xfc, 0x31, 0x00, 0x8e, 0xb4, 0xaa, 0x4d, 0x66, 0x0f, 0x00, 0x00,
 }
 
 func (m *SessionHeader) Marshal() (dAtA []byte, err error) {
@@ -1406,6 +1538,150 @@ func (m *HashRange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ProtoRelay) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProtoRelay) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProtoRelay) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Proof.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintPocket(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	{
+		size, err := m.Meta.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintPocket(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	{
+		size, err := m.Payload.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintPocket(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *ProtoPayload) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProtoPayload) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProtoPayload) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Headers) > 0 {
+		for k := range m.Headers {
+			v := m.Headers[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintPocket(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintPocket(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintPocket(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintPocket(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Method) > 0 {
+		i -= len(m.Method)
+		copy(dAtA[i:], m.Method)
+		i = encodeVarintPocket(dAtA, i, uint64(len(m.Method)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintPocket(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ProtoRelayMeta) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProtoRelayMeta) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ProtoRelayMeta) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.BlockHeight != 0 {
+		i = encodeVarintPocket(dAtA, i, uint64(m.BlockHeight))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintPocket(dAtA []byte, offset int, v uint64) int {
 	offset -= sovPocket(v)
 	base := offset
@@ -1713,6 +1989,62 @@ func (m *HashRange) Size() (n int) {
 	return n
 }
 
+func (m *ProtoRelay) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Payload.Size()
+	n += 1 + l + sovPocket(uint64(l))
+	l = m.Meta.Size()
+	n += 1 + l + sovPocket(uint64(l))
+	l = m.Proof.Size()
+	n += 1 + l + sovPocket(uint64(l))
+	return n
+}
+
+func (m *ProtoPayload) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovPocket(uint64(l))
+	}
+	l = len(m.Method)
+	if l > 0 {
+		n += 1 + l + sovPocket(uint64(l))
+	}
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovPocket(uint64(l))
+	}
+	if len(m.Headers) > 0 {
+		for k, v := range m.Headers {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovPocket(uint64(len(k))) + 1 + len(v) + sovPocket(uint64(len(v)))
+			n += mapEntrySize + 1 + sovPocket(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *ProtoRelayMeta) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BlockHeight != 0 {
+		n += 1 + sovPocket(uint64(m.BlockHeight))
+	}
+	return n
+}
+
 func sovPocket(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -1837,10 +2169,7 @@ func (m *SessionHeader) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthPocket
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthPocket
 			}
 			if (iNdEx + skippy) > l {
@@ -1989,10 +2318,7 @@ func (m *Session) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthPocket
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthPocket
 			}
 			if (iNdEx + skippy) > l {
@@ -2199,10 +2525,7 @@ func (m *MsgClaim) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthPocket
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthPocket
 			}
 			if (iNdEx + skippy) > l {
@@ -2337,10 +2660,7 @@ func (m *MsgProtoProof) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthPocket
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthPocket
 			}
 			if (iNdEx + skippy) > l {
@@ -2460,10 +2780,7 @@ func (m *ProofI) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthPocket
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthPocket
 			}
 			if (iNdEx + skippy) > l {
@@ -2655,10 +2972,7 @@ func (m *ProtoEvidence) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthPocket
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthPocket
 			}
 			if (iNdEx + skippy) > l {
@@ -2907,10 +3221,7 @@ func (m *RelayProof) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthPocket
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthPocket
 			}
 			if (iNdEx + skippy) > l {
@@ -3061,10 +3372,7 @@ func (m *ChallengeProofInvalidData) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthPocket
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthPocket
 			}
 			if (iNdEx + skippy) > l {
@@ -3211,10 +3519,7 @@ func (m *RelayResponse) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthPocket
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthPocket
 			}
 			if (iNdEx + skippy) > l {
@@ -3392,10 +3697,7 @@ func (m *AAT) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthPocket
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthPocket
 			}
 			if (iNdEx + skippy) > l {
@@ -3531,10 +3833,7 @@ func (m *MerkleProof) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthPocket
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthPocket
 			}
 			if (iNdEx + skippy) > l {
@@ -3622,10 +3921,7 @@ func (m *Range) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthPocket
-			}
-			if (iNdEx + skippy) < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthPocket
 			}
 			if (iNdEx + skippy) > l {
@@ -3742,10 +4038,498 @@ func (m *HashRange) Unmarshal(dAtA []byte) error {
 			if err != nil {
 				return err
 			}
-			if skippy < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthPocket
 			}
-			if (iNdEx + skippy) < 0 {
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProtoRelay) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPocket
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProtoRelay: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProtoRelay: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPocket
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Payload.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Meta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPocket
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Meta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proof", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPocket
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Proof.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPocket(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPocket
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProtoPayload) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPocket
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProtoPayload: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProtoPayload: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPocket
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Method", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPocket
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Method = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPocket
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Headers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPocket
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPocket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Headers == nil {
+				m.Headers = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPocket
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPocket
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthPocket
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthPocket
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPocket
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthPocket
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthPocket
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipPocket(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthPocket
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Headers[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPocket(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPocket
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProtoRelayMeta) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPocket
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProtoRelayMeta: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProtoRelayMeta: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHeight", wireType)
+			}
+			m.BlockHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPocket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPocket(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthPocket
 			}
 			if (iNdEx + skippy) > l {
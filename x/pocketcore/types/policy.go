@@ -0,0 +1,225 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	gopath "path"
+	"strconv"
+	"strings"
+
+	sdk "github.com/pokt-network/pocket-core/types"
+)
+
+// the reasons of the policy rejections, the labels of the policy metrics
+const (
+	PolicyMethodDenied  = "method_denied"
+	PolicyPathDenied    = "path_denied"
+	PolicyLogBlockRange = "log_block_range"
+)
+
+// the JSON-RPC method whose block range is limited by the policy
+const ethGetLogsMethod = "eth_getLogs"
+
+// "RequestPolicy" - The policy of the relay requests of a hosted blockchain, set in chains.json
+type RequestPolicy struct {
+	AllowMethods     []string `json:"allow_methods,omitempty"`       // the only JSON-RPC methods relayed (optional)
+	DenyMethods      []string `json:"deny_methods,omitempty"`        // the JSON-RPC methods never relayed (optional)
+	AllowPaths       []string `json:"allow_paths,omitempty"`         // the only path prefixes relayed, when the relay has a path (optional)
+	MaxLogBlockRange int64    `json:"max_log_block_range,omitempty"` // the max block range of the eth_getLogs requests (optional)
+}
+
+// "Validate" - Validates the request policy
+func (p *RequestPolicy) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if p.MaxLogBlockRange < 0 {
+		return fmt.Errorf("the max log block range is negative: %d", p.MaxLogBlockRange)
+	}
+	return nil
+}
+
+// "Check" - Checks the payload of a relay against the policy: the path against the allowed paths and the methods of the
+// JSON-RPC request (or batch) against the allowed and denied methods and the max log block range. A payload that is not
+// a JSON-RPC request is only checked by its path. Returns the reason of the rejection with the error
+func (p *RequestPolicy) Check(payload Payload) (reason string, err error) {
+	if p == nil {
+		return "", nil
+	}
+	if payload.Path != "" && len(p.AllowPaths) != 0 && !p.pathAllowed(payload.Path) {
+		return PolicyPathDenied, fmt.Errorf("the path %s is not allowed", payload.Path)
+	}
+	if len(p.AllowMethods) == 0 && len(p.DenyMethods) == 0 && p.MaxLogBlockRange == 0 {
+		return "", nil
+	}
+	reqs, err := parseJSONRPCRequests(payload.Data)
+	if err != nil {
+		return PolicyMethodDenied, err
+	}
+	for _, req := range reqs {
+		if !p.methodAllowed(req.Method) {
+			return PolicyMethodDenied, fmt.Errorf("the method %s is not allowed", req.Method)
+		}
+		if p.MaxLogBlockRange != 0 && req.Method == ethGetLogsMethod {
+			if err := checkLogBlockRange(req.Params, p.MaxLogBlockRange); err != nil {
+				return PolicyLogBlockRange, err
+			}
+		}
+	}
+	return "", nil
+}
+
+// pathAllowed matches the decoded and cleaned path against the allowed prefixes. The path is relayed as is, so a path
+// containing a dot dot, which the backend may resolve out of the allowed prefix, is never allowed
+func (p *RequestPolicy) pathAllowed(raw string) bool {
+	decoded, err := url.PathUnescape(raw)
+	if err != nil || strings.Contains(decoded, "..") {
+		return false
+	}
+	path := strings.Trim(gopath.Clean("/"+decoded), "/")
+	if path == "" {
+		return true
+	}
+	for _, allowed := range p.AllowPaths {
+		allowed = strings.Trim(allowed, "/")
+		if path == allowed || strings.HasPrefix(path, allowed+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *RequestPolicy) methodAllowed(method string) bool {
+	for _, denied := range p.DenyMethods {
+		if method == denied {
+			return false
+		}
+	}
+	if len(p.AllowMethods) == 0 {
+		return true
+	}
+	for _, allowed := range p.AllowMethods {
+		if method == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// listsMethod returns whether the method is one of the allowed methods
+func (p *RequestPolicy) listsMethod(method string) bool {
+	if p == nil {
+		return false
+	}
+	for _, allowed := range p.AllowMethods {
+		if method == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonRPCRequest is the part of a JSON-RPC request checked by the policy
+type jsonRPCRequest struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+// parseJSONRPCRequests returns the requests of a JSON-RPC request or batch, none when the data is not json or an object
+// without a method. Json that cannot be parsed is an error, as it cannot be checked
+func parseJSONRPCRequests(data string) ([]jsonRPCRequest, error) {
+	data = strings.TrimSpace(data)
+	switch {
+	case strings.HasPrefix(data, "["):
+		var batch []json.RawMessage
+		if err := json.Unmarshal([]byte(data), &batch); err != nil {
+			return nil, fmt.Errorf("the JSON-RPC batch is invalid: %v", err)
+		}
+		reqs := make([]jsonRPCRequest, len(batch))
+		for i, bz := range batch {
+			if err := json.Unmarshal(bz, &reqs[i]); err != nil {
+				return nil, fmt.Errorf("the JSON-RPC request is invalid: %v", err)
+			}
+		}
+		return reqs, nil
+	case strings.HasPrefix(data, "{"):
+		var req jsonRPCRequest
+		if err := json.Unmarshal([]byte(data), &req); err != nil {
+			return nil, fmt.Errorf("the JSON-RPC request is invalid: %v", err)
+		}
+		if req.Method == "" {
+			return nil, nil
+		}
+		return []jsonRPCRequest{req}, nil
+	}
+	return nil, nil
+}
+
+// checkLogBlockRange checks the block range of the filter of an eth_getLogs request. The head tags (latest, pending,
+// safe, finalized or no block) are only bounded against the head, so a range between a head tag and a block number
+// cannot be checked and is rejected
+func checkLogBlockRange(raw json.RawMessage, max int64) error {
+	var params []json.RawMessage
+	if err := json.Unmarshal(raw, &params); err != nil || len(params) == 0 {
+		return fmt.Errorf("the log filter is missing")
+	}
+	var filter struct {
+		FromBlock string `json:"fromBlock"`
+		ToBlock   string `json:"toBlock"`
+		BlockHash string `json:"blockHash"`
+	}
+	if err := json.Unmarshal(params[0], &filter); err != nil {
+		return fmt.Errorf("the log filter is invalid: %v", err)
+	}
+	if filter.BlockHash != "" {
+		return nil
+	}
+	from, fromHead, err := parseBlockTag(filter.FromBlock)
+	if err != nil {
+		return err
+	}
+	to, toHead, err := parseBlockTag(filter.ToBlock)
+	if err != nil {
+		return err
+	}
+	switch {
+	case fromHead && toHead:
+		return nil
+	case fromHead || toHead:
+		return fmt.Errorf("the log block range from %s to %s cannot be bounded, use block numbers", filter.FromBlock, filter.ToBlock)
+	case to-from > max:
+		return fmt.Errorf("the log block range of %d blocks exceeds the max of %d", to-from, max)
+	}
+	return nil
+}
+
+// parseBlockTag returns the block number of a block tag of a log filter, or whether the tag is the head
+func parseBlockTag(tag string) (number int64, head bool, err error) {
+	switch tag {
+	case "", "latest", "pending", "safe", "finalized":
+		return 0, true, nil
+	case "earliest":
+		return 0, false, nil
+	}
+	number, err = strconv.ParseInt(strings.TrimPrefix(strings.ToLower(tag), "0x"), 16, 64)
+	if err != nil || !strings.HasPrefix(strings.ToLower(tag), "0x") {
+		return 0, false, fmt.Errorf("the block %s of the log filter is invalid", tag)
+	}
+	return number, false, nil
+}
+
+// "CheckPolicy" - Checks the relay against the request policy of its chain, the rejections are counted in the service
+// metrics of the chain
+func (r Relay) CheckPolicy(hostedBlockchains *HostedBlockchains) sdk.Error {
+	chain, err := hostedBlockchains.GetChain(r.Proof.Blockchain)
+	if err != nil {
+		return err
+	}
+	reason, er := chain.Policy.Check(r.Payload)
+	if er != nil {
+		GlobalServiceMetric().AddPolicyRejectFor(r.Proof.Blockchain, reason)
+		return NewPolicyRejectedError(ModuleName, reason, er)
+	}
+	return nil
+}
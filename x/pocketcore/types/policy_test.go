@@ -0,0 +1,59 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestPolicy_Check(t *testing.T) {
+	policy := &RequestPolicy{
+		AllowMethods:     []string{"eth_blockNumber", "eth_getLogs", "eth_call"},
+		DenyMethods:      []string{"eth_call"},
+		AllowPaths:       []string{"/v1/status", "cosmos/bank/"},
+		MaxLogBlockRange: 100,
+	}
+	tests := []struct {
+		name    string
+		payload Payload
+		reason  string
+	}{
+		{"allowed method", Payload{Data: `{"jsonrpc":"2.0","method":"eth_blockNumber","params":[],"id":1}`}, ""},
+		{"method not allowed", Payload{Data: `{"jsonrpc":"2.0","method":"debug_traceTransaction","params":["0x1"],"id":1}`}, PolicyMethodDenied},
+		{"denied method", Payload{Data: `{"jsonrpc":"2.0","method":"eth_call","params":[],"id":1}`}, PolicyMethodDenied},
+		{"batch with a method not allowed", Payload{Data: `[{"method":"eth_blockNumber"},{"method":"debug_traceTransaction"}]`}, PolicyMethodDenied},
+		{"batch with an invalid request", Payload{Data: `[{"method":"debug_traceTransaction"},5]`}, PolicyMethodDenied},
+		{"invalid json", Payload{Data: `{"method":"debug_traceTransaction"`}, PolicyMethodDenied},
+		{"not json-rpc", Payload{Data: `foo`}, ""},
+		{"allowed path", Payload{Data: `{}`, Path: "/v1/status"}, ""},
+		{"allowed path prefix", Payload{Data: `{}`, Path: "/cosmos/bank/balances/foo"}, ""},
+		{"path not allowed", Payload{Data: `{}`, Path: "/v1/statuses"}, PolicyPathDenied},
+		{"path traversal", Payload{Data: `{}`, Path: "v1/status/../../admin"}, PolicyPathDenied},
+		{"encoded path traversal", Payload{Data: `{}`, Path: "/v1/status/%2e%2e/%2E%2E/admin"}, PolicyPathDenied},
+		{"invalid path encoding", Payload{Data: `{}`, Path: "/v1/status/%zz"}, PolicyPathDenied},
+		{"encoded allowed path", Payload{Data: `{}`, Path: "/v1%2Fstatus"}, ""},
+		{"cleaned allowed path", Payload{Data: `{}`, Path: "//v1/./status/"}, ""},
+		{"logs in range", Payload{Data: `{"method":"eth_getLogs","params":[{"fromBlock":"0x10","toBlock":"0x74"}]}`}, ""},
+		{"logs of the head", Payload{Data: `{"method":"eth_getLogs","params":[{"toBlock":"latest"}]}`}, ""},
+		{"logs of a block hash", Payload{Data: `{"method":"eth_getLogs","params":[{"blockHash":"0xabc"}]}`}, ""},
+		{"logs over the range", Payload{Data: `{"method":"eth_getLogs","params":[{"fromBlock":"0x10","toBlock":"0x75"}]}`}, PolicyLogBlockRange},
+		{"logs from earliest", Payload{Data: `{"method":"eth_getLogs","params":[{"fromBlock":"earliest","toBlock":"0x1000"}]}`}, PolicyLogBlockRange},
+		{"logs to the head", Payload{Data: `{"method":"eth_getLogs","params":[{"fromBlock":"0x10","toBlock":"latest"}]}`}, PolicyLogBlockRange},
+		{"logs with an invalid block", Payload{Data: `{"method":"eth_getLogs","params":[{"fromBlock":"16","toBlock":"0x20"}]}`}, PolicyLogBlockRange},
+		{"logs without filter", Payload{Data: `{"method":"eth_getLogs","params":{}}`}, PolicyLogBlockRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, err := policy.Check(tt.payload)
+			assert.Equal(t, tt.reason, reason)
+			assert.Equal(t, tt.reason != "", err != nil)
+		})
+	}
+	// no policy
+	var none *RequestPolicy
+	reason, err := none.Check(Payload{Data: `{"method":"debug_traceTransaction"}`})
+	assert.Equal(t, "", reason)
+	assert.Nil(t, err)
+	assert.Nil(t, none.Validate())
+	assert.NotNil(t, (&RequestPolicy{MaxLogBlockRange: -1}).Validate())
+}
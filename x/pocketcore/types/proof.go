@@ -216,6 +216,21 @@ func (rp RelayProof) Store(maxRelays sdk.BigInt) {
 	SetProof(rp.SessionHeader(), RelayEvidence, rp, maxRelays)
 }
 
+// "Admit" - Checks the relay proof against the stored evidence and stores it, atomically for the session
+func (rp RelayProof) Admit(maxRelays sdk.BigInt) sdk.Error {
+	return AdmitProof(rp.SessionHeader(), RelayEvidence, rp, maxRelays)
+}
+
+// "Reserve" - Checks the relay proof against the stored evidence and reserves its slot, atomically for the session
+func (rp RelayProof) Reserve(maxRelays sdk.BigInt) sdk.Error {
+	return ReserveProof(rp.SessionHeader(), RelayEvidence, rp, maxRelays)
+}
+
+// "Release" - Releases the slot reserved for the relay proof, without storing it
+func (rp RelayProof) Release() {
+	ReleaseProof(rp.SessionHeader(), RelayEvidence, rp)
+}
+
 func (rp RelayProof) GetSigner() sdk.Address {
 	pk, err := crypto.NewPublicKey(rp.ServicerPubKey)
 	if err != nil {
@@ -0,0 +1,182 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// the JSON-RPC method of the block number of the staleness check by default
+	DefaultBlockNumberMethod = "eth_blockNumber"
+	// the seconds between the staleness checks by default
+	DefaultStalenessInterval = 10
+)
+
+// "ResponseChecks" - The checks of the backend responses of a hosted blockchain, set in chains.json. The relays whose
+// response fails a check are returned unsigned and their proof is not stored
+type ResponseChecks struct {
+	HTTPStatus        bool   `json:"http_status"`                   // fail the responses without a 2xx http status
+	JSONRPCError      bool   `json:"jsonrpc_error"`                 // fail the JSON-RPC responses with an error object
+	ReferenceURL      string `json:"reference_url,omitempty"`       // the JSON-RPC url of the reference block number (optional)
+	MaxBlocksBehind   int64  `json:"max_blocks_behind,omitempty"`   // fail the relays when the backend is further behind the reference
+	BlockNumberMethod string `json:"block_number_method,omitempty"` // the JSON-RPC method of the block number, eth_blockNumber by default
+	StalenessInterval int64  `json:"staleness_interval,omitempty"`  // the seconds between the staleness checks, 10 by default
+}
+
+// responseCheckError is a backend response that fails the checks of its chain
+type responseCheckError struct {
+	reason string
+}
+
+func (e responseCheckError) Error() string {
+	return e.reason
+}
+
+// "IsResponseCheckError" - Returns whether the error is a backend response failing the checks of its chain
+func IsResponseCheckError(err error) bool {
+	return errors.As(err, &responseCheckError{})
+}
+
+// "Validate" - Validates the response checks
+func (c *ResponseChecks) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.MaxBlocksBehind < 0 || c.StalenessInterval < 0 {
+		return fmt.Errorf("the staleness check is invalid")
+	}
+	if c.MaxBlocksBehind > 0 && c.ReferenceURL == "" {
+		return fmt.Errorf("the staleness check has no reference url")
+	}
+	return nil
+}
+
+// "CheckStatus" - Checks the http status of a backend response
+func (c *ResponseChecks) CheckStatus(status int) error {
+	if c == nil || !c.HTTPStatus || status/100 == 2 {
+		return nil
+	}
+	return responseCheckError{fmt.Sprintf("the backend responded with http status %d", status)}
+}
+
+// "CheckResponse" - Checks the body of a backend response for JSON-RPC error objects, of the response or of any response
+// of a batch
+func (c *ResponseChecks) CheckResponse(response string) error {
+	if c == nil || !c.JSONRPCError {
+		return nil
+	}
+	var responses []struct {
+		Error json.RawMessage `json:"error"`
+	}
+	response = strings.TrimSpace(response)
+	if !strings.HasPrefix(response, "[") {
+		response = "[" + response + "]"
+	}
+	if err := json.Unmarshal([]byte(response), &responses); err != nil {
+		return nil
+	}
+	for _, res := range responses {
+		if len(res.Error) != 0 && string(res.Error) != "null" {
+			return responseCheckError{fmt.Sprintf("the backend responded with the JSON-RPC error %s", res.Error)}
+		}
+	}
+	return nil
+}
+
+// "CheckStaleness" - Checks the last block number of the backend of the chain against the reference. The block numbers
+// are refreshed in the background every staleness interval, so the relays never wait on the check
+func (c *ResponseChecks) CheckStaleness(chain HostedBlockchain) error {
+	if c == nil || c.MaxBlocksBehind == 0 {
+		return nil
+	}
+	state := getStalenessState(chain)
+	state.l.Lock()
+	defer state.l.Unlock()
+	interval := time.Duration(c.StalenessInterval) * time.Second
+	if interval == 0 {
+		interval = DefaultStalenessInterval * time.Second
+	}
+	if !state.refreshing && time.Since(state.checked) >= interval {
+		state.refreshing = true
+		go state.refresh(chain, *c)
+	}
+	if state.behind > c.MaxBlocksBehind {
+		return responseCheckError{fmt.Sprintf("the backend is %d blocks behind the reference", state.behind)}
+	}
+	return nil
+}
+
+// stalenessState is the last staleness check of a chain
+type stalenessState struct {
+	l          sync.Mutex
+	checked    time.Time
+	behind     int64
+	refreshing bool
+}
+
+var (
+	stalenessStates  = make(map[string]*stalenessState)
+	stalenessStatesL sync.Mutex
+)
+
+func getStalenessState(chain HostedBlockchain) *stalenessState {
+	stalenessStatesL.Lock()
+	defer stalenessStatesL.Unlock()
+	key := chain.ID + "|" + chain.URL + "|" + chain.ResponseChecks.ReferenceURL
+	state, found := stalenessStates[key]
+	if !found {
+		state = &stalenessState{}
+		stalenessStates[key] = state
+	}
+	return state
+}
+
+// refresh queries the block numbers of the backend and the reference, a backend or reference that can't be queried is
+// not considered behind
+func (s *stalenessState) refresh(chain HostedBlockchain, c ResponseChecks) {
+	method := c.BlockNumberMethod
+	if method == "" {
+		method = DefaultBlockNumberMethod
+	}
+	req := fmt.Sprintf(`{"jsonrpc":"2.0","method":"%s","params":[],"id":1}`, method)
+	var behind int64
+	backend, err := chain.Execute(Payload{Data: req, Method: DEFAULTHTTPMETHOD})
+	if err == nil {
+		reference, _, er := executeHTTPRequest(req, c.ReferenceURL, GlobalPocketConfig.UserAgent, BasicAuth{}, DEFAULTHTTPMETHOD, nil)
+		if er == nil {
+			backendHeight, er1 := parseBlockNumber(backend)
+			referenceHeight, er2 := parseBlockNumber(reference)
+			if er1 == nil && er2 == nil && referenceHeight > backendHeight {
+				behind = referenceHeight - backendHeight
+			}
+		}
+	}
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.behind = behind
+	s.checked = time.Now()
+	s.refreshing = false
+}
+
+// parseBlockNumber parses the result of a JSON-RPC block number response: a hex or decimal string, or a number
+func parseBlockNumber(response string) (int64, error) {
+	var res struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(response), &res); err != nil {
+		return 0, err
+	}
+	var s string
+	if err := json.Unmarshal(res.Result, &s); err != nil {
+		s = string(res.Result)
+	}
+	if strings.HasPrefix(strings.ToLower(s), "0x") {
+		return strconv.ParseInt(s[2:], 16, 64)
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
@@ -0,0 +1,90 @@
+package types
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseChecks_CheckResponse(t *testing.T) {
+	checks := &ResponseChecks{HTTPStatus: true, JSONRPCError: true}
+	assert.Nil(t, checks.CheckStatus(200))
+	assert.Nil(t, checks.CheckStatus(204))
+	assert.True(t, IsResponseCheckError(checks.CheckStatus(500)))
+	assert.True(t, IsResponseCheckError(checks.CheckStatus(404)))
+	tests := []struct {
+		name     string
+		response string
+		fails    bool
+	}{
+		{"result", `{"jsonrpc":"2.0","id":1,"result":"0x1"}`, false},
+		{"null error", `{"jsonrpc":"2.0","id":1,"result":"0x1","error":null}`, false},
+		{"error", `{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"header not found"}}`, true},
+		{"batch", `[{"id":1,"result":"0x1"},{"id":2,"result":"0x2"}]`, false},
+		{"batch with an error", `[{"id":1,"result":"0x1"},{"id":2,"error":{"code":-32000,"message":"header not found"}}]`, true},
+		{"not json", `foo`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.fails, checks.CheckResponse(tt.response) != nil)
+		})
+	}
+	// no checks
+	var none *ResponseChecks
+	assert.Nil(t, none.CheckStatus(500))
+	assert.Nil(t, none.CheckResponse(`{"error":{"code":-32000}}`))
+	assert.Nil(t, (&ResponseChecks{}).CheckStatus(500))
+	assert.Nil(t, none.Validate())
+	assert.NotNil(t, (&ResponseChecks{MaxBlocksBehind: 5}).Validate())
+	assert.Nil(t, (&ResponseChecks{MaxBlocksBehind: 5, ReferenceURL: "http://localhost:8545"}).Validate())
+}
+
+func TestResponseChecks_CheckStaleness(t *testing.T) {
+	newNode := func(height *int64) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":"0x%x"}`, atomic.LoadInt64(height))
+		}))
+	}
+	backendHeight, referenceHeight := int64(100), int64(120)
+	backend, reference := newNode(&backendHeight), newNode(&referenceHeight)
+	defer backend.Close()
+	defer reference.Close()
+	chain := HostedBlockchain{ID: "0001", URL: backend.URL, ResponseChecks: &ResponseChecks{
+		ReferenceURL:    reference.URL,
+		MaxBlocksBehind: 10,
+	}}
+	// the first check starts the refresh in the background
+	assert.Nil(t, chain.ResponseChecks.CheckStaleness(chain))
+	assert.Eventually(t, func() bool {
+		return IsResponseCheckError(chain.ResponseChecks.CheckStaleness(chain))
+	}, 5*time.Second, 10*time.Millisecond)
+	// the backend catches up, the state is refreshed after the interval
+	atomic.StoreInt64(&backendHeight, 115)
+	state := getStalenessState(chain)
+	state.l.Lock()
+	state.checked = time.Time{}
+	state.l.Unlock()
+	_ = chain.ResponseChecks.CheckStaleness(chain)
+	assert.Eventually(t, func() bool {
+		return chain.ResponseChecks.CheckStaleness(chain) == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestParseBlockNumber(t *testing.T) {
+	for response, expected := range map[string]int64{
+		`{"result":"0x10"}`: 16,
+		`{"result":"16"}`:   16,
+		`{"result":16}`:     16,
+	} {
+		height, err := parseBlockNumber(response)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, height)
+	}
+	_, err := parseBlockNumber(`{"error":{"code":-32000}}`)
+	assert.NotNil(t, err)
+}
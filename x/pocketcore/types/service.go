@@ -11,7 +11,6 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
-	"strings"
 	"time"
 )
 
@@ -66,18 +65,9 @@ func (r *Relay) Validate(ctx sdk.Ctx, posKeeper PosKeeper, appsKeeper AppsKeeper
 		Chain:              r.Proof.Blockchain,
 		SessionBlockHeight: r.Proof.SessionBlockHeight,
 	}
-	// validate unique relay
-	evidence, totalRelays := GetTotalProofs(header, RelayEvidence, maxPossibleRelays)
-	if evidence.IsSealed() {
-		return sdk.ZeroInt(), NewSealedEvidenceError(ModuleName)
-	}
-	// get evidence key by proof
-	if !IsUniqueProof(r.Proof, evidence) {
-		return sdk.ZeroInt(), NewDuplicateProofError(ModuleName)
-	}
-	// validate not over service
-	if sdk.NewInt(totalRelays).GTE(maxPossibleRelays) {
-		return sdk.ZeroInt(), NewOverServiceError(ModuleName)
+	// validate unique relay, not over service, under the lock of the session
+	if err := CheckProof(header, RelayEvidence, r.Proof, maxPossibleRelays); err != nil {
+		return sdk.ZeroInt(), err
 	}
 	// validate the Proof
 	if err := r.Proof.ValidateLocal(app.GetChains(), int(sessionNodeCount), sessionBlockHeight, node); err != nil {
@@ -120,16 +110,26 @@ func (r Relay) Execute(hostedBlockchains *HostedBlockchains) (string, sdk.Error)
 		GlobalServiceMetric().AddErrorFor(r.Proof.Blockchain)
 		return "", err
 	}
-	url := strings.Trim(chain.URL, `/`)
-	if len(r.Payload.Path) > 0 {
-		url = url + "/" + strings.Trim(r.Payload.Path, `/`)
+	// fail fast when the backend is behind the reference
+	if er := chain.ResponseChecks.CheckStaleness(chain); er != nil {
+		GlobalServiceMetric().AddErrorFor(r.Proof.Blockchain)
+		return "", NewBackendResponseError(ModuleName, er)
+	}
+	// do the request on the backend of the chain
+	res, er := chain.Execute(r.Payload)
+	if er == nil {
+		er = chain.ResponseChecks.CheckResponse(res)
 	}
-	// do basic http request on the relay
-	res, er := executeHTTPRequest(r.Payload.Data, url, GlobalPocketConfig.UserAgent, chain.BasicAuth, r.Payload.Method, r.Payload.Headers)
 	if er != nil {
 		// metric track
 		GlobalServiceMetric().AddErrorFor(r.Proof.Blockchain)
-		return res, NewHTTPExecutionError(ModuleName, er)
+		switch {
+		case IsResponseCheckError(er):
+			return res, NewBackendResponseError(ModuleName, er)
+		case chain.BackendType() == BackendHTTP:
+			return res, NewHTTPExecutionError(ModuleName, er)
+		}
+		return res, NewBackendExecutionError(ModuleName, chain.BackendType(), er)
 	}
 	return res, nil
 }
@@ -148,6 +148,39 @@ func (r Relay) Bytes() []byte {
 	return res
 }
 
+// "ToProto" - Converts the relay to its protobuf encoding
+func (r Relay) ToProto() ProtoRelay {
+	return ProtoRelay{
+		Payload: ProtoPayload{
+			Data:    r.Payload.Data,
+			Method:  r.Payload.Method,
+			Path:    r.Payload.Path,
+			Headers: r.Payload.Headers,
+		},
+		Meta:  ProtoRelayMeta{BlockHeight: r.Meta.BlockHeight},
+		Proof: r.Proof,
+	}
+}
+
+// "FromProto" - Converts the protobuf encoding back to the relay. The request hash is the one of the relay: an empty
+// headers map is decoded as no headers, so it must be hashed as such
+func (pr ProtoRelay) FromProto() Relay {
+	var headers map[string]string
+	if len(pr.Payload.Headers) != 0 {
+		headers = pr.Payload.Headers
+	}
+	return Relay{
+		Payload: Payload{
+			Data:    pr.Payload.Data,
+			Method:  pr.Payload.Method,
+			Path:    pr.Payload.Path,
+			Headers: headers,
+		},
+		Meta:  RelayMeta{BlockHeight: pr.Meta.BlockHeight},
+		Proof: pr.Proof,
+	}
+}
+
 // "Requesthash" - The cryptographic merkleHash representation of the request
 func (r Relay) RequestHash() []byte {
 	return Hash(r.Bytes())
@@ -245,6 +278,11 @@ func (rr RelayResponse) Validate() sdk.Error {
 
 // "Hash" - The cryptographic merkleHash representation of the relay response
 func (rr RelayResponse) Hash() []byte {
+	return Hash(rr.HashSeed())
+}
+
+// "HashSeed" - The bytes hashed by Hash: the response and the hash of its proof, without the signature
+func (rr RelayResponse) HashSeed() []byte {
 	seed, err := json.Marshal(relayResponse{
 		Signature: "",
 		Response:  rr.Response,
@@ -253,7 +291,7 @@ func (rr RelayResponse) Hash() []byte {
 	if err != nil {
 		log.Fatalf(fmt.Errorf("an error occured hashing the relay response:\n%v", err).Error())
 	}
-	return Hash(seed)
+	return seed
 }
 
 // "HashString" - The hex string representation of the merkleHash
@@ -279,18 +317,24 @@ type DispatchResponse struct {
 	BlockHeight int64           `json:"block_height"`
 }
 
+// "BatchDispatchResponse" - The response object used in batch dispatching, a session for every chain of the app
+type BatchDispatchResponse struct {
+	Sessions    []DispatchSession `json:"sessions"`
+	BlockHeight int64             `json:"block_height"`
+}
+
 type DispatchSession struct {
 	SessionHeader `json:"header"`
 	SessionKey    `json:"key"`
 	SessionNodes  []exported.ValidatorI `json:"nodes"`
 }
 
-// "executeHTTPRequest" takes in the raw json string and forwards it to the RPC endpoint
-func executeHTTPRequest(payload, url, userAgent string, basicAuth BasicAuth, method string, headers map[string]string) (string, error) {
+// "executeHTTPRequest" takes in the raw json string and forwards it to the RPC endpoint, returns the body and the status
+func executeHTTPRequest(payload, url, userAgent string, basicAuth BasicAuth, method string, headers map[string]string) (response string, status int, err error) {
 	// generate an http request
 	req, err := http.NewRequest(method, url, bytes.NewBuffer([]byte(payload)))
 	if err != nil {
-		return "", err
+		return "", 0, err
 	}
 	if basicAuth.Username != "" {
 		req.SetBasicAuth(basicAuth.Username, basicAuth.Password)
@@ -309,19 +353,19 @@ func executeHTTPRequest(payload, url, userAgent string, basicAuth BasicAuth, met
 	// execute the request
 	resp, err := (&http.Client{Timeout: globalRPCTimeout * time.Millisecond}).Do(req)
 	if err != nil {
-		return "", err
+		return "", 0, err
 	}
 	// read all bz
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return "", err
+		return "", resp.StatusCode, err
 	}
 	defer resp.Body.Close()
 	if GlobalPocketConfig.JSONSortRelayResponses {
 		body = []byte(sortJSONResponse(string(body)))
 	}
 	// return
-	return string(body), nil
+	return string(body), resp.StatusCode, nil
 }
 
 // "sortJSONResponse" - sorts json from a relay response
@@ -270,6 +270,49 @@ func TestRelayResponse_BytesAndHash(t *testing.T) {
 	assert.Equal(t, storedHashString, relayResp.HashString())
 }
 
+func TestRelay_ProtoRoundTrip(t *testing.T) {
+	appPrivKey := GetRandomPrivateKey()
+	relay := Relay{
+		Payload: Payload{Data: `{"jsonrpc":"2.0","method":"eth_blockNumber","params":[],"id":1}`, Method: "POST"},
+		Meta:    RelayMeta{BlockHeight: 5},
+		Proof: RelayProof{
+			Entropy:            230942034,
+			SessionBlockHeight: 1,
+			ServicerPubKey:     GetRandomPrivateKey().PublicKey().RawString(),
+			Blockchain:         "0001",
+			Token: AAT{
+				Version:              "0.0.1",
+				ApplicationPublicKey: appPrivKey.PublicKey().RawString(),
+				ClientPublicKey:      appPrivKey.PublicKey().RawString(),
+				ApplicationSignature: "foo",
+			},
+			Signature: "bar",
+		},
+	}
+	for _, headers := range []map[string]string{nil, {"Content-Type": "application/json", "X-Foo": "bar"}} {
+		relay.Payload.Headers = headers
+		relay.Proof.RequestHash = relay.RequestHashString()
+		pr := relay.ToProto()
+		bz, err := pr.Marshal()
+		assert.Nil(t, err)
+		var decoded ProtoRelay
+		assert.Nil(t, decoded.Unmarshal(bz))
+		res := decoded.FromProto()
+		assert.Equal(t, relay, res)
+		// the hashes the signatures are over are the ones of the json relay
+		assert.Equal(t, relay.RequestHashString(), res.RequestHashString())
+		assert.Equal(t, relay.Proof.HashString(), res.Proof.HashString())
+	}
+	// an empty headers map is decoded as no headers
+	relay.Payload.Headers = map[string]string{}
+	pr := relay.ToProto()
+	bz, err := pr.Marshal()
+	assert.Nil(t, err)
+	var decoded ProtoRelay
+	assert.Nil(t, decoded.Unmarshal(bz))
+	assert.Nil(t, decoded.FromProto().Payload.Headers)
+}
+
 func TestSortJSON(t *testing.T) {
 	// out of order json arrays
 	j1 := `{"foo":0,"bar":1}`
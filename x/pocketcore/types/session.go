@@ -10,6 +10,9 @@ import (
 	"log"
 )
 
+// "MaxBatchDispatchChains" - The maximum number of chains of a batch dispatch
+const MaxBatchDispatchChains = 100
+
 // "Session" - The relationship between an application and the pocket network
 
 func (s Session) IsSealed() bool {
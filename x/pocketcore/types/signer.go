@@ -0,0 +1,77 @@
+package types
+
+import (
+	"github.com/pokt-network/pocket-core/crypto"
+	"github.com/pokt-network/pocket-core/crypto/signer"
+	sdk "github.com/pokt-network/pocket-core/types"
+)
+
+// the msg types of the signer
+const (
+	SignRelayResponse = signer.MsgTypeRelayResponse
+	SignClaim         = signer.MsgTypeClaim
+	SignProof         = signer.MsgTypeProof
+)
+
+var globalSigner Signer
+
+// "Signer" - Signs the relay responses and the claim / proof txs of the node, see the crypto/signer msg types (a relay
+// response is signed by its hash seed, see signer.SignBytes)
+type Signer interface {
+	PublicKey() crypto.PublicKey
+	Sign(msgType string, msg []byte) ([]byte, error)
+}
+
+// "KeySigner" - Signs with a private key held by the node
+type KeySigner struct {
+	Key crypto.PrivateKey
+}
+
+func (s KeySigner) PublicKey() crypto.PublicKey {
+	return s.Key.PublicKey()
+}
+
+func (s KeySigner) Sign(msgType string, msg []byte) ([]byte, error) {
+	return s.Key.Sign(signer.SignBytes(msgType, msg))
+}
+
+// "TxSigner" - Signs the sign bytes of txs of a single msg type with a signer (auth.TxSigner)
+type TxSigner struct {
+	Signer
+	MsgType string
+}
+
+func (s TxSigner) Sign(msg []byte) ([]byte, error) {
+	return s.Signer.Sign(s.MsgType, msg)
+}
+
+// "NewClaimTxSigner" - Returns the tx signer of claims
+func NewClaimTxSigner(s Signer) TxSigner {
+	return TxSigner{Signer: s, MsgType: SignClaim}
+}
+
+// "NewProofTxSigner" - Returns the tx signer of proofs
+func NewProofTxSigner(s Signer) TxSigner {
+	return TxSigner{Signer: s, MsgType: SignProof}
+}
+
+// "InitSigner" - Initializes the global signer e.g. a remote signer, replacing the private validator key
+func InitSigner(s Signer) {
+	globalSigner = s
+}
+
+// "GetSigner" - Returns the global signer or a signer of the private validator key when none is set
+func GetSigner() (Signer, sdk.Error) {
+	if globalSigner != nil {
+		return globalSigner, nil
+	}
+	pvKey, err := GetPVKeyFile()
+	if err != nil {
+		return nil, err
+	}
+	pk, er := crypto.PrivKeyToPrivateKey(pvKey.PrivKey)
+	if er != nil {
+		return nil, NewKeybaseError(ModuleName, er)
+	}
+	return KeySigner{Key: pk}, nil
+}
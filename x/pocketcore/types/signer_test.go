@@ -0,0 +1,50 @@
+package types
+
+import (
+	"testing"
+
+	cryptoSigner "github.com/pokt-network/pocket-core/crypto/signer"
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/privval"
+)
+
+type recordingSigner struct {
+	KeySigner
+	msgTypes []string
+}
+
+func (s *recordingSigner) Sign(msgType string, msg []byte) ([]byte, error) {
+	s.msgTypes = append(s.msgTypes, msgType)
+	return s.KeySigner.Sign(msgType, msg)
+}
+
+func TestGetSigner(t *testing.T) {
+	defer InitSigner(nil)
+	// falls back to the private validator key
+	pk := GetRandomPrivateKey()
+	InitPVKeyFile(privval.FilePVKey{Address: pk.PubKey().Address(), PubKey: pk.PubKey(), PrivKey: pk.PrivKey()})
+	defer InitPVKeyFile(privval.FilePVKey{})
+	signer, err := GetSigner()
+	assert.Nil(t, err)
+	assert.True(t, signer.PublicKey().Equals(pk.PublicKey()))
+	// a remote signer replaces it
+	remote := &recordingSigner{KeySigner: KeySigner{Key: GetRandomPrivateKey()}}
+	InitSigner(remote)
+	signer, err = GetSigner()
+	assert.Nil(t, err)
+	assert.True(t, signer.PublicKey().Equals(remote.PublicKey()))
+	// the tx signers name the msg type they sign
+	msg := []byte("sign bytes")
+	sig, er := NewClaimTxSigner(signer).Sign(msg)
+	assert.Nil(t, er)
+	assert.True(t, remote.PublicKey().VerifyBytes(msg, sig))
+	_, er = NewProofTxSigner(signer).Sign(msg)
+	assert.Nil(t, er)
+	// a relay response is signed by the hash of its hash seed
+	resp := RelayResponse{Response: "response", Proof: RelayProof{Entropy: 1}}
+	assert.Nil(t, cryptoSigner.ValidateMsg(SignRelayResponse, resp.HashSeed()))
+	sig, er = signer.Sign(SignRelayResponse, resp.HashSeed())
+	assert.Nil(t, er)
+	assert.True(t, remote.PublicKey().VerifyBytes(resp.Hash(), sig))
+	assert.Equal(t, []string{SignClaim, SignProof, SignRelayResponse}, remote.msgTypes)
+}